@@ -13,6 +13,9 @@ type AccessControllerImpl struct {
 type AccessController interface {
 	UpsertAccess(ctx context.Context, req service.UpsertAccessReq) error
 	GetAccessByRoleUID(ctx context.Context, req service.GetAccessByRoleUIDReq) ([]service.GetAccessByRoleUIDResp, error)
+	GetAccessHistory(ctx context.Context, req service.GetAccessHistoryReq) ([]service.AccessHistoryResp, error)
+	DiffAccessHistory(ctx context.Context, req service.DiffAccessHistoryReq) (service.DiffAccessHistoryResp, error)
+	RollbackAccess(ctx context.Context, req service.RollbackAccessReq) error
 }
 
 func NewAccessController(accessSvc usecase.AccessService) AccessController {
@@ -26,3 +29,15 @@ func (ac *AccessControllerImpl) UpsertAccess(ctx context.Context, req service.Up
 func (ac *AccessControllerImpl) GetAccessByRoleUID(ctx context.Context, req service.GetAccessByRoleUIDReq) ([]service.GetAccessByRoleUIDResp, error) {
 	return ac.accessSvc.GetAccessByRoleUID(ctx, req)
 }
+
+func (ac *AccessControllerImpl) GetAccessHistory(ctx context.Context, req service.GetAccessHistoryReq) ([]service.AccessHistoryResp, error) {
+	return ac.accessSvc.GetAccessHistory(ctx, req)
+}
+
+func (ac *AccessControllerImpl) DiffAccessHistory(ctx context.Context, req service.DiffAccessHistoryReq) (service.DiffAccessHistoryResp, error) {
+	return ac.accessSvc.DiffAccessHistory(ctx, req)
+}
+
+func (ac *AccessControllerImpl) RollbackAccess(ctx context.Context, req service.RollbackAccessReq) error {
+	return ac.accessSvc.RollbackAccess(ctx, req)
+}
@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/service/accessReviews/usecase"
+)
+
+type AccessReviewsControllerImpl struct {
+	accessReviewsSvc usecase.AccessReviewsService
+}
+
+type AccessReviewsController interface {
+	CreateAccessReview(ctx context.Context, req service.CreateAccessReviewReq) (service.AccessReviewResp, error)
+	GetAccessReviews(ctx context.Context, req service.GetAccessReviewsReq) ([]service.AccessReviewResp, error)
+	GetAccessReviewItems(ctx context.Context, req service.GetAccessReviewItemsReq) ([]service.AccessReviewItemResp, error)
+	DecideAccessReviewItem(ctx context.Context, req service.DecideAccessReviewItemReq) (service.AccessReviewItemResp, error)
+	ExportAccessReview(ctx context.Context, req service.ExportAccessReviewReq) (service.AccessReviewReportResp, error)
+}
+
+func NewAccessReviewsController(accessReviewsSvc usecase.AccessReviewsService) AccessReviewsController {
+	return &AccessReviewsControllerImpl{accessReviewsSvc: accessReviewsSvc}
+}
+
+func (ac *AccessReviewsControllerImpl) CreateAccessReview(ctx context.Context, req service.CreateAccessReviewReq) (service.AccessReviewResp, error) {
+	return ac.accessReviewsSvc.CreateAccessReview(ctx, req)
+}
+
+func (ac *AccessReviewsControllerImpl) GetAccessReviews(ctx context.Context, req service.GetAccessReviewsReq) ([]service.AccessReviewResp, error) {
+	return ac.accessReviewsSvc.GetAccessReviews(ctx, req)
+}
+
+func (ac *AccessReviewsControllerImpl) GetAccessReviewItems(ctx context.Context, req service.GetAccessReviewItemsReq) ([]service.AccessReviewItemResp, error) {
+	return ac.accessReviewsSvc.GetAccessReviewItems(ctx, req)
+}
+
+func (ac *AccessReviewsControllerImpl) DecideAccessReviewItem(ctx context.Context, req service.DecideAccessReviewItemReq) (service.AccessReviewItemResp, error) {
+	return ac.accessReviewsSvc.DecideAccessReviewItem(ctx, req)
+}
+
+func (ac *AccessReviewsControllerImpl) ExportAccessReview(ctx context.Context, req service.ExportAccessReviewReq) (service.AccessReviewReportResp, error) {
+	return ac.accessReviewsSvc.ExportAccessReview(ctx, req)
+}
@@ -1,10 +1,19 @@
 package controller
 
 type AppController struct {
-	AccessController    interface{ AccessController }
-	AuthzController     interface{ AuthzController }
-	JWKController       interface{ JWKController }
-	UsersController     interface{ UsersController }
-	ResourcesController interface{ ResourcesController }
-	RolesController     interface{ RolesController }
+	AccessController          interface{ AccessController }
+	AccessReviewsController   interface{ AccessReviewsController }
+	AuthzController           interface{ AuthzController }
+	BlobsController           interface{ BlobsController }
+	ElevationsController      interface{ ElevationsController }
+	ExpertisesController      interface{ ExpertisesController }
+	JWKController             interface{ JWKController }
+	MentorsController         interface{ MentorsController }
+	NotificationsController   interface{ NotificationsController }
+	OrganizationsController   interface{ OrganizationsController }
+	UsersController           interface{ UsersController }
+	ResourcesController       interface{ ResourcesController }
+	RoleConstraintsController interface{ RoleConstraintsController }
+	RolesController           interface{ RolesController }
+	RBACController            interface{ RBACController }
 }
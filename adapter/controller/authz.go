@@ -12,8 +12,13 @@ type AuthzControllerImpl struct {
 
 type AuthzController interface {
 	Login(ctx context.Context, req service.LoginReq) (resp service.LoginResp, err error)
+	RefreshToken(ctx context.Context, req service.RefreshTokenReq) (resp service.LoginResp, err error)
 	Logout(ctx context.Context, req service.LogoutReq) error
 	HasAuthenticated(ctx context.Context, req service.HasAuthenticatedReq) (resp service.HasAuthenticatedResp, err error)
+	Authorize(ctx context.Context, req service.AuthorizeReq) (resp service.AuthorizeResp, err error)
+	CheckPermission(ctx context.Context, req service.CheckPermissionReq) (resp service.CheckPermissionResp, err error)
+	CheckPermissionBatch(ctx context.Context, req service.CheckPermissionBatchReq) (resp service.CheckPermissionBatchResp, err error)
+	GrantRole(ctx context.Context, req service.GrantRoleReq) (resp service.GrantRoleResp, err error)
 }
 
 func NewAuthzController(authzSvc usecase.AuthzService) AuthzController {
@@ -26,6 +31,10 @@ func (ac *AuthzControllerImpl) Login(ctx context.Context, req service.LoginReq)
 	return ac.authzSvc.Login(ctx, req)
 }
 
+func (ac *AuthzControllerImpl) RefreshToken(ctx context.Context, req service.RefreshTokenReq) (resp service.LoginResp, err error) {
+	return ac.authzSvc.RefreshToken(ctx, req)
+}
+
 func (ac *AuthzControllerImpl) Logout(ctx context.Context, req service.LogoutReq) error {
 	return ac.authzSvc.Logout(ctx, req)
 }
@@ -33,3 +42,19 @@ func (ac *AuthzControllerImpl) Logout(ctx context.Context, req service.LogoutReq
 func (ac *AuthzControllerImpl) HasAuthenticated(ctx context.Context, req service.HasAuthenticatedReq) (resp service.HasAuthenticatedResp, err error) {
 	return ac.authzSvc.HasAuthenticated(ctx, req)
 }
+
+func (ac *AuthzControllerImpl) Authorize(ctx context.Context, req service.AuthorizeReq) (resp service.AuthorizeResp, err error) {
+	return ac.authzSvc.Authorize(ctx, req)
+}
+
+func (ac *AuthzControllerImpl) CheckPermission(ctx context.Context, req service.CheckPermissionReq) (resp service.CheckPermissionResp, err error) {
+	return ac.authzSvc.CheckPermission(ctx, req)
+}
+
+func (ac *AuthzControllerImpl) CheckPermissionBatch(ctx context.Context, req service.CheckPermissionBatchReq) (resp service.CheckPermissionBatchResp, err error) {
+	return ac.authzSvc.CheckPermissionBatch(ctx, req)
+}
+
+func (ac *AuthzControllerImpl) GrantRole(ctx context.Context, req service.GrantRoleReq) (resp service.GrantRoleResp, err error) {
+	return ac.authzSvc.GrantRole(ctx, req)
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/service/blobs/usecase"
+)
+
+type BlobsControllerImpl struct {
+	blobsSvc usecase.BlobsService
+}
+
+type BlobsController interface {
+	GetBlob(ctx context.Context, req service.GetBlobReq) (service.BlobResp, error)
+}
+
+func NewBlobsController(blobsSvc usecase.BlobsService) BlobsController {
+	return &BlobsControllerImpl{blobsSvc: blobsSvc}
+}
+
+func (bc *BlobsControllerImpl) GetBlob(ctx context.Context, req service.GetBlobReq) (service.BlobResp, error) {
+	return bc.blobsSvc.GetBlob(ctx, req)
+}
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/service/elevations/usecase"
+)
+
+type ElevationsControllerImpl struct {
+	elevationsSvc usecase.ElevationsService
+}
+
+type ElevationsController interface {
+	CreateElevation(ctx context.Context, req service.CreateElevationReq) (service.ElevationResp, error)
+	GetElevations(ctx context.Context, req service.GetElevationsReq) ([]service.ElevationResp, error)
+	ApproveElevation(ctx context.Context, req service.DecideElevationReq) (service.ElevationResp, error)
+	DenyElevation(ctx context.Context, req service.DecideElevationReq) (service.ElevationResp, error)
+}
+
+func NewElevationsController(elevationsSvc usecase.ElevationsService) ElevationsController {
+	return &ElevationsControllerImpl{elevationsSvc: elevationsSvc}
+}
+
+func (ec *ElevationsControllerImpl) CreateElevation(ctx context.Context, req service.CreateElevationReq) (service.ElevationResp, error) {
+	return ec.elevationsSvc.CreateElevation(ctx, req)
+}
+
+func (ec *ElevationsControllerImpl) GetElevations(ctx context.Context, req service.GetElevationsReq) ([]service.ElevationResp, error) {
+	return ec.elevationsSvc.GetElevations(ctx, req)
+}
+
+func (ec *ElevationsControllerImpl) ApproveElevation(ctx context.Context, req service.DecideElevationReq) (service.ElevationResp, error) {
+	return ec.elevationsSvc.ApproveElevation(ctx, req)
+}
+
+func (ec *ElevationsControllerImpl) DenyElevation(ctx context.Context, req service.DecideElevationReq) (service.ElevationResp, error) {
+	return ec.elevationsSvc.DenyElevation(ctx, req)
+}
@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/service/expertises/usecase"
+)
+
+type ExpertisesControllerImpl struct {
+	expertisesSvc usecase.ExpertisesService
+}
+
+type ExpertisesController interface {
+	CreateExpertise(ctx context.Context, req service.CreateExpertiseReq) (service.ExpertiseResp, error)
+	GetExpertises(ctx context.Context) ([]service.ExpertiseResp, error)
+	AutocompleteExpertises(ctx context.Context, req service.AutocompleteExpertisesReq) ([]service.ExpertiseTagResp, error)
+	UpdateExpertise(ctx context.Context, req service.UpdateExpertiseReq) (service.ExpertiseResp, error)
+	DeleteExpertise(ctx context.Context, req service.DeleteExpertiseReq) error
+}
+
+func NewExpertisesController(expertisesSvc usecase.ExpertisesService) ExpertisesController {
+	return &ExpertisesControllerImpl{expertisesSvc: expertisesSvc}
+}
+
+func (ec *ExpertisesControllerImpl) CreateExpertise(ctx context.Context, req service.CreateExpertiseReq) (service.ExpertiseResp, error) {
+	return ec.expertisesSvc.CreateExpertise(ctx, req)
+}
+
+func (ec *ExpertisesControllerImpl) GetExpertises(ctx context.Context) ([]service.ExpertiseResp, error) {
+	return ec.expertisesSvc.GetExpertises(ctx)
+}
+
+func (ec *ExpertisesControllerImpl) AutocompleteExpertises(ctx context.Context, req service.AutocompleteExpertisesReq) ([]service.ExpertiseTagResp, error) {
+	return ec.expertisesSvc.AutocompleteExpertises(ctx, req)
+}
+
+func (ec *ExpertisesControllerImpl) UpdateExpertise(ctx context.Context, req service.UpdateExpertiseReq) (service.ExpertiseResp, error) {
+	return ec.expertisesSvc.UpdateExpertise(ctx, req)
+}
+
+func (ec *ExpertisesControllerImpl) DeleteExpertise(ctx context.Context, req service.DeleteExpertiseReq) error {
+	return ec.expertisesSvc.DeleteExpertise(ctx, req)
+}
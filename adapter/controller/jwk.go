@@ -12,6 +12,7 @@ type JWKControllerImpl struct {
 
 type JWKController interface {
 	VerifyJWT(ctx context.Context, req service.VerifyTokenReq) (resp service.VerifyTokenResp, err error)
+	VerifyRefreshJWT(ctx context.Context, req service.VerifyTokenReq) (resp service.RefreshTokenReq, err error)
 }
 
 func NewJWKController(jwkSvc usecase.JWKService) JWKController {
@@ -23,3 +24,7 @@ func NewJWKController(jwkSvc usecase.JWKService) JWKController {
 func (jc *JWKControllerImpl) VerifyJWT(ctx context.Context, req service.VerifyTokenReq) (resp service.VerifyTokenResp, err error) {
 	return jc.jwkSvc.VerifyJWT(ctx, req)
 }
+
+func (jc *JWKControllerImpl) VerifyRefreshJWT(ctx context.Context, req service.VerifyTokenReq) (resp service.RefreshTokenReq, err error) {
+	return jc.jwkSvc.VerifyRefreshJWT(ctx, req)
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/service/mentors/usecase"
+)
+
+type MentorsControllerImpl struct {
+	mentorsSvc usecase.MentorsService
+}
+
+type MentorsController interface {
+	SearchMentors(ctx context.Context, req service.SearchMentorsReq) (service.SearchMentorsResp, error)
+}
+
+func NewMentorsController(mentorsSvc usecase.MentorsService) MentorsController {
+	return &MentorsControllerImpl{mentorsSvc: mentorsSvc}
+}
+
+func (mc *MentorsControllerImpl) SearchMentors(ctx context.Context, req service.SearchMentorsReq) (service.SearchMentorsResp, error) {
+	return mc.mentorsSvc.SearchMentors(ctx, req)
+}
@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/service/notifications/usecase"
+)
+
+type NotificationsControllerImpl struct {
+	notificationsSvc usecase.NotificationsService
+}
+
+type NotificationsController interface {
+	GetNotifications(ctx context.Context, req service.GetNotificationsReq) ([]service.NotificationResp, error)
+	ReadNotification(ctx context.Context, req service.ReadNotificationReq) error
+}
+
+func NewNotificationsController(notificationsSvc usecase.NotificationsService) NotificationsController {
+	return &NotificationsControllerImpl{notificationsSvc: notificationsSvc}
+}
+
+func (nc *NotificationsControllerImpl) GetNotifications(ctx context.Context, req service.GetNotificationsReq) ([]service.NotificationResp, error) {
+	return nc.notificationsSvc.GetNotifications(ctx, req)
+}
+
+func (nc *NotificationsControllerImpl) ReadNotification(ctx context.Context, req service.ReadNotificationReq) error {
+	return nc.notificationsSvc.ReadNotification(ctx, req)
+}
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/service/organizations/usecase"
+)
+
+type OrganizationsControllerImpl struct {
+	organizationsSvc usecase.OrganizationsService
+}
+
+type OrganizationsController interface {
+	CreateOrganization(ctx context.Context, req service.CreateOrganizationReq) (service.OrganizationResp, error)
+	GetOrganizations(ctx context.Context) ([]service.OrganizationResp, error)
+	ResolveOrganization(ctx context.Context, req service.ResolveOrganizationReq) (service.OrganizationResp, error)
+}
+
+func NewOrganizationsController(organizationsSvc usecase.OrganizationsService) OrganizationsController {
+	return &OrganizationsControllerImpl{organizationsSvc: organizationsSvc}
+}
+
+func (oc *OrganizationsControllerImpl) CreateOrganization(ctx context.Context, req service.CreateOrganizationReq) (service.OrganizationResp, error) {
+	return oc.organizationsSvc.CreateOrganization(ctx, req)
+}
+
+func (oc *OrganizationsControllerImpl) GetOrganizations(ctx context.Context) ([]service.OrganizationResp, error) {
+	return oc.organizationsSvc.GetOrganizations(ctx)
+}
+
+func (oc *OrganizationsControllerImpl) ResolveOrganization(ctx context.Context, req service.ResolveOrganizationReq) (service.OrganizationResp, error) {
+	return oc.organizationsSvc.ResolveOrganization(ctx, req)
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/service/rbac/usecase"
+)
+
+type RBACControllerImpl struct {
+	rbacSvc usecase.RBACService
+}
+
+type RBACController interface {
+	ApplyRBAC(ctx context.Context, req service.ApplyRBACReq) (service.ApplyRBACResp, error)
+}
+
+func NewRBACController(rbacSvc usecase.RBACService) RBACController {
+	return &RBACControllerImpl{rbacSvc: rbacSvc}
+}
+
+func (rc *RBACControllerImpl) ApplyRBAC(ctx context.Context, req service.ApplyRBACReq) (service.ApplyRBACResp, error) {
+	return rc.rbacSvc.ApplyRBAC(ctx, req)
+}
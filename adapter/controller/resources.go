@@ -13,6 +13,8 @@ type ResourcesControllerImpl struct {
 type ResourcesController interface {
 	CreateResources(ctx context.Context, req service.CreateResourcesReq) error
 	GetResourcesByType(ctx context.Context, req service.GetResourcesByTypeReq) ([]service.GetResourcesByTypeResp, error)
+	SyncAPIResources(ctx context.Context, req service.SyncAPIResourcesReq) (service.SyncAPIResourcesResp, error)
+	GetResources(ctx context.Context, req service.ListReq) (service.GetResourcesResp, error)
 }
 
 func NewResourcesController(resourcesSvc usecase.ResourcesService) ResourcesController {
@@ -26,3 +28,11 @@ func (rc *ResourcesControllerImpl) CreateResources(ctx context.Context, req serv
 func (rc *ResourcesControllerImpl) GetResourcesByType(ctx context.Context, req service.GetResourcesByTypeReq) ([]service.GetResourcesByTypeResp, error) {
 	return rc.resourcesSvc.GetResourcesByType(ctx, req)
 }
+
+func (rc *ResourcesControllerImpl) SyncAPIResources(ctx context.Context, req service.SyncAPIResourcesReq) (service.SyncAPIResourcesResp, error) {
+	return rc.resourcesSvc.SyncAPIResources(ctx, req)
+}
+
+func (rc *ResourcesControllerImpl) GetResources(ctx context.Context, req service.ListReq) (service.GetResourcesResp, error) {
+	return rc.resourcesSvc.GetResources(ctx, req)
+}
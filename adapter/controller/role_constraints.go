@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/service/roleConstraints/usecase"
+)
+
+type RoleConstraintsControllerImpl struct {
+	roleConstraintsSvc usecase.RoleConstraintsService
+}
+
+type RoleConstraintsController interface {
+	CreateRoleConstraint(ctx context.Context, req service.CreateRoleConstraintReq) (service.RoleConstraintResp, error)
+	GetRoleConstraints(ctx context.Context) ([]service.RoleConstraintResp, error)
+	DeleteRoleConstraint(ctx context.Context, req service.DeleteRoleConstraintReq) error
+	GetRoleConstraintViolations(ctx context.Context) ([]service.RoleConstraintViolationResp, error)
+}
+
+func NewRoleConstraintsController(roleConstraintsSvc usecase.RoleConstraintsService) RoleConstraintsController {
+	return &RoleConstraintsControllerImpl{roleConstraintsSvc: roleConstraintsSvc}
+}
+
+func (rc *RoleConstraintsControllerImpl) CreateRoleConstraint(ctx context.Context, req service.CreateRoleConstraintReq) (service.RoleConstraintResp, error) {
+	return rc.roleConstraintsSvc.CreateRoleConstraint(ctx, req)
+}
+
+func (rc *RoleConstraintsControllerImpl) GetRoleConstraints(ctx context.Context) ([]service.RoleConstraintResp, error) {
+	return rc.roleConstraintsSvc.GetRoleConstraints(ctx)
+}
+
+func (rc *RoleConstraintsControllerImpl) DeleteRoleConstraint(ctx context.Context, req service.DeleteRoleConstraintReq) error {
+	return rc.roleConstraintsSvc.DeleteRoleConstraint(ctx, req)
+}
+
+func (rc *RoleConstraintsControllerImpl) GetRoleConstraintViolations(ctx context.Context) ([]service.RoleConstraintViolationResp, error) {
+	return rc.roleConstraintsSvc.GetRoleConstraintViolations(ctx)
+}
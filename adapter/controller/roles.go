@@ -13,6 +13,7 @@ type RolesControllerImpl struct {
 
 type RolesController interface {
 	CreateRoles(ctx context.Context, req service.CreateRolesReq) error
+	GetRoles(ctx context.Context, req service.ListReq) (service.GetRolesResp, error)
 }
 
 func NewRolesController(rolesSvc usecase.RolesService) RolesController {
@@ -25,3 +26,7 @@ func (rc *RolesControllerImpl) CreateRoles(ctx context.Context, req service.Crea
 
 	return rc.rolesSvc.CreateRoles(ctx, req)
 }
+
+func (rc *RolesControllerImpl) GetRoles(ctx context.Context, req service.ListReq) (service.GetRolesResp, error) {
+	return rc.rolesSvc.GetRoles(ctx, req)
+}
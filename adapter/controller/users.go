@@ -12,7 +12,47 @@ type UsersControllerImpl struct {
 
 type UsersController interface {
 	CreateUsers(ctx context.Context, req service.CreateUsersReq) error
-	GetUsersWithPagination(ctx context.Context, req service.GetUsersWithPaginationReq) (service.GetUsersWithPaginationResp, error)
+	GetUsersWithPagination(ctx context.Context, req service.ListReq) (service.GetUsersWithPaginationResp, error)
+	GetUser(ctx context.Context, req service.GetUserReq) (service.UserDetailResp, error)
+	UpdateProfile(ctx context.Context, req service.UpdateProfileReq) (service.UserDetailResp, error)
+	DeleteUser(ctx context.Context, req service.DeleteUserReq) error
+	RestoreUser(ctx context.Context, req service.RestoreUserReq) (service.UserDetailResp, error)
+	GetUserExpertises(ctx context.Context, req service.GetProfileSectionReq) ([]service.ExpertiseTagResp, error)
+	SetUserExpertises(ctx context.Context, req service.SetUserExpertisesReq) ([]service.ExpertiseTagResp, error)
+	UploadPhoto(ctx context.Context, req service.UploadPhotoReq) (service.PhotoResp, error)
+	DeletePhoto(ctx context.Context, req service.DeletePhotoReq) error
+	ImportUsers(ctx context.Context, req service.ImportUsersReq) (service.ImportUsersResp, error)
+	ExportUsers(ctx context.Context, req service.ExportUsersReq) error
+	ChangePassword(ctx context.Context, req service.ChangePasswordReq) error
+	SuspendUser(ctx context.Context, req service.UpdateUserStatusReq) (service.UserDetailResp, error)
+	ReactivateUser(ctx context.Context, req service.UpdateUserStatusReq) (service.UserDetailResp, error)
+	RequestDataExport(ctx context.Context, req service.DataRequestReq) (service.DataRequestResp, error)
+	RequestErasure(ctx context.Context, req service.DataRequestReq) (service.DataRequestResp, error)
+	GetDataRequests(ctx context.Context, req service.GetDataRequestsReq) ([]service.DataRequestResp, error)
+	EraseUser(ctx context.Context, req service.EraseUserReq) (service.DataRequestResp, error)
+
+	GetEducations(ctx context.Context, req service.GetProfileSectionReq) ([]service.EducationResp, error)
+	CreateEducation(ctx context.Context, req service.EducationReq) ([]service.EducationResp, error)
+	UpdateEducation(ctx context.Context, req service.EducationReq) ([]service.EducationResp, error)
+	DeleteEducation(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.EducationResp, error)
+	ReorderEducations(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.EducationResp, error)
+
+	GetWorkExperiences(ctx context.Context, req service.GetProfileSectionReq) ([]service.WorkExperienceResp, error)
+	CreateWorkExperience(ctx context.Context, req service.WorkExperienceReq) ([]service.WorkExperienceResp, error)
+	UpdateWorkExperience(ctx context.Context, req service.WorkExperienceReq) ([]service.WorkExperienceResp, error)
+	DeleteWorkExperience(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.WorkExperienceResp, error)
+	ReorderWorkExperiences(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.WorkExperienceResp, error)
+
+	GetLanguages(ctx context.Context, req service.GetProfileSectionReq) ([]service.LanguageResp, error)
+	CreateLanguage(ctx context.Context, req service.LanguageReq) ([]service.LanguageResp, error)
+	DeleteLanguage(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.LanguageResp, error)
+	ReorderLanguages(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.LanguageResp, error)
+
+	GetSocials(ctx context.Context, req service.GetProfileSectionReq) ([]service.SocialResp, error)
+	CreateSocial(ctx context.Context, req service.SocialReq) ([]service.SocialResp, error)
+	UpdateSocial(ctx context.Context, req service.SocialReq) ([]service.SocialResp, error)
+	DeleteSocial(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.SocialResp, error)
+	ReorderSocials(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.SocialResp, error)
 }
 
 func NewUsersController(userSvc usecase.UsersService) UsersController {
@@ -23,6 +63,150 @@ func (uc *UsersControllerImpl) CreateUsers(ctx context.Context, req service.Crea
 	return uc.usersSvc.CreateUsers(ctx, req)
 }
 
-func (uc *UsersControllerImpl) GetUsersWithPagination(ctx context.Context, req service.GetUsersWithPaginationReq) (service.GetUsersWithPaginationResp, error) {
+func (uc *UsersControllerImpl) GetUsersWithPagination(ctx context.Context, req service.ListReq) (service.GetUsersWithPaginationResp, error) {
 	return uc.usersSvc.GetUsersWithPagination(ctx, req)
 }
+
+func (uc *UsersControllerImpl) GetUser(ctx context.Context, req service.GetUserReq) (service.UserDetailResp, error) {
+	return uc.usersSvc.GetUser(ctx, req)
+}
+
+func (uc *UsersControllerImpl) UpdateProfile(ctx context.Context, req service.UpdateProfileReq) (service.UserDetailResp, error) {
+	return uc.usersSvc.UpdateProfile(ctx, req)
+}
+
+func (uc *UsersControllerImpl) DeleteUser(ctx context.Context, req service.DeleteUserReq) error {
+	return uc.usersSvc.DeleteUser(ctx, req)
+}
+
+func (uc *UsersControllerImpl) RestoreUser(ctx context.Context, req service.RestoreUserReq) (service.UserDetailResp, error) {
+	return uc.usersSvc.RestoreUser(ctx, req)
+}
+
+func (uc *UsersControllerImpl) GetUserExpertises(ctx context.Context, req service.GetProfileSectionReq) ([]service.ExpertiseTagResp, error) {
+	return uc.usersSvc.GetUserExpertises(ctx, req)
+}
+
+func (uc *UsersControllerImpl) SetUserExpertises(ctx context.Context, req service.SetUserExpertisesReq) ([]service.ExpertiseTagResp, error) {
+	return uc.usersSvc.SetUserExpertises(ctx, req)
+}
+
+func (uc *UsersControllerImpl) GetEducations(ctx context.Context, req service.GetProfileSectionReq) ([]service.EducationResp, error) {
+	return uc.usersSvc.GetEducations(ctx, req)
+}
+
+func (uc *UsersControllerImpl) CreateEducation(ctx context.Context, req service.EducationReq) ([]service.EducationResp, error) {
+	return uc.usersSvc.CreateEducation(ctx, req)
+}
+
+func (uc *UsersControllerImpl) UpdateEducation(ctx context.Context, req service.EducationReq) ([]service.EducationResp, error) {
+	return uc.usersSvc.UpdateEducation(ctx, req)
+}
+
+func (uc *UsersControllerImpl) DeleteEducation(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.EducationResp, error) {
+	return uc.usersSvc.DeleteEducation(ctx, req)
+}
+
+func (uc *UsersControllerImpl) ReorderEducations(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.EducationResp, error) {
+	return uc.usersSvc.ReorderEducations(ctx, req)
+}
+
+func (uc *UsersControllerImpl) GetWorkExperiences(ctx context.Context, req service.GetProfileSectionReq) ([]service.WorkExperienceResp, error) {
+	return uc.usersSvc.GetWorkExperiences(ctx, req)
+}
+
+func (uc *UsersControllerImpl) CreateWorkExperience(ctx context.Context, req service.WorkExperienceReq) ([]service.WorkExperienceResp, error) {
+	return uc.usersSvc.CreateWorkExperience(ctx, req)
+}
+
+func (uc *UsersControllerImpl) UpdateWorkExperience(ctx context.Context, req service.WorkExperienceReq) ([]service.WorkExperienceResp, error) {
+	return uc.usersSvc.UpdateWorkExperience(ctx, req)
+}
+
+func (uc *UsersControllerImpl) DeleteWorkExperience(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.WorkExperienceResp, error) {
+	return uc.usersSvc.DeleteWorkExperience(ctx, req)
+}
+
+func (uc *UsersControllerImpl) ReorderWorkExperiences(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.WorkExperienceResp, error) {
+	return uc.usersSvc.ReorderWorkExperiences(ctx, req)
+}
+
+func (uc *UsersControllerImpl) GetLanguages(ctx context.Context, req service.GetProfileSectionReq) ([]service.LanguageResp, error) {
+	return uc.usersSvc.GetLanguages(ctx, req)
+}
+
+func (uc *UsersControllerImpl) CreateLanguage(ctx context.Context, req service.LanguageReq) ([]service.LanguageResp, error) {
+	return uc.usersSvc.CreateLanguage(ctx, req)
+}
+
+func (uc *UsersControllerImpl) DeleteLanguage(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.LanguageResp, error) {
+	return uc.usersSvc.DeleteLanguage(ctx, req)
+}
+
+func (uc *UsersControllerImpl) ReorderLanguages(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.LanguageResp, error) {
+	return uc.usersSvc.ReorderLanguages(ctx, req)
+}
+
+func (uc *UsersControllerImpl) GetSocials(ctx context.Context, req service.GetProfileSectionReq) ([]service.SocialResp, error) {
+	return uc.usersSvc.GetSocials(ctx, req)
+}
+
+func (uc *UsersControllerImpl) CreateSocial(ctx context.Context, req service.SocialReq) ([]service.SocialResp, error) {
+	return uc.usersSvc.CreateSocial(ctx, req)
+}
+
+func (uc *UsersControllerImpl) UpdateSocial(ctx context.Context, req service.SocialReq) ([]service.SocialResp, error) {
+	return uc.usersSvc.UpdateSocial(ctx, req)
+}
+
+func (uc *UsersControllerImpl) DeleteSocial(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.SocialResp, error) {
+	return uc.usersSvc.DeleteSocial(ctx, req)
+}
+
+func (uc *UsersControllerImpl) ReorderSocials(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.SocialResp, error) {
+	return uc.usersSvc.ReorderSocials(ctx, req)
+}
+
+func (uc *UsersControllerImpl) UploadPhoto(ctx context.Context, req service.UploadPhotoReq) (service.PhotoResp, error) {
+	return uc.usersSvc.UploadPhoto(ctx, req)
+}
+
+func (uc *UsersControllerImpl) DeletePhoto(ctx context.Context, req service.DeletePhotoReq) error {
+	return uc.usersSvc.DeletePhoto(ctx, req)
+}
+
+func (uc *UsersControllerImpl) ImportUsers(ctx context.Context, req service.ImportUsersReq) (service.ImportUsersResp, error) {
+	return uc.usersSvc.ImportUsers(ctx, req)
+}
+
+func (uc *UsersControllerImpl) ExportUsers(ctx context.Context, req service.ExportUsersReq) error {
+	return uc.usersSvc.ExportUsers(ctx, req)
+}
+
+func (uc *UsersControllerImpl) ChangePassword(ctx context.Context, req service.ChangePasswordReq) error {
+	return uc.usersSvc.ChangePassword(ctx, req)
+}
+
+func (uc *UsersControllerImpl) SuspendUser(ctx context.Context, req service.UpdateUserStatusReq) (service.UserDetailResp, error) {
+	return uc.usersSvc.SuspendUser(ctx, req)
+}
+
+func (uc *UsersControllerImpl) ReactivateUser(ctx context.Context, req service.UpdateUserStatusReq) (service.UserDetailResp, error) {
+	return uc.usersSvc.ReactivateUser(ctx, req)
+}
+
+func (uc *UsersControllerImpl) RequestDataExport(ctx context.Context, req service.DataRequestReq) (service.DataRequestResp, error) {
+	return uc.usersSvc.RequestDataExport(ctx, req)
+}
+
+func (uc *UsersControllerImpl) RequestErasure(ctx context.Context, req service.DataRequestReq) (service.DataRequestResp, error) {
+	return uc.usersSvc.RequestErasure(ctx, req)
+}
+
+func (uc *UsersControllerImpl) GetDataRequests(ctx context.Context, req service.GetDataRequestsReq) ([]service.DataRequestResp, error) {
+	return uc.usersSvc.GetDataRequests(ctx, req)
+}
+
+func (uc *UsersControllerImpl) EraseUser(ctx context.Context, req service.EraseUserReq) (service.DataRequestResp, error) {
+	return uc.usersSvc.EraseUser(ctx, req)
+}
@@ -1,15 +1,24 @@
 package cmd
 
 import (
+	"context"
 	"github/yogabagas/join-app/config"
+	repo "github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
 	"github/yogabagas/join-app/pkg/cache/redis"
 	"github/yogabagas/join-app/pkg/database/sql"
+	"github/yogabagas/join-app/registry"
 	"github/yogabagas/join-app/shared/constant"
+	"log"
 	"net/url"
+	"os"
+
+	"github.com/joho/godotenv"
 )
 
 var (
 	configURL string
+	orgKey    string
 
 	sqlDB       = sql.DBConn
 	redisClient = redis.CacheConn
@@ -27,3 +36,46 @@ func InitCache() (*redis.Cache, error) {
 
 	return redis.NewCache(&redisCreds)
 }
+
+// loadModules loads the environment and config, then connects the SQL and
+// cache modules used by every command.
+func loadModules() {
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		log.Fatalln("can't load env", err)
+		os.Exit(1)
+	}
+
+	config.LoadConfig(configURL)
+
+	sqlDB, err = InitSQLModule()
+	if err != nil {
+		log.Fatalln("can't connect database", err)
+	}
+
+	redisClient, err = InitCache()
+	if err != nil {
+		log.Fatalln("can't connect cache", err)
+	}
+}
+
+// tenantContext scopes ctx to the organization named by --org, by uid or slug.
+func tenantContext(ctx context.Context) context.Context {
+
+	org, err := registry.NewRegistry(registry.NewSQLConn(sqlDB.MySQL)).NewAppController().
+		OrganizationsController.ResolveOrganization(ctx, service.ResolveOrganizationReq{
+		Key: orgKey,
+	})
+	if err != nil {
+		log.Fatalln("can't resolve organization", err)
+	}
+
+	return repo.WithTenant(ctx, org.UID)
+}
+
+// organizations lists every organization, for jobs that run for each tenant.
+func organizations(ctx context.Context) ([]service.OrganizationResp, error) {
+	return registry.NewRegistry(registry.NewSQLConn(sqlDB.MySQL)).NewAppController().
+		OrganizationsController.GetOrganizations(ctx)
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/registry"
+	"github/yogabagas/join-app/shared/constant"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// The organizations are managed from the CLI only, as the tenants can't see
+// one another through the API.
+var (
+	organizationName string
+	organizationSlug string
+
+	organizationsCmd = &cobra.Command{
+		Use:   "organizations",
+		Short: "Manage the organizations, the tenants of the app",
+	}
+
+	organizationsCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Create an organization, its roles and resources being seeded with rbac apply --org",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			loadModules()
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+
+			req := service.CreateOrganizationReq{
+				Name:      organizationName,
+				Slug:      organizationSlug,
+				CreatedBy: constant.System,
+			}
+
+			if err := req.Validate(); err != nil {
+				log.Fatalln("invalid organization", err)
+			}
+
+			org, err := registry.NewRegistry(registry.NewSQLConn(sqlDB.MySQL)).NewAppController().
+				OrganizationsController.CreateOrganization(context.Background(), req)
+			if err != nil {
+				log.Fatalln("error create organization", err)
+			}
+
+			fmt.Printf("organization %s created: %s\n", org.Slug, org.UID)
+		},
+	}
+
+	organizationsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List every organization",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			loadModules()
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+
+			orgs, err := organizations(context.Background())
+			if err != nil {
+				log.Fatalln("error read organizations", err)
+			}
+
+			for _, v := range orgs {
+				fmt.Printf("%s\t%s\t%s\n", v.UID, v.Slug, v.Name)
+			}
+		},
+	}
+)
+
+func init() {
+	organizationsCreateCmd.Flags().StringVar(&organizationName, "name", "", "name of the organization")
+	organizationsCreateCmd.Flags().StringVar(&organizationSlug, "slug", "", "slug of the organization, sent in the X-Organization header")
+	organizationsCreateCmd.MarkFlagRequired("name")
+	organizationsCreateCmd.MarkFlagRequired("slug")
+
+	organizationsCmd.AddCommand(organizationsCreateCmd)
+	organizationsCmd.AddCommand(organizationsListCmd)
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/registry"
+	"github/yogabagas/join-app/shared/constant"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	manifestPath string
+	rbacDryRun   bool
+
+	rbacCmd = &cobra.Command{
+		Use:   "rbac",
+		Short: "Manage roles, resources and grants declaratively",
+	}
+
+	rbacApplyCmd = &cobra.Command{
+		Use:   "apply",
+		Short: "Apply an RBAC manifest, printing the difference with the database",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			loadModules()
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+
+			manifest, err := readManifest(manifestPath)
+			if err != nil {
+				log.Fatalln("can't read manifest", err)
+			}
+
+			reg := registry.NewRegistry(
+				registry.NewSQLConn(sqlDB.MySQL),
+				registry.NewCache(redisClient.Client),
+			)
+
+			resp, err := reg.NewAppController().RBACController.ApplyRBAC(tenantContext(context.Background()), service.ApplyRBACReq{
+				Manifest:  manifest,
+				DryRun:    rbacDryRun,
+				CreatedBy: constant.System,
+			})
+			if err != nil {
+				log.Fatalln("error apply rbac manifest", err)
+			}
+
+			printRBACDiff(resp)
+		},
+	}
+)
+
+func init() {
+	rbacApplyCmd.Flags().StringVarP(&manifestPath, "file", "f", "database/seed/rbac.yaml", "RBAC manifest, YAML or JSON")
+	rbacApplyCmd.Flags().BoolVar(&rbacDryRun, "dry-run", false, "print the changes without writing them")
+	rbacApplyCmd.Flags().StringVar(&orgKey, "org", constant.DefaultOrganization, "organization uid or slug to apply the manifest to")
+
+	rbacCmd.AddCommand(rbacApplyCmd)
+}
+
+func readManifest(path string) (manifest service.RBACManifest, err error) {
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return manifest, err
+	}
+
+	switch filepath.Ext(path) {
+	case ".json":
+		err = json.Unmarshal(b, &manifest)
+	default:
+		err = yaml.UnmarshalStrict(b, &manifest)
+	}
+
+	return manifest, err
+}
+
+func printRBACDiff(resp service.ApplyRBACResp) {
+
+	for _, v := range resp.Roles {
+		sign := map[string]string{"add": "+", "update": "~"}[v.Op]
+
+		if v.Parent != "" {
+			fmt.Printf("%s role     %s under %s\n", sign, v.Name, v.Parent)
+			continue
+		}
+		fmt.Printf("%s role     %s\n", sign, v.Name)
+	}
+
+	for _, v := range resp.Resources {
+		if v.Parent != "" {
+			fmt.Printf("+ resource %s %q (%s) under %q\n", v.Type, v.Name, v.Action, v.Parent)
+			continue
+		}
+		fmt.Printf("+ resource %s %q (%s)\n", v.Type, v.Name, v.Action)
+	}
+
+	for _, v := range resp.Grants {
+		sign := map[string]string{"add": "+", "remove": "-", "update": "~"}[v.Op]
+
+		line := fmt.Sprintf("%s grant    %s -> %s %q (%s)", sign, v.Role, v.Type, v.Resource, v.Action)
+		if v.Condition != "" {
+			line += fmt.Sprintf(" when %s", v.Condition)
+		}
+		fmt.Println(line)
+	}
+
+	switch {
+	case len(resp.Roles) == 0 && len(resp.Resources) == 0 && len(resp.Grants) == 0:
+		fmt.Println("nothing to change")
+	case resp.Applied:
+		fmt.Println("manifest applied")
+	default:
+		fmt.Println("dry run, nothing has been written")
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/transport/rest"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	resourcesDryRun bool
+
+	resourcesCmd = &cobra.Command{
+		Use:   "resources",
+		Short: "Manage RBAC resources",
+	}
+
+	resourcesSyncCmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Register an API resource for every route of the router",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			loadModules()
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+
+			rest := rest.NewRouter(
+				&rest.Option{
+					Sql:   sqlDB.MySQL,
+					Redis: redisClient.Client,
+				},
+			)
+
+			resp, err := rest.SyncResources(tenantContext(context.Background()), resourcesDryRun)
+			if err != nil {
+				log.Fatalln("error sync api resources", err)
+			}
+
+			if resourcesDryRun {
+				fmt.Println("dry run, nothing has been written")
+			}
+
+			for _, v := range resp.Created {
+				fmt.Printf("+ %-7s %s\n", v.Method, v.Path)
+			}
+
+			for _, v := range resp.Orphaned {
+				fmt.Printf("? %-7s %s (%s) has no route anymore\n", v.Method, v.Path, v.UID)
+			}
+
+			fmt.Printf("%d created, %d unchanged, %d orphaned\n", len(resp.Created), resp.Unchanged, len(resp.Orphaned))
+		},
+	}
+)
+
+func init() {
+	resourcesSyncCmd.Flags().BoolVar(&resourcesDryRun, "dry-run", false, "print the changes without writing them")
+	resourcesSyncCmd.Flags().StringVar(&orgKey, "org", constant.DefaultOrganization, "organization uid or slug to sync the resources of")
+
+	resourcesCmd.AddCommand(resourcesSyncCmd)
+}
@@ -1,30 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"github/yogabagas/join-app/config"
+	repo "github/yogabagas/join-app/domain/repository/sql"
 	"github/yogabagas/join-app/transport/rest"
 	"log"
-	"os"
 	"time"
 
-	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 )
 
 var serverCmd = &cobra.Command{
 	Use: "api-serve",
 	PreRun: func(cmd *cobra.Command, args []string) {
-
-		err := godotenv.Load(".env")
-		if err != nil {
-			log.Fatalln("can't load env", err)
-			os.Exit(1)
-		}
-
-		config.LoadConfig(configURL)
-
-		sqlDB, _ = InitSQLModule()
-		redisClient, _ = InitCache()
+		loadModules()
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 
@@ -37,6 +27,33 @@ var serverCmd = &cobra.Command{
 				Redis:        redisClient.Client,
 			},
 		)
+
+		if config.GlobalCfg.App.SyncResources {
+			go func() {
+				ctx := context.Background()
+
+				orgs, err := organizations(ctx)
+				if err != nil {
+					log.Println("error read organizations", err)
+					return
+				}
+
+				for _, org := range orgs {
+					resp, err := rest.SyncResources(repo.WithTenant(ctx, org.UID), false)
+					if err != nil {
+						log.Println("error sync api resources of", org.Slug, err)
+						continue
+					}
+					log.Printf("api resources of %s synced: %d created, %d unchanged, %d orphaned",
+						org.Slug, len(resp.Created), resp.Unchanged, len(resp.Orphaned))
+				}
+			}()
+		}
+
+		go rest.SweepExpiredAuthz(context.Background())
+		go rest.CloseOverdueAccessReviews(context.Background())
+		go rest.ProcessDataRequests(context.Background())
+
 		go rest.Serve()
 		rest.SignalCheck()
 	},
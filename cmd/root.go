@@ -18,9 +18,13 @@ var rootCmd = &cobra.Command{
 
 func Run() {
 
-	serverCmd.PersistentFlags().StringVarP(&configURL, "config", "c", "config/files", "Config URL i.e. config/files")
+	rootCmd.PersistentFlags().StringVarP(&configURL, "config", "c", "config/files", "Config URL i.e. config/files")
 
 	rootCmd.AddCommand(serverCmd)
+	rootCmd.AddCommand(resourcesCmd)
+	rootCmd.AddCommand(rbacCmd)
+	rootCmd.AddCommand(usersCmd)
+	rootCmd.AddCommand(organizationsCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/registry"
+	"github/yogabagas/join-app/shared/constant"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	importPath   string
+	importDryRun bool
+
+	usersCmd = &cobra.Command{
+		Use:   "users",
+		Short: "Manage the users of an organization",
+	}
+
+	usersImportCmd = &cobra.Command{
+		Use:   "import",
+		Short: "Create users and their role from a CSV, reporting the rows that fail",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			loadModules()
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+
+			data, err := os.ReadFile(importPath)
+			if err != nil {
+				log.Fatalln("can't read csv", err)
+			}
+
+			reg := registry.NewRegistry(
+				registry.NewSQLConn(sqlDB.MySQL),
+				registry.NewCache(redisClient.Client),
+			)
+
+			resp, err := reg.NewAppController().UsersController.ImportUsers(tenantContext(context.Background()), service.ImportUsersReq{
+				Data:     data,
+				DryRun:   importDryRun,
+				ActorUID: constant.System,
+			})
+			if err != nil {
+				log.Fatalln("error import users", err)
+			}
+
+			printImportReport(resp)
+
+			if resp.Failed > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	usersImportCmd.Flags().StringVarP(&importPath, "file", "f", "", "CSV of the users, its header naming the columns")
+	usersImportCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "validate the rows without creating the users")
+	usersImportCmd.Flags().StringVar(&orgKey, "org", constant.DefaultOrganization, "organization uid or slug to import the users to")
+	usersImportCmd.MarkFlagRequired("file")
+
+	usersCmd.AddCommand(usersImportCmd)
+}
+
+func printImportReport(resp service.ImportUsersResp) {
+
+	for _, row := range resp.Rows {
+		for _, e := range row.Errors {
+			if e.Field != "" {
+				fmt.Printf("row %d %s: %s: %s\n", row.Row, row.Email, e.Field, e.Message)
+				continue
+			}
+			fmt.Printf("row %d %s: %s\n", row.Row, row.Email, e.Message)
+		}
+	}
+
+	if resp.DryRun {
+		fmt.Printf("dry run, %d of %d rows valid, nothing has been written\n", resp.Total-resp.Failed, resp.Total)
+		return
+	}
+
+	fmt.Printf("%d users created, %d rows failed\n", resp.Created, resp.Failed)
+}
@@ -10,23 +10,28 @@ var GlobalCfg *Config
 
 type (
 	Config struct {
-		App                    App       `json:"app"`
-		DB                     DB        `json:"db"`
-		Cache                  Cache     `json:"cache"`
-		Whitelist              Whitelist `json:"whitelist"`
-		JWK                    JWK       `json:"jwk"`
-		PasswordAlg            string    `json:"password_alg"`
-		TokenExpiration        int       `json:"token_exp"`
-		RefreshTokenExpiration int       `json:"refresh_token_exp"`
+		App                    App            `json:"app"`
+		DB                     DB             `json:"db"`
+		Cache                  Cache          `json:"cache"`
+		Whitelist              Whitelist      `json:"whitelist"`
+		Authorization          Authorization  `json:"authorization"`
+		JWK                    JWK            `json:"jwk"`
+		Storage                Storage        `json:"storage"`
+		PasswordPolicy         PasswordPolicy `json:"password_policy"`
+		Privacy                Privacy        `json:"privacy"`
+		PasswordAlg            string         `json:"password_alg"`
+		TokenExpiration        int            `json:"token_exp"`
+		RefreshTokenExpiration int            `json:"refresh_token_exp"`
 	}
 
 	App struct {
-		Name         string `json:"name"`
-		Host         string `json:"host"`
-		Port         string `json:"port"`
-		ReadTimeout  int    `json:"read_timeout"`
-		WriteTimeout int    `json:"write_timeout"`
-		JWTSecret    string `json:"jwt_secret"`
+		Name          string `json:"name"`
+		Host          string `json:"host"`
+		Port          string `json:"port"`
+		ReadTimeout   int    `json:"read_timeout"`
+		WriteTimeout  int    `json:"write_timeout"`
+		JWTSecret     string `json:"jwt_secret"`
+		SyncResources bool   `json:"sync_resources"`
 	}
 
 	DB struct {
@@ -44,11 +49,77 @@ type (
 			Password string `json:"password"`
 			Host     string `json:"host"`
 		} `json:"redis"`
+		TTL       int `json:"ttl"`
+		LocalSize int `json:"local_size"`
+		LocalTTL  int `json:"local_ttl"`
 	}
 	Whitelist struct {
 		APIs []API `json:"API"`
 	}
 
+	Authorization struct {
+		// Enforce checks the access rules of every route, unless set to false.
+		Enforce       *bool `json:"enforce"`
+		SweepInterval int   `json:"sweep_interval"`
+		// SelfServiceRoles are the role names anyone may pick when signing up.
+		SelfServiceRoles []string `json:"self_service_roles"`
+		// ElevationApprovers are the role names allowed to decide on elevation
+		// requests, which last at most MaxElevationMinutes once approved.
+		ElevationApprovers  []string `json:"elevation_approvers"`
+		MaxElevationMinutes int      `json:"max_elevation_minutes"`
+	}
+
+	// Storage picks the blob store holding the uploads, local or s3, and
+	// bounds the uploads and the lifetime of the URLs serving them.
+	Storage struct {
+		Driver string `json:"driver"`
+		// SigningSecret signs the URLs of the local store, the app jwt_secret
+		// when unset.
+		SigningSecret  string `json:"signing_secret"`
+		URLTTL         int    `json:"url_ttl"`
+		MaxUploadBytes int64  `json:"max_upload_bytes"`
+		Local          struct {
+			Dir     string `json:"dir"`
+			BaseURL string `json:"base_url"`
+		} `json:"local"`
+		S3 struct {
+			Endpoint string `json:"endpoint"`
+			// PublicEndpoint is the endpoint the signed URLs point to when
+			// the clients reach the store another way than the app does.
+			PublicEndpoint string `json:"public_endpoint"`
+			Region         string `json:"region"`
+			Bucket         string `json:"bucket"`
+			AccessKey      string `json:"access_key"`
+			SecretKey      string `json:"secret_key"`
+			PathStyle      bool   `json:"path_style"`
+		} `json:"s3"`
+	}
+
+	// PasswordPolicy bounds the passwords the users set. The classes are the
+	// lowercase and uppercase letters, the digits and the symbols.
+	PasswordPolicy struct {
+		MinLength     int  `json:"min_length"`
+		MaxLength     int  `json:"max_length"`
+		RequireUpper  bool `json:"require_upper"`
+		RequireLower  bool `json:"require_lower"`
+		RequireDigit  bool `json:"require_digit"`
+		RequireSymbol bool `json:"require_symbol"`
+		// BannedList is a file of the passwords refused whatever their case,
+		// one per line, none when unset.
+		BannedList string `json:"banned_list"`
+		// History is the number of previous passwords, besides the current
+		// one, a user can't set again.
+		History int `json:"history"`
+	}
+
+	// Privacy paces the personal data requests, the exports being compiled
+	// every JobInterval seconds and their archives kept ExportRetention
+	// seconds.
+	Privacy struct {
+		JobInterval     int `json:"job_interval"`
+		ExportRetention int `json:"export_retention"`
+	}
+
 	API struct {
 		Endpoint string   `json:"endpoint"`
 		Methods  []string `json:"methods"`
@@ -63,6 +134,12 @@ type (
 	}
 )
 
+// Enforced tells whether the access rules are checked, which they are when
+// enforce is left unset.
+func (a Authorization) Enforced() bool {
+	return a.Enforce == nil || *a.Enforce
+}
+
 func LoadConfig(path string) interface{} {
 
 	env := os.Getenv("APP_ENV")
@@ -0,0 +1 @@
+ALTER TABLE `access` DROP COLUMN `condition_expr`;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE `access` ADD COLUMN `condition_expr` text DEFAULT NULL AFTER `resource_uid`;
\ No newline at end of file
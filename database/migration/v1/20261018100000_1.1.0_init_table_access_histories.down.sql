@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `access_histories`;
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE `access_histories` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `uid` varchar(100) NOT NULL,
+    `role_uid` varchar(100) NOT NULL,
+    `version` int NOT NULL,
+    `snapshot` json NOT NULL,
+    `added` json NOT NULL,
+    `removed` json NOT NULL,
+    `changed` json NOT NULL,
+    `note` varchar(255) NOT NULL DEFAULT '',
+    `created_by` varchar(100) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT now(),
+    PRIMARY KEY (`id`),
+    UNIQUE KEY (`uid`),
+    UNIQUE KEY (`role_uid`, `version`),
+    FOREIGN KEY (`role_uid`) REFERENCES roles(`uid`)
+)ENGINE=InnoDB DEFAULT CHARSET=utf8;
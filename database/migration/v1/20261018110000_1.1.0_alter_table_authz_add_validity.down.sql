@@ -0,0 +1,3 @@
+ALTER TABLE `authz` DROP KEY `idx_authz_valid_until`,
+    DROP COLUMN `valid_until`,
+    DROP COLUMN `valid_from`;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE `authz` ADD COLUMN `valid_from` datetime DEFAULT NULL AFTER `last_active`,
+    ADD COLUMN `valid_until` datetime DEFAULT NULL AFTER `valid_from`,
+    ADD KEY `idx_authz_valid_until` (`is_deleted`, `valid_until`);
\ No newline at end of file
@@ -0,0 +1,18 @@
+ALTER TABLE `access_histories` DROP FOREIGN KEY `fk_access_histories_organization_uid`,
+    DROP KEY `idx_access_histories_organization_uid`,
+    DROP COLUMN `organization_uid`;
+ALTER TABLE `authz` DROP FOREIGN KEY `fk_authz_organization_uid`,
+    DROP KEY `idx_authz_organization_uid`,
+    DROP COLUMN `organization_uid`;
+ALTER TABLE `access` DROP FOREIGN KEY `fk_access_organization_uid`,
+    DROP KEY `idx_access_organization_uid`,
+    DROP COLUMN `organization_uid`;
+ALTER TABLE `resources` DROP FOREIGN KEY `fk_resources_organization_uid`,
+    DROP KEY `idx_resources_organization_uid`,
+    DROP COLUMN `organization_uid`;
+ALTER TABLE `roles` DROP FOREIGN KEY `fk_roles_organization_uid`,
+    DROP KEY `idx_roles_organization_uid`,
+    DROP COLUMN `organization_uid`;
+ALTER TABLE `users` DROP FOREIGN KEY `fk_users_organization_uid`,
+    DROP KEY `idx_users_organization_uid`,
+    DROP COLUMN `organization_uid`;
@@ -0,0 +1,29 @@
+ALTER TABLE `users` ADD COLUMN `organization_uid` varchar(100) NOT NULL DEFAULT 'default' AFTER `uid`,
+    ADD KEY `idx_users_organization_uid` (`organization_uid`),
+    ADD CONSTRAINT `fk_users_organization_uid` FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`);
+ALTER TABLE `users` ALTER COLUMN `organization_uid` DROP DEFAULT;
+
+ALTER TABLE `roles` ADD COLUMN `organization_uid` varchar(100) NOT NULL DEFAULT 'default' AFTER `uid`,
+    ADD KEY `idx_roles_organization_uid` (`organization_uid`),
+    ADD CONSTRAINT `fk_roles_organization_uid` FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`);
+ALTER TABLE `roles` ALTER COLUMN `organization_uid` DROP DEFAULT;
+
+ALTER TABLE `resources` ADD COLUMN `organization_uid` varchar(100) NOT NULL DEFAULT 'default' AFTER `uid`,
+    ADD KEY `idx_resources_organization_uid` (`organization_uid`),
+    ADD CONSTRAINT `fk_resources_organization_uid` FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`);
+ALTER TABLE `resources` ALTER COLUMN `organization_uid` DROP DEFAULT;
+
+ALTER TABLE `access` ADD COLUMN `organization_uid` varchar(100) NOT NULL DEFAULT 'default' AFTER `uid`,
+    ADD KEY `idx_access_organization_uid` (`organization_uid`),
+    ADD CONSTRAINT `fk_access_organization_uid` FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`);
+ALTER TABLE `access` ALTER COLUMN `organization_uid` DROP DEFAULT;
+
+ALTER TABLE `authz` ADD COLUMN `organization_uid` varchar(100) NOT NULL DEFAULT 'default' AFTER `uid`,
+    ADD KEY `idx_authz_organization_uid` (`organization_uid`),
+    ADD CONSTRAINT `fk_authz_organization_uid` FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`);
+ALTER TABLE `authz` ALTER COLUMN `organization_uid` DROP DEFAULT;
+
+ALTER TABLE `access_histories` ADD COLUMN `organization_uid` varchar(100) NOT NULL DEFAULT 'default' AFTER `uid`,
+    ADD KEY `idx_access_histories_organization_uid` (`organization_uid`),
+    ADD CONSTRAINT `fk_access_histories_organization_uid` FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`);
+ALTER TABLE `access_histories` ALTER COLUMN `organization_uid` DROP DEFAULT;
@@ -0,0 +1,2 @@
+ALTER TABLE `roles` DROP FOREIGN KEY `fk_roles_parent_uid`,
+    DROP COLUMN `parent_uid`;
\ No newline at end of file
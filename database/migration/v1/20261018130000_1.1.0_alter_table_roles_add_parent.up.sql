@@ -0,0 +1,2 @@
+ALTER TABLE `roles` ADD COLUMN `parent_uid` varchar(100) DEFAULT NULL AFTER `name`,
+    ADD CONSTRAINT `fk_roles_parent_uid` FOREIGN KEY (`parent_uid`) REFERENCES roles(`uid`);
\ No newline at end of file
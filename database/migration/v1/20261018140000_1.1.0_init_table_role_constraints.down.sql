@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `role_constraint_roles`;
+DROP TABLE IF EXISTS `role_constraints`;
\ No newline at end of file
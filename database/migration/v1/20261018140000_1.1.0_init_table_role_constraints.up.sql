@@ -0,0 +1,23 @@
+CREATE TABLE `role_constraints` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `uid` varchar(100) NOT NULL,
+    `organization_uid` varchar(100) NOT NULL,
+    `name` varchar(255) NOT NULL,
+    `is_deleted` boolean NOT NULL DEFAULT 0,
+    `created_by` varchar(100) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT now(),
+    `updated_by` varchar(100) NOT NULL,
+    `updated_at` datetime NOT NULL DEFAULT now(),
+    PRIMARY KEY (`id`),
+    UNIQUE KEY (`uid`),
+    KEY (`organization_uid`),
+    FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`)
+)ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE `role_constraint_roles` (
+    `constraint_uid` varchar(100) NOT NULL,
+    `role_uid` varchar(100) NOT NULL,
+    PRIMARY KEY (`constraint_uid`, `role_uid`),
+    FOREIGN KEY (`constraint_uid`) REFERENCES role_constraints(`uid`),
+    FOREIGN KEY (`role_uid`) REFERENCES roles(`uid`)
+)ENGINE=InnoDB DEFAULT CHARSET=utf8;
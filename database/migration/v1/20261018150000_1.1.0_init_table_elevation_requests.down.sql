@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `elevation_requests`;
\ No newline at end of file
@@ -0,0 +1,25 @@
+CREATE TABLE `elevation_requests` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `uid` varchar(100) NOT NULL,
+    `organization_uid` varchar(100) NOT NULL,
+    `user_uid` varchar(100) NOT NULL,
+    `role_uid` varchar(100) NOT NULL,
+    `justification` text NOT NULL,
+    `duration_minutes` int NOT NULL,
+    `status` varchar(20) NOT NULL DEFAULT 'pending',
+    `decided_by` varchar(100) NOT NULL DEFAULT '',
+    `decided_at` datetime NULL,
+    `decision_note` text NOT NULL,
+    `authz_uid` varchar(100) NOT NULL DEFAULT '',
+    `created_by` varchar(100) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT now(),
+    `updated_by` varchar(100) NOT NULL,
+    `updated_at` datetime NOT NULL DEFAULT now(),
+    PRIMARY KEY (`id`),
+    UNIQUE KEY (`uid`),
+    KEY (`organization_uid`, `status`),
+    KEY (`user_uid`),
+    FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`),
+    FOREIGN KEY (`user_uid`) REFERENCES users(`uid`),
+    FOREIGN KEY (`role_uid`) REFERENCES roles(`uid`)
+)ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `access_review_items`;
+DROP TABLE IF EXISTS `access_reviews`;
\ No newline at end of file
@@ -0,0 +1,38 @@
+CREATE TABLE `access_reviews` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `uid` varchar(100) NOT NULL,
+    `organization_uid` varchar(100) NOT NULL,
+    `name` varchar(255) NOT NULL,
+    `deadline` datetime NOT NULL,
+    `status` varchar(20) NOT NULL DEFAULT 'open',
+    `closed_at` datetime NULL,
+    `created_by` varchar(100) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT now(),
+    `updated_by` varchar(100) NOT NULL,
+    `updated_at` datetime NOT NULL DEFAULT now(),
+    PRIMARY KEY (`id`),
+    UNIQUE KEY (`uid`),
+    KEY (`organization_uid`, `status`, `deadline`),
+    FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`)
+)ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE `access_review_items` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `uid` varchar(100) NOT NULL,
+    `organization_uid` varchar(100) NOT NULL,
+    `review_uid` varchar(100) NOT NULL,
+    `authz_uid` varchar(100) NOT NULL,
+    `user_uid` varchar(100) NOT NULL,
+    `role_uid` varchar(100) NOT NULL,
+    `reviewer_uid` varchar(100) NOT NULL,
+    `decision` varchar(20) NOT NULL DEFAULT '',
+    `decided_by` varchar(100) NOT NULL DEFAULT '',
+    `decided_at` datetime NULL,
+    `note` text NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY (`uid`),
+    KEY (`review_uid`, `reviewer_uid`),
+    FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`),
+    FOREIGN KEY (`review_uid`) REFERENCES access_reviews(`uid`),
+    FOREIGN KEY (`authz_uid`) REFERENCES authz(`uid`)
+)ENGINE=InnoDB DEFAULT CHARSET=utf8;
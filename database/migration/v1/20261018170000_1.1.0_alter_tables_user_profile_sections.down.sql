@@ -0,0 +1,11 @@
+ALTER TABLE `user_socials` DROP KEY `uid`, DROP COLUMN `position`, DROP COLUMN `uid`;
+
+ALTER TABLE `user_languages` DROP KEY `uid`, DROP COLUMN `position`, DROP COLUMN `uid`;
+
+UPDATE `user_work_experiences` SET `end_date` = `start_date` WHERE `end_date` IS NULL;
+ALTER TABLE `user_work_experiences` DROP KEY `uid`, DROP COLUMN `is_deleted`, DROP COLUMN `position`,
+    MODIFY COLUMN `end_date` date NOT NULL, DROP COLUMN `uid`;
+
+UPDATE `user_educations` SET `end_year` = `start_year` WHERE `end_year` IS NULL;
+ALTER TABLE `user_educations` DROP KEY `uid`, DROP COLUMN `is_deleted`, DROP COLUMN `position`,
+    CHANGE COLUMN `end_year` `to` year NOT NULL, CHANGE COLUMN `start_year` `from` year NOT NULL, DROP COLUMN `uid`;
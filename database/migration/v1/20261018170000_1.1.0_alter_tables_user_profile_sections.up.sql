@@ -0,0 +1,24 @@
+ALTER TABLE `user_educations` ADD COLUMN `uid` varchar(100) NOT NULL DEFAULT '' FIRST,
+    CHANGE COLUMN `from` `start_year` year NOT NULL,
+    CHANGE COLUMN `to` `end_year` year NULL,
+    ADD COLUMN `position` int NOT NULL DEFAULT 0 AFTER `end_year`,
+    ADD COLUMN `is_deleted` boolean NOT NULL DEFAULT 0 AFTER `position`;
+UPDATE `user_educations` SET `uid` = UUID() WHERE `uid` = '';
+ALTER TABLE `user_educations` ALTER COLUMN `uid` DROP DEFAULT, ADD UNIQUE KEY (`uid`);
+
+ALTER TABLE `user_work_experiences` ADD COLUMN `uid` varchar(100) NOT NULL DEFAULT '' FIRST,
+    MODIFY COLUMN `end_date` date NULL,
+    ADD COLUMN `position` int NOT NULL DEFAULT 0 AFTER `description`,
+    ADD COLUMN `is_deleted` boolean NOT NULL DEFAULT 0 AFTER `position`;
+UPDATE `user_work_experiences` SET `uid` = UUID() WHERE `uid` = '';
+ALTER TABLE `user_work_experiences` ALTER COLUMN `uid` DROP DEFAULT, ADD UNIQUE KEY (`uid`);
+
+ALTER TABLE `user_languages` ADD COLUMN `uid` varchar(100) NOT NULL DEFAULT '' FIRST,
+    ADD COLUMN `position` int NOT NULL DEFAULT 0 AFTER `language`;
+UPDATE `user_languages` SET `uid` = UUID() WHERE `uid` = '';
+ALTER TABLE `user_languages` ALTER COLUMN `uid` DROP DEFAULT, ADD UNIQUE KEY (`uid`);
+
+ALTER TABLE `user_socials` ADD COLUMN `uid` varchar(100) NOT NULL DEFAULT '' FIRST,
+    ADD COLUMN `position` int NOT NULL DEFAULT 0 AFTER `link`;
+UPDATE `user_socials` SET `uid` = UUID() WHERE `uid` = '';
+ALTER TABLE `user_socials` ALTER COLUMN `uid` DROP DEFAULT, ADD UNIQUE KEY (`uid`);
@@ -0,0 +1,3 @@
+ALTER TABLE `expertises` DROP FOREIGN KEY `fk_expertises_organization_uid`,
+    DROP KEY `idx_expertises_organization_uid_name`,
+    DROP COLUMN `organization_uid`;
@@ -0,0 +1,4 @@
+ALTER TABLE `expertises` ADD COLUMN `organization_uid` varchar(100) NOT NULL DEFAULT 'default' AFTER `uid`,
+    ADD KEY `idx_expertises_organization_uid_name` (`organization_uid`, `name`),
+    ADD CONSTRAINT `fk_expertises_organization_uid` FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`);
+ALTER TABLE `expertises` ALTER COLUMN `organization_uid` DROP DEFAULT;
@@ -0,0 +1 @@
+ALTER TABLE `users` ADD KEY `idx_users_organization_uid_email` (`organization_uid`, `email`);
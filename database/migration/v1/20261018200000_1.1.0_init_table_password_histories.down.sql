@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `password_histories`;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE `password_histories` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `user_uid` varchar(100) NOT NULL,
+    `password` varchar(255) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT now(),
+    PRIMARY KEY (`id`),
+    KEY (`user_uid`, `id`),
+    FOREIGN KEY (`user_uid`) REFERENCES users(`uid`)
+)ENGINE=InnoDB DEFAULT CHARSET=utf8;
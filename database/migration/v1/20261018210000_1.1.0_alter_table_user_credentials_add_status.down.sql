@@ -0,0 +1,3 @@
+ALTER TABLE `user_credentials` DROP COLUMN `status_updated_at`,
+    DROP COLUMN `status_updated_by`,
+    DROP COLUMN `status_reason`;
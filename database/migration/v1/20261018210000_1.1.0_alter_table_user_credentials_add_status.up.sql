@@ -0,0 +1,3 @@
+ALTER TABLE `user_credentials` ADD COLUMN `status_reason` varchar(255) NOT NULL DEFAULT '' AFTER `is_active`,
+    ADD COLUMN `status_updated_by` varchar(100) NULL AFTER `status_reason`,
+    ADD COLUMN `status_updated_at` datetime NULL AFTER `status_updated_by`;
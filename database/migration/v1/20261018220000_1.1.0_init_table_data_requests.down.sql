@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `data_requests`;
\ No newline at end of file
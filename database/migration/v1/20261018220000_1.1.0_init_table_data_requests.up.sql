@@ -0,0 +1,22 @@
+CREATE TABLE `data_requests` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `uid` varchar(100) NOT NULL,
+    `organization_uid` varchar(100) NOT NULL,
+    `user_uid` varchar(100) NOT NULL,
+    `kind` varchar(20) NOT NULL,
+    `status` varchar(20) NOT NULL DEFAULT 'pending',
+    `note` varchar(255) NOT NULL DEFAULT '',
+    `archive_key` varchar(255) NOT NULL DEFAULT '',
+    `error` varchar(255) NOT NULL DEFAULT '',
+    `created_by` varchar(100) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT now(),
+    `completed_by` varchar(100) NOT NULL DEFAULT '',
+    `completed_at` datetime NULL,
+    `expires_at` datetime NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY (`uid`),
+    KEY (`organization_uid`, `kind`, `status`),
+    KEY (`user_uid`),
+    FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`),
+    FOREIGN KEY (`user_uid`) REFERENCES users(`uid`)
+)ENGINE=InnoDB DEFAULT CHARSET=utf8;
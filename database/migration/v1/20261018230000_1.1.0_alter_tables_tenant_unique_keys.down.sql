@@ -0,0 +1,9 @@
+ALTER TABLE `user_credentials` DROP FOREIGN KEY `fk_user_credentials_organization_uid`,
+    DROP KEY `uk_user_credentials_organization_uid_username`,
+    ADD UNIQUE KEY (`username`),
+    DROP COLUMN `organization_uid`;
+
+ALTER TABLE `users` DROP KEY `uk_users_organization_uid_email`,
+    ADD KEY `idx_users_organization_uid_email` (`organization_uid`, `email`),
+    ADD UNIQUE KEY (`uid`, `email`);
+ALTER TABLE `users` DROP KEY `uk_users_uid`;
@@ -0,0 +1,11 @@
+ALTER TABLE `users` ADD UNIQUE KEY `uk_users_uid` (`uid`);
+ALTER TABLE `users` DROP KEY `uid`,
+    DROP KEY `idx_users_organization_uid_email`,
+    ADD UNIQUE KEY `uk_users_organization_uid_email` (`organization_uid`, `email`);
+
+ALTER TABLE `user_credentials` ADD COLUMN `organization_uid` varchar(100) NOT NULL DEFAULT '' AFTER `user_uid`;
+UPDATE `user_credentials` uc JOIN `users` u ON uc.`user_uid` = u.`uid` SET uc.`organization_uid` = u.`organization_uid`;
+ALTER TABLE `user_credentials` ALTER COLUMN `organization_uid` DROP DEFAULT,
+    DROP KEY `username`,
+    ADD UNIQUE KEY `uk_user_credentials_organization_uid_username` (`organization_uid`, `username`),
+    ADD CONSTRAINT `fk_user_credentials_organization_uid` FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`);
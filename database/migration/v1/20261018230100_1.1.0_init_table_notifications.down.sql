@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `notifications`;
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE `notifications` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `uid` varchar(100) NOT NULL,
+    `organization_uid` varchar(100) NOT NULL,
+    `user_uid` varchar(100) NOT NULL,
+    `kind` varchar(50) NOT NULL,
+    `subject_uid` varchar(100) NOT NULL,
+    `payload` json NOT NULL,
+    `read_at` datetime NULL,
+    `created_by` varchar(100) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT now(),
+    PRIMARY KEY (`id`),
+    UNIQUE KEY (`uid`),
+    KEY (`organization_uid`, `user_uid`, `read_at`),
+    FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`),
+    FOREIGN KEY (`user_uid`) REFERENCES users(`uid`)
+)ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,15 @@
+CREATE TABLE `user_status_histories` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `uid` varchar(100) NOT NULL,
+    `organization_uid` varchar(100) NOT NULL,
+    `user_uid` varchar(100) NOT NULL,
+    `is_active` boolean NOT NULL,
+    `reason` varchar(255) NOT NULL DEFAULT '',
+    `created_by` varchar(100) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT now(),
+    PRIMARY KEY (`id`),
+    UNIQUE KEY (`uid`),
+    KEY (`organization_uid`, `user_uid`),
+    FOREIGN KEY (`organization_uid`) REFERENCES organizations(`uid`),
+    FOREIGN KEY (`user_uid`) REFERENCES users(`uid`)
+)ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -10,6 +10,7 @@ type Access struct {
 	UID         string
 	RoleUID     string
 	ResourceUID string
+	Condition   string
 	IsDeleted   bool
 	CreatedBy   string
 	CreatedAt   time.Time
@@ -30,4 +31,36 @@ type ReadAccessByRoleUIDResp struct {
 	Action    string
 	ParentUID sql.NullString
 	Level     int
+	Condition sql.NullString
+}
+
+type ReadAccessByResourceReq struct {
+	RoleUIDs []string
+	Name     string
+	Action   string
+}
+
+type ReadAccessByResourceResp struct {
+	UID         string
+	RoleUID     string
+	ResourceUID string
+	Condition   sql.NullString
+}
+
+type ReadAccessByRolesAndResourcesReq struct {
+	RoleUIDs     []string
+	ResourceUIDs []string
+}
+
+// ReadAccessByRoleUIDsReq reads the grants of RoleUIDs. ForUpdate locks the
+// roles first, so the grants of a role are replaced one change after the other
+// even before the role has any grant or history.
+type ReadAccessByRoleUIDsReq struct {
+	RoleUIDs  []string
+	ForUpdate bool
+}
+
+type DeleteAccessByRoleUIDReq struct {
+	RoleUID   string
+	UpdatedBy string
 }
@@ -0,0 +1,66 @@
+package model
+
+import "time"
+
+type AccessHistory struct {
+	ID        int
+	UID       string
+	RoleUID   string
+	Version   int
+	Snapshot  []AccessSnapshotItem
+	Added     []AccessSnapshotItem
+	Removed   []AccessSnapshotItem
+	Changed   []AccessSnapshotItem
+	Note      string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+type AccessSnapshotItem struct {
+	ResourceUID string `json:"resource_uid"`
+	Condition   string `json:"condition,omitempty"`
+}
+
+type ReadAccessHistoriesByRoleUIDReq struct {
+	RoleUID string
+}
+
+type ReadAccessHistoryByVersionReq struct {
+	RoleUID string
+	Version int
+}
+
+type ReadLatestAccessVersionReq struct {
+	RoleUID string
+}
+
+// DiffAccess returns the grants only in after, only in before, and in both
+// with a different condition (as found in after).
+func DiffAccess(before, after []AccessSnapshotItem) (added, removed, changed []AccessSnapshotItem) {
+
+	prev := make(map[string]string, len(before))
+	for _, v := range before {
+		prev[v.ResourceUID] = v.Condition
+	}
+
+	next := make(map[string]bool, len(after))
+	for _, v := range after {
+		next[v.ResourceUID] = true
+
+		condition, ok := prev[v.ResourceUID]
+		switch {
+		case !ok:
+			added = append(added, v)
+		case condition != v.Condition:
+			changed = append(changed, v)
+		}
+	}
+
+	for _, v := range before {
+		if !next[v.ResourceUID] {
+			removed = append(removed, v)
+		}
+	}
+
+	return
+}
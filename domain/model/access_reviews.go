@@ -0,0 +1,67 @@
+package model
+
+import "time"
+
+// AccessReview is a campaign asking reviewers to confirm or revoke every
+// grant that existed when it was opened.
+type AccessReview struct {
+	ID        int
+	UID       string
+	Name      string
+	Deadline  time.Time
+	Status    string
+	ClosedAt  *time.Time
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// AccessReviewItem is the snapshot of one grant under review. UserEmail and
+// RoleName are only filled when reading.
+type AccessReviewItem struct {
+	ID          int
+	UID         string
+	ReviewUID   string
+	AuthzUID    string
+	UserUID     string
+	UserEmail   string
+	RoleUID     string
+	RoleName    string
+	ReviewerUID string
+	Decision    string
+	DecidedBy   string
+	DecidedAt   *time.Time
+	Note        string
+}
+
+type ReadAccessReviewsReq struct {
+	Status string
+}
+
+type ReadAccessReviewByUIDReq struct {
+	UID string
+}
+
+type ReadAccessReviewItemsReq struct {
+	ReviewUID   string
+	ReviewerUID string
+	Pending     bool
+}
+
+type ReadAccessReviewItemByUIDReq struct {
+	ReviewUID string
+	UID       string
+}
+
+type DecideAccessReviewItemReq struct {
+	UID       string
+	Decision  string
+	DecidedBy string
+	Note      string
+}
+
+type CloseAccessReviewReq struct {
+	UID       string
+	UpdatedBy string
+}
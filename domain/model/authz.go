@@ -9,8 +9,34 @@ type Authz struct {
 	RoleUID    string
 	IsDeleted  bool
 	LastActive time.Time
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
 	CreatedBy  string
 	CreatedAt  time.Time
 	UpdatedBy  string
 	UpdatedAt  time.Time
 }
+
+type ReadAuthzByUserUIDReq struct {
+	UserUID string
+}
+
+type ReadAuthzByUserUIDResp struct {
+	UID      string
+	RoleUID  string
+	RoleName string
+}
+
+type DeleteAuthzByUIDsReq struct {
+	UIDs      []string
+	UpdatedBy string
+}
+
+// ReadUnexpiredAuthzReq reads the current and future grants of UserUID, or of
+// every user in the organization when it is empty. ForUpdate locks the user
+// and their grants until the transaction ends, so concurrent grants to the
+// user are checked one after the other.
+type ReadUnexpiredAuthzReq struct {
+	UserUID   string
+	ForUpdate bool
+}
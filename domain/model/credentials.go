@@ -3,11 +3,15 @@ package model
 import "time"
 
 type UserCredential struct {
-	UserUID   string
-	Username  string
-	Password  string
-	IsActive  bool
-	CreatedAt time.Time
+	UserUID  string
+	Username string
+	Password string
+	IsActive bool
+	// StatusReason is why the credential was last suspended or reactivated.
+	StatusReason    string
+	StatusUpdatedBy string
+	StatusUpdatedAt *time.Time
+	CreatedAt       time.Time
 }
 
 type ReadCredentialsByUserUIDAndPasswordReq struct {
@@ -16,5 +20,56 @@ type ReadCredentialsByUserUIDAndPasswordReq struct {
 }
 
 type ReadCredentialsByUserUIDAndPasswordResp struct {
-	Valid bool
+	Valid    bool
+	IsActive bool
+}
+
+type ReadUsernamesReq struct {
+	Usernames []string
+}
+
+type ReadCredentialByUserUIDReq struct {
+	UserUID string
+}
+
+type UpdatePasswordReq struct {
+	UserUID  string
+	Password string
+}
+
+type UpdateCredentialActiveReq struct {
+	UserUID   string
+	IsActive  bool
+	Reason    string
+	UpdatedBy string
+}
+
+// UserStatusHistory records a suspension or reactivation of UserUID, IsActive
+// being the status it set.
+type UserStatusHistory struct {
+	UID       string
+	UserUID   string
+	IsActive  bool
+	Reason    string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+type PasswordHistory struct {
+	ID        int64
+	UserUID   string
+	Password  string
+	CreatedAt time.Time
+}
+
+type ReadPasswordHistoriesReq struct {
+	UserUID string
+	Limit   int
+}
+
+// DeletePasswordHistoriesReq drops the histories of UserUID but the Keep
+// latest ones.
+type DeletePasswordHistoriesReq struct {
+	UserUID string
+	Keep    int
 }
@@ -0,0 +1,89 @@
+package model
+
+import "time"
+
+// DataRequest is a request of UserUID about their personal data, an export
+// of it or its erasure. A completed export keeps the key of its archive
+// until ExpiresAt.
+type DataRequest struct {
+	ID          int
+	UID         string
+	UserUID     string
+	Kind        string
+	Status      string
+	Note        string
+	ArchiveKey  string
+	Error       string
+	CreatedBy   string
+	CreatedAt   time.Time
+	CompletedBy string
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time
+}
+
+// ReadDataRequestsReq filters on the fields that are set. ExpiredBefore
+// keeps the requests expiring before it.
+type ReadDataRequestsReq struct {
+	UserUID       string
+	Kind          string
+	Status        string
+	ExpiredBefore *time.Time
+	Limit         int
+}
+
+// UpdateDataRequestStatusReq moves the request from status From to Status,
+// nothing changing when it isn't in From anymore.
+type UpdateDataRequestStatusReq struct {
+	UID         string
+	From        string
+	Status      string
+	ArchiveKey  string
+	Error       string
+	CompletedBy string
+	ExpiresAt   *time.Time
+}
+
+// Feedback is a feedback CreatedBy gave to the mentor MentorUID.
+type Feedback struct {
+	UID       string
+	MentorUID string
+	Rating    float64
+	Comment   string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// AuditEntry is a row of the audited tables about a user, such as their
+// role grants or elevation requests, Kind naming the table.
+type AuditEntry struct {
+	Kind     string
+	UID      string
+	RoleUID  string
+	Status   string
+	ActorUID string
+	Note     string
+	At       time.Time
+}
+
+type ReadPersonalDataReq struct {
+	UserUID string
+}
+
+// AnonymizeUserReq overwrites the personal columns of the user with the
+// values of the request, keeping their uid so the rows referring to them
+// stay valid.
+type AnonymizeUserReq struct {
+	UserUID   string
+	FirstName string
+	LastName  string
+	Email     string
+	Username  string
+	Password  string
+	Reason    string
+	UpdatedBy string
+}
+
+type AnonymizeUserResp struct {
+	// Photo is the photo the user had, for its blobs to be deleted.
+	Photo string
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// ElevationRequest asks for RoleUID to be granted to UserUID for Duration
+// minutes. Once decided it keeps who decided, when and why.
+type ElevationRequest struct {
+	ID            int
+	UID           string
+	UserUID       string
+	RoleUID       string
+	Justification string
+	Duration      int
+	Status        string
+	DecidedBy     string
+	DecidedAt     *time.Time
+	DecisionNote  string
+	AuthzUID      string
+	CreatedBy     string
+	CreatedAt     time.Time
+	UpdatedBy     string
+	UpdatedAt     time.Time
+}
+
+type ReadElevationRequestsReq struct {
+	UserUID string
+	Status  string
+}
+
+type ReadElevationRequestByUIDReq struct {
+	UID string
+}
+
+type DecideElevationRequestReq struct {
+	UID          string
+	Status       string
+	DecidedBy    string
+	DecisionNote string
+	AuthzUID     string
+}
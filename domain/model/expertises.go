@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+type Expertise struct {
+	ID        int
+	UID       string
+	Name      string
+	IsDeleted bool
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// ReadExpertisesReq lists the expertises whose name starts with Prefix, all
+// of them when it is empty, up to Limit when it is set.
+type ReadExpertisesReq struct {
+	Prefix string
+	Limit  int
+}
+
+type ReadExpertisesByUIDsReq struct {
+	UIDs []string
+}
+
+type UpdateExpertiseReq struct {
+	UID       string
+	Name      string
+	UpdatedBy string
+}
+
+type DeleteExpertiseReq struct {
+	UID       string
+	UpdatedBy string
+}
+
+type UserExpertise struct {
+	UserUID      string
+	ExpertiseUID string
+	Name         string
+}
+
+type ReadUserExpertisesReq struct {
+	UserUIDs []string
+}
+
+type ReplaceUserExpertisesReq struct {
+	UserUID       string
+	ExpertiseUIDs []string
+}
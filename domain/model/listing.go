@@ -0,0 +1,34 @@
+package model
+
+// ListReq asks a listing for a page of its rows, filtered and sorted on the
+// fields the listing declares. The page starts at Offset, or right after the
+// row of uid After or right before the row of uid Before.
+type ListReq struct {
+	Filters []ListFilter
+	Sorts   []ListSort
+	Limit   int
+	Offset  int
+	After   string
+	Before  string
+}
+
+// ListPage tells how many rows match the filters and, in the default order,
+// the uids of the rows bounding the page when more rows follow or precede it.
+type ListPage struct {
+	Total   int
+	NextUID string
+	PrevUID string
+}
+
+// ListFilter compares Field to Values with Op, only the in operator taking
+// several values.
+type ListFilter struct {
+	Field  string
+	Op     string
+	Values []string
+}
+
+type ListSort struct {
+	Field string
+	Desc  bool
+}
@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// Mentor is a user holding the mentor role, with what the search ranks them
+// on. YearsOfExperience runs from the start of their first work experience.
+type Mentor struct {
+	UID               string
+	FirstName         string
+	LastName          string
+	Description       string
+	Gender            int
+	Country           string
+	Photo             string
+	Languages         []string
+	YearsOfExperience int
+	Rating            float64
+	RatingCount       int
+	Relevance         float64
+	CreatedAt         time.Time
+}
+
+// SearchMentorsReq filters the users holding the role RoleName. Empty
+// filters are left out, the ones with several values match any of them.
+type SearchMentorsReq struct {
+	RoleName      string
+	Query         string
+	ExpertiseUIDs []string
+	Countries     []string
+	Languages     []string
+	Gender        *int
+	MinYears      *int
+	MaxYears      *int
+	MinRating     *float64
+	Sort          string
+	Limit         int
+	Offset        int
+}
+
+type SearchMentorsResp struct {
+	Mentors []*Mentor
+	Total   int
+}
+
+// MentorFacet counts the mentors having Value for Field, under every filter
+// of the search but the one on Field.
+type MentorFacet struct {
+	Field string
+	Value string
+	Label string
+	Count int
+}
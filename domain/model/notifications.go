@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// Notification tells UserUID about something that happened to them, such as
+// the decision on their elevation request SubjectUID. Payload holds the
+// details as JSON.
+type Notification struct {
+	ID         int
+	UID        string
+	UserUID    string
+	Kind       string
+	SubjectUID string
+	Payload    string
+	ReadAt     *time.Time
+	CreatedBy  string
+	CreatedAt  time.Time
+}
+
+// ReadNotificationsReq reads the notifications of UserUID, the latest first,
+// only the unread ones when Unread is set.
+type ReadNotificationsReq struct {
+	UserUID string
+	Unread  bool
+	Limit   int
+}
+
+type MarkNotificationReadReq struct {
+	UID     string
+	UserUID string
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+type Organization struct {
+	ID        int
+	UID       string
+	Name      string
+	Slug      string
+	IsDeleted bool
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+type ReadOrganizationByKeyReq struct {
+	Key string
+}
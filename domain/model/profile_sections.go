@@ -0,0 +1,64 @@
+package model
+
+import "time"
+
+// ProfileSection holds what the entries of every profile section share.
+type ProfileSection struct {
+	UID       string
+	UserUID   string
+	Position  int
+	IsDeleted bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Entry gives access to the shared fields of the section entry embedding s.
+func (s *ProfileSection) Entry() *ProfileSection {
+	return s
+}
+
+type UserEducation struct {
+	ProfileSection
+	College   string
+	Degree    string
+	Major     string
+	StartYear int
+	EndYear   *int
+}
+
+type UserWorkExperience struct {
+	ProfileSection
+	Role        string
+	Company     string
+	Industry    string
+	StartDate   time.Time
+	EndDate     *time.Time
+	Description string
+}
+
+type UserLanguage struct {
+	ProfileSection
+	Language string
+}
+
+type UserSocial struct {
+	ProfileSection
+	Title string
+	Link  string
+}
+
+type ReadProfileSectionReq struct {
+	UserUID string
+}
+
+type DeleteProfileSectionReq struct {
+	UserUID string
+	UID     string
+}
+
+// ReorderProfileSectionReq moves the entries of a section to the position of
+// their uid in UIDs.
+type ReorderProfileSectionReq struct {
+	UserUID string
+	UIDs    []string
+}
@@ -31,3 +31,15 @@ type ReadResourcesByTypeResp struct {
 	ParentUID sql.NullString
 	Level     int
 }
+
+type ReadResourcesByKeyReq struct {
+	Key    string
+	Action string
+}
+
+// ReadResourcesWithPaginationResp holds a page of the resources and where it sits
+// in the listing.
+type ReadResourcesWithPaginationResp struct {
+	Resources []*Resource
+	Page      ListPage
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// RoleConstraint is a separation of duties rule: nobody may hold more than
+// one of RoleUIDs at the same time.
+type RoleConstraint struct {
+	ID        int
+	UID       string
+	Name      string
+	RoleUIDs  []string
+	IsDeleted bool
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+type DeleteRoleConstraintReq struct {
+	UID       string
+	UpdatedBy string
+}
+
+func (rc *RoleConstraint) Contains(roleUID string) bool {
+	for _, v := range rc.RoleUIDs {
+		if v == roleUID {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleConstraintViolation is a user holding, at overlapping times, Grants of
+// several roles Constraint keeps apart.
+type RoleConstraintViolation struct {
+	Constraint *RoleConstraint
+	UserUID    string
+	Grants     []*Authz
+}
@@ -6,6 +6,7 @@ type Role struct {
 	ID        int
 	UID       string
 	Name      string
+	ParentUID string
 	IsDeleted bool
 	CreatedBy string
 	CreatedAt time.Time
@@ -16,3 +17,16 @@ type Role struct {
 type ReadRolesByIDReq struct {
 	ID int
 }
+
+type UpdateRoleParentReq struct {
+	UID       string
+	ParentUID string
+	UpdatedBy string
+}
+
+// ReadRolesWithPaginationResp holds a page of the roles and where it sits in the
+// listing.
+type ReadRolesWithPaginationResp struct {
+	Roles []*Role
+	Page  ListPage
+}
@@ -24,32 +24,32 @@ type User struct {
 	UpdatedAt   time.Time
 }
 
-type ReadUsersWithPaginationReq struct {
-	Fullname string
-	Limit    int
-	Offset   int
-}
-
+// ReadUsersWithPaginationResp holds a page of the users and where it sits in the
+// listing.
 type ReadUsersWithPaginationResp struct {
-	Users   []UserWithRole
-	PerPage int
+	Users []UserWithRole
+	Page  ListPage
 }
 
 type UserWithRole struct {
-	ID        int
-	UID       string
-	FirstName string
-	LastName  string
-	Email     string
-	Birthdate time.Time
-	Username  string
-	Password  string
-	IsDeleted bool
-	CreatedBy string
-	CreatedAt time.Time
-	UpdatedBy string
-	UpdatedAt time.Time
-	RoleName  string
+	ID          int
+	UID         string
+	FirstName   string
+	LastName    string
+	Email       string
+	Birthdate   time.Time
+	Gender      int
+	Country     string
+	Description string
+	Username    string
+	Password    string
+	IsActive    bool
+	IsDeleted   bool
+	CreatedBy   string
+	CreatedAt   time.Time
+	UpdatedBy   string
+	UpdatedAt   time.Time
+	RoleName    string
 }
 
 type CountUsersReq struct {
@@ -63,7 +63,9 @@ type CountUsersResp struct {
 type GenerateAccessTokenReq struct {
 	KeyID      string
 	UserUID    string
+	SessionUID string
 	RoleUID    string
+	OrgUID     string
 	LastActive int64
 	ExpiredAt  int
 	Signer     jose.Signer
@@ -74,20 +76,49 @@ type GenerateAccessTokenResp struct {
 }
 
 type GenerateRefreshTokenReq struct {
-	KeyID     string
-	UserUID   string
-	ExpiredAt int
-	Signer    jose.Signer
+	KeyID      string
+	UserUID    string
+	SessionUID string
+	OrgUID     string
+	ExpiredAt  int
+	Signer     jose.Signer
 }
 
 type GenerateRefreshTokenResp struct {
 	Token string
 }
 
+type ReadUserByUIDReq struct {
+	UID string
+}
+
+// UpdateUserProfileReq leaves the columns of nil fields untouched.
+type UpdateUserProfileReq struct {
+	UID         string
+	FirstName   *string
+	LastName    *string
+	Birthdate   *time.Time
+	Description *string
+	Gender      *int
+	Country     *string
+	Photo       *string
+	UpdatedBy   string
+}
+
+type UpdateUserDeletedReq struct {
+	UID       string
+	IsDeleted bool
+	UpdatedBy string
+}
+
 type ReadUserByEmailReq struct {
 	Email string
 }
 
+type ReadUsersByEmailsReq struct {
+	Emails []string
+}
+
 type ReadUserByEmailResp struct {
 	UserUID    string
 	RoleUID    string
@@ -0,0 +1,108 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ErrNotFound         = BlobError("[blob] not found")
+	ErrInvalidKey       = BlobError("[blob] invalid key")
+	ErrInvalidSignature = BlobError("[blob] invalid signature")
+	ErrExpiredURL       = BlobError("[blob] url expired")
+)
+
+type BlobError string
+
+func (e BlobError) Error() string {
+	return string(e)
+}
+
+// BlobStore keeps the uploaded files under slash separated keys.
+type BlobStore interface {
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+	// Get opens the blob of key, the caller closing its body.
+	Get(ctx context.Context, key string) (*Object, error)
+	// Delete removes the blob of key, a missing blob being no error.
+	Delete(ctx context.Context, key string) error
+	// SignedURL gives a URL serving the blob of key to anyone holding it, until
+	// ttl elapses.
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+type Object struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+}
+
+// ValidKey accepts the relative slash separated keys without empty, dot or
+// dot-dot segments, so a key never escapes the store.
+func ValidKey(key string) error {
+
+	if key == "" || strings.ContainsAny(key, "\\\x00") {
+		return ErrInvalidKey
+	}
+
+	for _, v := range strings.Split(key, "/") {
+		if v == "" || v == "." || v == ".." {
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
+
+// URLSigner signs the URLs the app serves the blobs of the local store at,
+// as baseURL/key?expires=unix&signature=hmac.
+type URLSigner struct {
+	baseURL string
+	secret  []byte
+}
+
+func NewURLSigner(baseURL, secret string) *URLSigner {
+	return &URLSigner{baseURL: strings.TrimSuffix(baseURL, "/"), secret: []byte(secret)}
+}
+
+func (s *URLSigner) URL(key string, expiresAt time.Time) string {
+
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(key, expires))
+
+	return s.baseURL + "/" + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode()
+}
+
+// Verify checks the expires and signature parameters of a URL of key.
+func (s *URLSigner) Verify(key, expires, signature string, now time.Time) error {
+
+	if !hmac.Equal([]byte(s.sign(key, expires)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if now.Unix() > unix {
+		return ErrExpiredURL
+	}
+
+	return nil
+}
+
+func (s *URLSigner) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
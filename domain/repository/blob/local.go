@@ -0,0 +1,132 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// LocalStore keeps the blobs as files under a directory, served by the app
+// at the URLs signed by its URLSigner.
+type LocalStore struct {
+	dir    string
+	signer *URLSigner
+}
+
+// URLVerifier is implemented by the stores whose signed URLs the app serves
+// itself.
+type URLVerifier interface {
+	VerifyURL(key, expires, signature string) error
+}
+
+func NewLocalStore(dir string, signer *URLSigner) *LocalStore {
+	return &LocalStore{dir: dir, signer: signer}
+}
+
+// Put writes the blob to a temporary file renamed over the key, so readers
+// never see a partial blob.
+func (ls *LocalStore) Put(ctx context.Context, key string, body []byte, contentType string) error {
+
+	name, err := ls.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(body); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+// Get opens the blob of key, its content type following the extension of
+// the key.
+func (ls *LocalStore) Get(ctx context.Context, key string) (*Object, error) {
+
+	name, err := ls.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &Object{Body: f, ContentType: contentType, Size: info.Size()}, nil
+}
+
+func (ls *LocalStore) Delete(ctx context.Context, key string) error {
+
+	name, err := ls.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (ls *LocalStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+
+	if err := ValidKey(key); err != nil {
+		return "", err
+	}
+
+	return ls.signer.URL(key, time.Now().Add(ttl)), nil
+}
+
+func (ls *LocalStore) VerifyURL(key, expires, signature string) error {
+
+	if err := ValidKey(key); err != nil {
+		return err
+	}
+
+	return ls.signer.Verify(key, expires, signature, time.Now())
+}
+
+func (ls *LocalStore) path(key string) (string, error) {
+
+	if err := ValidKey(key); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(ls.dir, filepath.FromSlash(key)), nil
+}
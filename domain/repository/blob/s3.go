@@ -0,0 +1,316 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4TimeFormat = "20060102T150405Z"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	// maxPresignTTL is the longest lifetime S3 accepts for a presigned URL.
+	maxPresignTTL = 7 * 24 * time.Hour
+)
+
+type S3Option struct {
+	Endpoint       string
+	PublicEndpoint string
+	Region         string
+	Bucket         string
+	AccessKey      string
+	SecretKey      string
+	// PathStyle addresses the bucket in the path rather than in the host, as
+	// MinIO and most local stand-ins expect.
+	PathStyle bool
+}
+
+// S3Store keeps the blobs in a bucket of an S3 compatible store, signing its
+// requests and URLs with AWS Signature Version 4.
+type S3Store struct {
+	client   *http.Client
+	endpoint *url.URL
+	public   *url.URL
+	opt      S3Option
+}
+
+func NewS3Store(opt S3Option) (*S3Store, error) {
+
+	if opt.Bucket == "" || opt.Region == "" || opt.AccessKey == "" || opt.SecretKey == "" {
+		return nil, errors.New("storage.s3 needs a bucket, a region and credentials")
+	}
+
+	endpoint, err := url.Parse(opt.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid storage.s3.endpoint %q", opt.Endpoint)
+	}
+
+	public := endpoint
+	if opt.PublicEndpoint != "" {
+		public, err = url.Parse(opt.PublicEndpoint)
+		if err != nil || public.Host == "" {
+			return nil, fmt.Errorf("invalid storage.s3.public_endpoint %q", opt.PublicEndpoint)
+		}
+	}
+
+	return &S3Store{
+		client:   &http.Client{Timeout: 30 * time.Second},
+		endpoint: endpoint,
+		public:   public,
+		opt:      opt,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, body []byte, contentType string) error {
+
+	res, err := s.do(ctx, http.MethodPut, key, body, map[string]string{"Content-Type": contentType})
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return s.check(res)
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (*Object, error) {
+
+	res, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.check(res); err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+
+	return &Object{Body: res.Body, ContentType: res.Header.Get("Content-Type"), Size: res.ContentLength}, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+
+	res, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err = s.check(res); err != nil && err != ErrNotFound {
+		return err
+	}
+
+	return nil
+}
+
+// SignedURL presigns a GET of the object on the public endpoint.
+func (s *S3Store) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+
+	if err := ValidKey(key); err != nil {
+		return "", err
+	}
+
+	if ttl > maxPresignTTL {
+		ttl = maxPresignTTL
+	}
+
+	now := time.Now().UTC()
+	u := s.objectURL(s.public, key)
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", sigV4Algorithm)
+	query.Set("X-Amz-Credential", s.opt.AccessKey+"/"+s.scope(now))
+	query.Set("X-Amz-Date", now.Format(sigV4TimeFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	u.RawQuery = canonicalQuery(query)
+
+	signature := s.signature(now, http.MethodGet, u, map[string]string{"host": u.Host}, unsignedPayload)
+	u.RawQuery += "&X-Amz-Signature=" + signature
+
+	return u.String(), nil
+}
+
+func (s *S3Store) do(ctx context.Context, method, key string, body []byte, headers map[string]string) (*http.Response, error) {
+
+	if err := ValidKey(key); err != nil {
+		return nil, err
+	}
+
+	u := s.objectURL(s.endpoint, key)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	payload := sha256.Sum256(body)
+
+	signed := map[string]string{
+		"host":                 u.Host,
+		"x-amz-content-sha256": hex.EncodeToString(payload[:]),
+		"x-amz-date":           now.Format(sigV4TimeFormat),
+	}
+
+	for k, v := range headers {
+		signed[strings.ToLower(k)] = v
+	}
+
+	for k, v := range signed {
+		if k != "host" {
+			req.Header.Set(k, v)
+		}
+	}
+
+	signature := s.signature(now, method, u, signed, signed["x-amz-content-sha256"])
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, s.opt.AccessKey, s.scope(now), signedHeaders(signed), signature))
+
+	return s.client.Do(req)
+}
+
+func (s *S3Store) check(res *http.Response) error {
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case res.StatusCode >= 300:
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("[blob] s3 %s: %s", res.Status, msg)
+	}
+
+	return nil
+}
+
+// objectURL addresses key in the bucket on endpoint.
+func (s *S3Store) objectURL(endpoint *url.URL, key string) *url.URL {
+
+	u := *endpoint
+	base := strings.TrimSuffix(u.Path, "/")
+
+	if s.opt.PathStyle {
+		u.Path = base + "/" + s.opt.Bucket + "/" + key
+	} else {
+		u.Host = s.opt.Bucket + "." + u.Host
+		u.Path = base + "/" + key
+	}
+	u.RawPath = uriEncode(u.Path, false)
+	u.RawQuery = ""
+
+	return &u
+}
+
+func (s *S3Store) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.opt.Region + "/s3/aws4_request"
+}
+
+// signature signs the canonical request of method on u with the headers, as
+// described by the AWS Signature Version 4 specification.
+func (s *S3Store) signature(now time.Time, method string, u *url.URL, headers map[string]string, payloadHash string) string {
+
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + strings.TrimSpace(headers[k]) + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		u.RawQuery,
+		canonicalHeaders.String(),
+		strings.Join(names, ";"),
+		payloadHash,
+	}, "\n")
+
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm,
+		now.Format(sigV4TimeFormat),
+		s.scope(now),
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.opt.SecretKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.opt.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func signedHeaders(headers map[string]string) string {
+
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ";")
+}
+
+// canonicalQuery encodes the query sorted by name, spaces as %20.
+func canonicalQuery(query url.Values) string {
+
+	names := make([]string, 0, len(query))
+	for k := range query {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	pairs := []string{}
+	for _, k := range names {
+		for _, v := range query[k] {
+			pairs = append(pairs, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes every byte but the unreserved characters, and
+// the slashes unless encodeSlash is set.
+func uriEncode(s string, encodeSlash bool) string {
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -170,10 +171,17 @@ func (c *CacheImpl) Delete(ctx context.Context, key string, opts ...DeleteOption
 	return c.client.Del(ctx, c.ns+key).Err()
 }
 
+// GetKeys returns the keys of the namespace matching pattern, without the
+// namespace.
 func (c *CacheImpl) GetKeys(ctx context.Context, pattern string) []string {
-	cmd := c.client.Keys(ctx, pattern)
-	keys, err := cmd.Result()
-	if err != nil {
+	iter := c.client.Scan(ctx, 0, c.ns+pattern, 0).Iterator()
+
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, strings.TrimPrefix(iter.Val(), c.ns))
+	}
+
+	if iter.Err() != nil {
 		return nil
 	}
 
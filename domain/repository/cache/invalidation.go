@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"log"
+	"strings"
+)
+
+// InvalidationChannel is the pub-sub channel every instance listens on to drop
+// entries from its LocalCache.
+const InvalidationChannel = "cache::invalidation"
+
+func WithPattern(pattern string) DeleteOptions {
+	return func(options *DeleteCache) {
+		options.Pattern = pattern
+	}
+}
+
+// Invalidate removes keys (or glob patterns) from redis and the local layer,
+// then broadcasts them so the other instances drop their local copies too.
+func Invalidate(ctx context.Context, c Cache, local *LocalCache, keys ...string) error {
+	for _, key := range keys {
+		var err error
+		if strings.ContainsAny(key, "*?[") {
+			err = c.Delete(ctx, key, WithPattern(key))
+		} else {
+			err = c.Delete(ctx, key)
+		}
+		if err != nil {
+			return err
+		}
+
+		if local != nil {
+			local.Delete(key)
+		}
+
+		if err = c.Publish(ctx, InvalidationChannel, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Listen drops local entries as invalidations arrive until ctx is done.
+func (lc *LocalCache) Listen(ctx context.Context, c Cache) error {
+	sub, err := c.Subscribe(ctx, InvalidationChannel)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	messages := sub.Channel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case key, ok := <-messages:
+			if !ok {
+				log.Println("cache invalidation channel closed")
+				return nil
+			}
+			lc.Delete(key)
+		}
+	}
+}
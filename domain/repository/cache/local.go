@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"container/list"
+	"path"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLocalSize = 1024
+	defaultLocalTTL  = time.Hour
+)
+
+// LocalCache is an in-process LRU kept in front of redis for hot, rarely
+// changing entries such as resource trees. Entries are dropped when an
+// invalidation is broadcast on InvalidationChannel, see Listen.
+type LocalCache struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type localEntry struct {
+	key       string
+	value     interface{}
+	expiredAt time.Time
+}
+
+func NewLocalCache(size int, ttl time.Duration) *LocalCache {
+	if size <= 0 {
+		size = defaultLocalSize
+	}
+
+	if ttl <= 0 {
+		ttl = defaultLocalTTL
+	}
+
+	return &LocalCache{
+		size:  size,
+		ttl:   ttl,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (lc *LocalCache) Get(key string) (interface{}, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	el, ok := lc.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*localEntry)
+	if time.Now().After(entry.expiredAt) {
+		lc.removeElement(el)
+		return nil, false
+	}
+
+	lc.ll.MoveToFront(el)
+	return entry.value, true
+}
+
+func (lc *LocalCache) Set(key string, value interface{}) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	expiredAt := time.Now().Add(lc.ttl)
+
+	if el, ok := lc.items[key]; ok {
+		entry := el.Value.(*localEntry)
+		entry.value = value
+		entry.expiredAt = expiredAt
+		lc.ll.MoveToFront(el)
+		return
+	}
+
+	lc.items[key] = lc.ll.PushFront(&localEntry{key: key, value: value, expiredAt: expiredAt})
+
+	if lc.ll.Len() > lc.size {
+		lc.removeElement(lc.ll.Back())
+	}
+}
+
+// Delete drops key, or every key matching it when it is a glob pattern.
+func (lc *LocalCache) Delete(pattern string) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if el, ok := lc.items[pattern]; ok {
+		lc.removeElement(el)
+		return
+	}
+
+	for key, el := range lc.items {
+		if ok, _ := path.Match(pattern, key); ok {
+			lc.removeElement(el)
+		}
+	}
+}
+
+func (lc *LocalCache) removeElement(el *list.Element) {
+	lc.ll.Remove(el)
+	delete(lc.items, el.Value.(*localEntry).key)
+}
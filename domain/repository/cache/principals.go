@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"github/yogabagas/join-app/shared/constant"
+)
+
+// PrincipalKey is the key of what the requests of userUID in orgUID are
+// checked against: their credential, their roles in window and the
+// separation of duties they break.
+func PrincipalKey(orgUID, userUID string) string {
+	return fmt.Sprintf(constant.UserPrincipal.String(), orgUID, userUID)
+}
+
+// RevokePrincipals drops the principals of userUIDs in orgUID, so their next
+// request reads their grants again.
+func RevokePrincipals(ctx context.Context, c Cache, orgUID string, userUIDs ...string) error {
+
+	for _, v := range userUIDs {
+		if err := c.Delete(ctx, PrincipalKey(orgUID, v)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RevokeOrganizationPrincipals drops the principals of every user of orgUID.
+func RevokeOrganizationPrincipals(ctx context.Context, c Cache, orgUID string) error {
+	pattern := fmt.Sprintf(constant.PrincipalPattern.String(), orgUID)
+	return c.Delete(ctx, pattern, WithPattern(pattern))
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"github/yogabagas/join-app/shared/constant"
+	"strings"
+)
+
+// SessionKey is the key of a session, the tokens issued before the sessions
+// had an id sharing the key of their user.
+func SessionKey(userUID, sessionUID string) string {
+	if sessionUID == "" {
+		return fmt.Sprintf(constant.UserAuth.String(), userUID)
+	}
+	return fmt.Sprintf(constant.UserSession.String(), userUID, sessionUID)
+}
+
+// RevokeSessions drops every session of userUID, so the next request has to
+// log in again.
+func RevokeSessions(ctx context.Context, c Cache, userUID string) error {
+	pattern := fmt.Sprintf(constant.UserAuth.String(), userUID) + "*"
+	return c.Delete(ctx, pattern, WithPattern(pattern))
+}
+
+// RevokeOtherSessions drops every session of userUID but sessionUID, which
+// keeps its remaining time.
+func RevokeOtherSessions(ctx context.Context, c Cache, userUID, sessionUID string) error {
+
+	key := SessionKey(userUID, sessionUID)
+
+	kept := c.Exist(ctx, key)
+	ttl := c.RemainingTime(ctx, key)
+
+	if err := RevokeSessions(ctx, c, userUID); err != nil {
+		return err
+	}
+
+	if !kept || ttl <= 0 {
+		return nil
+	}
+
+	return c.Set(ctx, key, true, ttl)
+}
+
+// Session is an open session of a user, ending in ExpiresIn seconds.
+type Session struct {
+	SessionUID string
+	ExpiresIn  int
+}
+
+// Sessions lists the open sessions of userUID, the session shared by the
+// tokens issued before the sessions had an id having no SessionUID.
+func Sessions(ctx context.Context, c Cache, userUID string) []Session {
+
+	prefix := fmt.Sprintf(constant.UserAuth.String(), userUID)
+
+	var sessions []Session
+	for _, key := range c.GetKeys(ctx, prefix+"*") {
+		var sid string
+		if key != prefix {
+			if _, err := fmt.Sscanf(strings.TrimPrefix(key, prefix), ":sid:%s", &sid); err != nil {
+				continue
+			}
+		}
+		sessions = append(sessions, Session{SessionUID: sid, ExpiresIn: c.RemainingTime(ctx, key)})
+	}
+
+	return sessions
+}
@@ -10,15 +10,29 @@ import (
 )
 
 const (
-	insertAccess             = `INSERT INTO access (uid, role_uid, resource_uid, created_by, updated_by) VALUES %s ON DUPLICATE KEY UPDATE is_deleted = false`
-	updateAccess             = `UPDATE access SET is_deleted = TRUE WHERE role_uid = ? AND resource_uid NOT IN (?)`
+	insertAccess = `INSERT INTO access (uid, organization_uid, role_uid, resource_uid, condition_expr, created_by, updated_by) VALUES %s 
+	ON DUPLICATE KEY UPDATE is_deleted = false, condition_expr = VALUES(condition_expr), updated_by = VALUES(updated_by), updated_at = now()`
+	updateAccess          = `UPDATE access SET is_deleted = TRUE WHERE organization_uid = ? AND role_uid = ? AND resource_uid NOT IN (?)`
+	deleteAccessByRoleUID = `UPDATE access SET is_deleted = TRUE, updated_by = ?, updated_at = now() 
+	WHERE organization_uid = ? AND role_uid = ? AND is_deleted = false`
 	selectResourcesByRoleUID = `WITH RECURSIVE menu_hierarchy AS (
-		SELECT uid, name, action, type, parent_uid, 1 as level FROM resources WHERE parent_uid IS NULL
+		SELECT uid, name, action, type, parent_uid, 1 as level FROM resources WHERE organization_uid = ? AND parent_uid IS NULL
 		UNION ALL
 		SELECT m.uid, m.name, m.action, m.type, m.parent_uid, mh.level + 1 FROM resources m
-		JOIN menu_hierarchy mh ON m.parent_uid = mh.uid)
-	  	SELECT mh.uid, a.role_uid, mh.name, mh.action, mh.type, mh.parent_uid, mh.level
-	  	FROM menu_hierarchy mh JOIN access a ON mh.uid = a.resource_uid WHERE role_uid = ? AND type = ?`
+		JOIN menu_hierarchy mh ON m.parent_uid = mh.uid WHERE m.organization_uid = ?)
+	  	SELECT mh.uid, a.role_uid, mh.name, mh.action, mh.type, mh.parent_uid, mh.level, a.condition_expr
+	  	FROM menu_hierarchy mh JOIN access a ON mh.uid = a.resource_uid 
+		WHERE a.organization_uid = ? AND role_uid = ? AND type = ? AND a.is_deleted = false ORDER BY mh.level, a.id`
+	selectAccessByResource = `SELECT a.uid, a.role_uid, a.resource_uid, a.condition_expr FROM access a JOIN resources r ON a.resource_uid = r.uid
+		WHERE a.organization_uid = ? AND r.organization_uid = a.organization_uid AND a.role_uid IN (%s) AND r.name = ? AND r.action = ? 
+		AND a.is_deleted = false AND r.is_deleted = false ORDER BY a.id ASC`
+	selectAccessByRoleUIDs = `SELECT uid, role_uid, resource_uid, condition_expr FROM access 
+	WHERE organization_uid = ? AND role_uid IN (%s) AND is_deleted = false ORDER BY id ASC`
+	selectAccessByRolesAndResources = `SELECT uid, role_uid, resource_uid, condition_expr FROM access 
+	WHERE organization_uid = ? AND role_uid IN (%s) AND resource_uid IN (%s) AND is_deleted = false ORDER BY id ASC`
+
+	// lockRoles locks the roles whose grants are about to be replaced.
+	lockRoles = `SELECT uid FROM roles WHERE organization_uid = ? AND uid IN (%s) FOR UPDATE`
 )
 
 type AccessRepositoryImpl struct {
@@ -37,25 +51,31 @@ func (ar *AccessRepositoryImpl) UpsertAccess(ctx context.Context, req []*model.A
 		resourcesUID []interface{}
 	)
 
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
 	if len(req) > 0 {
 		for _, v := range req {
-			values = append(values, "(?,?,?,?,?)")
-			args = append(args, v.UID, v.RoleUID, v.ResourceUID, v.CreatedBy, v.UpdatedBy)
+			condition := sql.NullString{String: v.Condition, Valid: v.Condition != ""}
+
+			values = append(values, "(?,?,?,?,?,?,?)")
+			args = append(args, v.UID, orgUID, v.RoleUID, v.ResourceUID, condition, v.CreatedBy, v.UpdatedBy)
 			roleUID = v.RoleUID
 			resourcesUID = append(resourcesUID, v.ResourceUID)
 		}
 
 		q := fmt.Sprintf(insertAccess, strings.Join(values, ", "))
 
-		_, err := ar.db.ExecContext(ctx, q, args...)
+		_, err = ar.db.ExecContext(ctx, q, args...)
 		if err != nil {
 			return err
 		}
 
-		placeHolder := strings.Join(strings.Split(strings.Repeat("?", len(req)), ""), ", ")
-		updateQuery := strings.Replace(updateAccess, "(?)", "("+placeHolder+")", 1)
+		updateQuery := strings.Replace(updateAccess, "(?)", "("+placeholders(len(req))+")", 1)
 
-		_, err = ar.db.ExecContext(ctx, updateQuery, append([]interface{}{roleUID}, resourcesUID...)...)
+		_, err = ar.db.ExecContext(ctx, updateQuery, append([]interface{}{orgUID, roleUID}, resourcesUID...)...)
 		if err != nil {
 			return err
 		}
@@ -66,7 +86,12 @@ func (ar *AccessRepositoryImpl) UpsertAccess(ctx context.Context, req []*model.A
 
 func (ar *AccessRepositoryImpl) ReadAccessByRoleUID(ctx context.Context, req *model.ReadAccessByRoleUIDReq) (resp []*model.ReadAccessByRoleUIDResp, err error) {
 
-	rows, err := ar.db.QueryContext(ctx, selectResourcesByRoleUID, req.RoleUID, req.Type)
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.db.QueryContext(ctx, selectResourcesByRoleUID, orgUID, orgUID, orgUID, req.RoleUID, req.Type)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
 	}
@@ -74,7 +99,7 @@ func (ar *AccessRepositoryImpl) ReadAccessByRoleUID(ctx context.Context, req *mo
 	for rows.Next() {
 		res := &model.ReadAccessByRoleUIDResp{}
 
-		err = rows.Scan(&res.UID, &res.RoleUID, &res.Name, &res.Action, &res.Type, &res.ParentUID, &res.Level)
+		err = rows.Scan(&res.UID, &res.RoleUID, &res.Name, &res.Action, &res.Type, &res.ParentUID, &res.Level, &res.Condition)
 		if err != nil {
 			return nil, err
 		}
@@ -83,3 +108,142 @@ func (ar *AccessRepositoryImpl) ReadAccessByRoleUID(ctx context.Context, req *mo
 
 	return
 }
+
+func (ar *AccessRepositoryImpl) ReadAccessByResource(ctx context.Context, req *model.ReadAccessByResourceReq) (resp []*model.ReadAccessByResourceResp, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.RoleUIDs) == 0 {
+		return nil, nil
+	}
+
+	args := []interface{}{orgUID}
+	for _, v := range req.RoleUIDs {
+		args = append(args, v)
+	}
+
+	rows, err := ar.db.QueryContext(ctx, fmt.Sprintf(selectAccessByResource, placeholders(len(req.RoleUIDs))),
+		append(args, req.Name, req.Action)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.ReadAccessByResourceResp{}
+
+		err = rows.Scan(&res.UID, &res.RoleUID, &res.ResourceUID, &res.Condition)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (ar *AccessRepositoryImpl) ReadAccessByRolesAndResources(ctx context.Context, req *model.ReadAccessByRolesAndResourcesReq) (resp []*model.ReadAccessByResourceResp, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.RoleUIDs) == 0 || len(req.ResourceUIDs) == 0 {
+		return nil, nil
+	}
+
+	args := []interface{}{orgUID}
+	for _, v := range req.RoleUIDs {
+		args = append(args, v)
+	}
+	for _, v := range req.ResourceUIDs {
+		args = append(args, v)
+	}
+
+	q := fmt.Sprintf(selectAccessByRolesAndResources, placeholders(len(req.RoleUIDs)), placeholders(len(req.ResourceUIDs)))
+
+	rows, err := ar.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.ReadAccessByResourceResp{}
+
+		err = rows.Scan(&res.UID, &res.RoleUID, &res.ResourceUID, &res.Condition)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (ar *AccessRepositoryImpl) ReadAccessByRoleUIDs(ctx context.Context, req *model.ReadAccessByRoleUIDsReq) (resp []*model.Access, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.RoleUIDs) == 0 {
+		return nil, nil
+	}
+
+	args := []interface{}{orgUID}
+	for _, v := range req.RoleUIDs {
+		args = append(args, v)
+	}
+
+	if req.ForUpdate {
+		locked, err := ar.db.QueryContext(ctx, fmt.Sprintf(lockRoles, placeholders(len(req.RoleUIDs))), args...)
+		if err != nil {
+			return nil, err
+		}
+		if err = locked.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := ar.db.QueryContext(ctx, fmt.Sprintf(selectAccessByRoleUIDs, placeholders(len(req.RoleUIDs))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.Access{}
+		condition := sql.NullString{}
+
+		err = rows.Scan(&res.UID, &res.RoleUID, &res.ResourceUID, &condition)
+		if err != nil {
+			return nil, err
+		}
+		res.Condition = condition.String
+
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (ar *AccessRepositoryImpl) DeleteAccessByRoleUID(ctx context.Context, req *model.DeleteAccessByRoleUIDReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = ar.db.ExecContext(ctx, deleteAccessByRoleUID, req.UpdatedBy, orgUID, req.RoleUID)
+	return err
+}
+
+func placeholders(n int) string {
+	return strings.Join(strings.Split(strings.Repeat("?", n), ""), ", ")
+}
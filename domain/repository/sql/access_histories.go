@@ -0,0 +1,142 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/service/access/repository"
+)
+
+const (
+	insertAccessHistory = `INSERT INTO access_histories (uid, organization_uid, role_uid, version, snapshot, added, removed, changed, note, created_by)
+	VALUES (?,?,?,?,?,?,?,?,?,?)`
+	selectLatestAccessVersion = `SELECT COALESCE(MAX(version), 0) FROM access_histories 
+	WHERE organization_uid = ? AND role_uid = ? FOR UPDATE`
+	selectAccessHistoriesByRoleUID = `SELECT id, uid, role_uid, version, snapshot, added, removed, changed, note, created_by, created_at
+	FROM access_histories WHERE organization_uid = ? AND role_uid = ? ORDER BY version DESC`
+	selectAccessHistoryByVersion = `SELECT id, uid, role_uid, version, snapshot, added, removed, changed, note, created_by, created_at
+	FROM access_histories WHERE organization_uid = ? AND role_uid = ? AND version = ?`
+)
+
+type AccessHistoriesRepositoryImpl struct {
+	db DBExecutor
+}
+
+func NewAccessHistoriesRepository(db DBExecutor) repository.AccessHistoriesRepository {
+	return &AccessHistoriesRepositoryImpl{db: db}
+}
+
+func (ahr *AccessHistoriesRepositoryImpl) CreateAccessHistory(ctx context.Context, req *model.AccessHistory) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	var args []interface{}
+	for _, items := range [][]model.AccessSnapshotItem{req.Snapshot, req.Added, req.Removed, req.Changed} {
+		if items == nil {
+			items = []model.AccessSnapshotItem{}
+		}
+
+		b, err := json.Marshal(items)
+		if err != nil {
+			return err
+		}
+		args = append(args, b)
+	}
+
+	_, err = ahr.db.ExecContext(ctx, insertAccessHistory, append(append([]interface{}{req.UID, orgUID, req.RoleUID, req.Version}, args...),
+		req.Note, req.CreatedBy)...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ahr *AccessHistoriesRepositoryImpl) ReadLatestAccessVersion(ctx context.Context, req *model.ReadLatestAccessVersionReq) (version int, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	err = ahr.db.QueryRowContext(ctx, selectLatestAccessVersion, orgUID, req.RoleUID).Scan(&version)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (ahr *AccessHistoriesRepositoryImpl) ReadAccessHistoriesByRoleUID(ctx context.Context, req *model.ReadAccessHistoriesByRoleUIDReq) (resp []*model.AccessHistory, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ahr.db.QueryContext(ctx, selectAccessHistoriesByRoleUID, orgUID, req.RoleUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res, err := scanAccessHistory(rows)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (ahr *AccessHistoriesRepositoryImpl) ReadAccessHistoryByVersion(ctx context.Context, req *model.ReadAccessHistoryByVersionReq) (*model.AccessHistory, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanAccessHistory(ahr.db.QueryRowContext(ctx, selectAccessHistoryByVersion, orgUID, req.RoleUID, req.Version))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAccessHistory(row scanner) (*model.AccessHistory, error) {
+
+	res := &model.AccessHistory{}
+
+	var snapshot, added, removed, changed []byte
+
+	err := row.Scan(&res.ID, &res.UID, &res.RoleUID, &res.Version, &snapshot, &added, &removed, &changed,
+		&res.Note, &res.CreatedBy, &res.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range []struct {
+		raw  []byte
+		dest *[]model.AccessSnapshotItem
+	}{{snapshot, &res.Snapshot}, {added, &res.Added}, {removed, &res.Removed}, {changed, &res.Changed}} {
+		if err = json.Unmarshal(v.raw, v.dest); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,250 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/service/accessReviews/repository"
+	"github/yogabagas/join-app/shared/constant"
+	"strings"
+)
+
+const (
+	insertAccessReview = `INSERT INTO access_reviews (uid, organization_uid, name, deadline, status, created_by, updated_by)
+	VALUES (?,?,?,?,?,?,?)`
+	insertAccessReviewItems = `INSERT INTO access_review_items (uid, organization_uid, review_uid, authz_uid, user_uid, role_uid,
+	reviewer_uid, note) VALUES %s`
+	selectAccessReviews = `SELECT id, uid, name, deadline, status, closed_at, created_by, created_at, updated_by, updated_at
+	FROM access_reviews WHERE organization_uid = ? AND (? = '' OR status = ?) ORDER BY id DESC`
+	selectAccessReviewByUID = `SELECT id, uid, name, deadline, status, closed_at, created_by, created_at, updated_by, updated_at
+	FROM access_reviews WHERE organization_uid = ? AND uid = ?`
+	// The deadlines are bound in UTC by the driver, so they are compared
+	// with UTC_TIMESTAMP() rather than the now() of the session time zone.
+	selectOverdueAccessReviews = `SELECT id, uid, name, deadline, status, closed_at, created_by, created_at, updated_by, updated_at
+	FROM access_reviews WHERE organization_uid = ? AND status = ? AND deadline <= UTC_TIMESTAMP() ORDER BY id ASC`
+	selectAccessReviewItems = `SELECT i.id, i.uid, i.review_uid, i.authz_uid, i.user_uid, IFNULL(u.email, ''), i.role_uid,
+	IFNULL(r.name, ''), i.reviewer_uid, i.decision, i.decided_by, i.decided_at, i.note FROM access_review_items i
+	LEFT JOIN users u ON u.uid = i.user_uid LEFT JOIN roles r ON r.uid = i.role_uid
+	WHERE i.organization_uid = ? AND i.review_uid = ? AND (? = '' OR i.reviewer_uid = ?) AND (? = false OR i.decision = '')
+	ORDER BY i.id ASC`
+	selectAccessReviewItemByUID = `SELECT i.id, i.uid, i.review_uid, i.authz_uid, i.user_uid, IFNULL(u.email, ''), i.role_uid,
+	IFNULL(r.name, ''), i.reviewer_uid, i.decision, i.decided_by, i.decided_at, i.note FROM access_review_items i
+	LEFT JOIN users u ON u.uid = i.user_uid LEFT JOIN roles r ON r.uid = i.role_uid
+	WHERE i.organization_uid = ? AND i.review_uid = ? AND i.uid = ?`
+	updateAccessReviewItemDecision = `UPDATE access_review_items SET decision = ?, decided_by = ?, decided_at = now(), note = ?
+	WHERE organization_uid = ? AND uid = ? AND decision = ''`
+	updateAccessReviewClosed = `UPDATE access_reviews SET status = ?, closed_at = now(), updated_by = ?, updated_at = now()
+	WHERE organization_uid = ? AND uid = ? AND status = ?`
+
+	accessReviewItemsBatch = 500
+)
+
+type AccessReviewsRepositoryImpl struct {
+	db DBExecutor
+}
+
+func NewAccessReviewsRepository(db DBExecutor) repository.AccessReviewsRepository {
+	return &AccessReviewsRepositoryImpl{db: db}
+}
+
+func (ar *AccessReviewsRepositoryImpl) CreateAccessReview(ctx context.Context, req *model.AccessReview) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = ar.db.ExecContext(ctx, insertAccessReview, req.UID, orgUID, req.Name, req.Deadline, req.Status,
+		req.CreatedBy, req.UpdatedBy)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ar *AccessReviewsRepositoryImpl) CreateAccessReviewItems(ctx context.Context, req []*model.AccessReviewItem) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(req); start += accessReviewItemsBatch {
+		end := start + accessReviewItemsBatch
+		if end > len(req) {
+			end = len(req)
+		}
+
+		values := []string{}
+		args := []interface{}{}
+
+		for _, v := range req[start:end] {
+			values = append(values, "(?,?,?,?,?,?,?,?)")
+			args = append(args, v.UID, orgUID, v.ReviewUID, v.AuthzUID, v.UserUID, v.RoleUID, v.ReviewerUID, v.Note)
+		}
+
+		_, err = ar.db.ExecContext(ctx, fmt.Sprintf(insertAccessReviewItems, strings.Join(values, ", ")), args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ar *AccessReviewsRepositoryImpl) ReadAccessReviews(ctx context.Context, req *model.ReadAccessReviewsReq) ([]*model.AccessReview, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ar.readAccessReviews(ctx, selectAccessReviews, orgUID, req.Status, req.Status)
+}
+
+func (ar *AccessReviewsRepositoryImpl) ReadAccessReviewByUID(ctx context.Context, req *model.ReadAccessReviewByUIDReq) (*model.AccessReview, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &model.AccessReview{}
+
+	err = ar.db.QueryRowContext(ctx, selectAccessReviewByUID, orgUID, req.UID).
+		Scan(&res.ID, &res.UID, &res.Name, &res.Deadline, &res.Status, &res.ClosedAt, &res.CreatedBy, &res.CreatedAt,
+			&res.UpdatedBy, &res.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ar *AccessReviewsRepositoryImpl) ReadOverdueAccessReviews(ctx context.Context) ([]*model.AccessReview, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ar.readAccessReviews(ctx, selectOverdueAccessReviews, orgUID, constant.ReviewOpen.String())
+}
+
+func (ar *AccessReviewsRepositoryImpl) readAccessReviews(ctx context.Context, query string, args ...interface{}) (resp []*model.AccessReview, err error) {
+
+	rows, err := ar.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.AccessReview{}
+
+		err = rows.Scan(&res.ID, &res.UID, &res.Name, &res.Deadline, &res.Status, &res.ClosedAt, &res.CreatedBy, &res.CreatedAt,
+			&res.UpdatedBy, &res.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (ar *AccessReviewsRepositoryImpl) ReadAccessReviewItems(ctx context.Context, req *model.ReadAccessReviewItemsReq) (resp []*model.AccessReviewItem, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.db.QueryContext(ctx, selectAccessReviewItems, orgUID, req.ReviewUID, req.ReviewerUID, req.ReviewerUID, req.Pending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.AccessReviewItem{}
+
+		err = rows.Scan(&res.ID, &res.UID, &res.ReviewUID, &res.AuthzUID, &res.UserUID, &res.UserEmail, &res.RoleUID,
+			&res.RoleName, &res.ReviewerUID, &res.Decision, &res.DecidedBy, &res.DecidedAt, &res.Note)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (ar *AccessReviewsRepositoryImpl) ReadAccessReviewItemByUID(ctx context.Context, req *model.ReadAccessReviewItemByUIDReq) (*model.AccessReviewItem, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &model.AccessReviewItem{}
+
+	err = ar.db.QueryRowContext(ctx, selectAccessReviewItemByUID, orgUID, req.ReviewUID, req.UID).
+		Scan(&res.ID, &res.UID, &res.ReviewUID, &res.AuthzUID, &res.UserUID, &res.UserEmail, &res.RoleUID,
+			&res.RoleName, &res.ReviewerUID, &res.Decision, &res.DecidedBy, &res.DecidedAt, &res.Note)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DecideAccessReviewItem only records the first decision on an item.
+func (ar *AccessReviewsRepositoryImpl) DecideAccessReviewItem(ctx context.Context, req *model.DecideAccessReviewItemReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := ar.db.ExecContext(ctx, updateAccessReviewItemDecision, req.Decision, req.DecidedBy, req.Note, orgUID, req.UID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.New("access review item is already decided")
+	}
+
+	return nil
+}
+
+func (ar *AccessReviewsRepositoryImpl) CloseAccessReview(ctx context.Context, req *model.CloseAccessReviewReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = ar.db.ExecContext(ctx, updateAccessReviewClosed, constant.ReviewClosed.String(), req.UpdatedBy, orgUID, req.UID,
+		constant.ReviewOpen.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,14 +2,36 @@ package sql
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"github/yogabagas/join-app/domain/model"
 	"github/yogabagas/join-app/service/authz/repository"
 	"time"
 )
 
 const (
-	insertAuthz = `INSERT INTO authz (uid, user_uid, role_uid, last_active, created_by, updated_by)
-	VALUES (?,?,?,?,?,?)`
+	insertAuthz = `INSERT INTO authz (uid, organization_uid, user_uid, role_uid, last_active, valid_from, valid_until, created_by, updated_by)
+	SELECT ?, u.organization_uid, u.uid, r.uid, ?, ?, ?, ?, ? FROM users u JOIN roles r ON r.organization_uid = u.organization_uid
+	WHERE u.organization_uid = ? AND u.uid = ? AND u.is_deleted = false AND r.uid = ? AND r.is_deleted = false`
+	selectAuthzByUserUID = `SELECT a.uid, a.role_uid, r.name FROM authz a JOIN roles r ON a.role_uid = r.uid 
+	WHERE a.organization_uid = ? AND a.user_uid = ? AND a.is_deleted = false AND r.is_deleted = false AND ` + authzInWindow + ` ORDER BY r.id ASC`
+	selectExpiredAuthz = `SELECT uid, user_uid, role_uid, valid_until FROM authz
+	WHERE organization_uid = ? AND is_deleted = false AND valid_until IS NOT NULL AND valid_until <= UTC_TIMESTAMP()`
+	selectUnexpiredAuthz = `SELECT uid, user_uid, role_uid, valid_from, valid_until FROM authz
+	WHERE organization_uid = ? AND (? = '' OR user_uid = ?) AND is_deleted = false AND (valid_until IS NULL OR valid_until > UTC_TIMESTAMP())
+	ORDER BY user_uid, id ASC`
+	// lockUser locks the user row, so the grants of a user holding none yet
+	// are serialized too.
+	lockUser          = `SELECT uid FROM users WHERE organization_uid = ? AND uid = ? FOR UPDATE`
+	deleteAuthzByUIDs = `UPDATE authz SET is_deleted = true, updated_by = ?, updated_at = now() 
+	WHERE organization_uid = ? AND is_deleted = false AND uid IN (%s)`
+
+	// authzInWindow keeps grants of alias a whose validity window is open. The
+	// windows are written in UTC, the driver converting the times it binds, so
+	// they are compared with UTC_TIMESTAMP() rather than now(), which follows
+	// the time zone of the session.
+	authzInWindow = `(a.valid_from IS NULL OR a.valid_from <= UTC_TIMESTAMP()) AND (a.valid_until IS NULL OR a.valid_until > UTC_TIMESTAMP())`
 )
 
 type AuthzRepositoryImpl struct {
@@ -22,13 +44,139 @@ func NewAuthzRepository(db DBExecutor) repository.AuthzRepository {
 
 func (ar *AuthzRepositoryImpl) CreateAuthz(ctx context.Context, req *model.Authz) error {
 
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
 	now := time.Now()
 
-	_, err := ar.db.ExecContext(ctx, insertAuthz, req.UID, req.UserUID, req.RoleUID, now, req.CreatedBy, req.UpdatedBy)
+	res, err := ar.db.ExecContext(ctx, insertAuthz, req.UID, now, req.ValidFrom, req.ValidUntil, req.CreatedBy, req.UpdatedBy,
+		orgUID, req.UserUID, req.RoleUID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
 
+	if affected == 0 {
+		return errors.New("user or role is not found in the organization")
+	}
+
 	return nil
 
 }
+
+func (ar *AuthzRepositoryImpl) ReadAuthzByUserUID(ctx context.Context, req *model.ReadAuthzByUserUIDReq) (resp []*model.ReadAuthzByUserUIDResp, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.db.QueryContext(ctx, selectAuthzByUserUID, orgUID, req.UserUID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.ReadAuthzByUserUIDResp{}
+
+		err = rows.Scan(&res.UID, &res.RoleUID, &res.RoleName)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (ar *AuthzRepositoryImpl) ReadExpiredAuthz(ctx context.Context) (resp []*model.Authz, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.db.QueryContext(ctx, selectExpiredAuthz, orgUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.Authz{}
+
+		err = rows.Scan(&res.UID, &res.UserUID, &res.RoleUID, &res.ValidUntil)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (ar *AuthzRepositoryImpl) ReadUnexpiredAuthz(ctx context.Context, req *model.ReadUnexpiredAuthzReq) (resp []*model.Authz, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := selectUnexpiredAuthz
+	if req.ForUpdate {
+		var uid string
+		if err = ar.db.QueryRowContext(ctx, lockUser, orgUID, req.UserUID).Scan(&uid); err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		query += " FOR UPDATE"
+	}
+
+	rows, err := ar.db.QueryContext(ctx, query, orgUID, req.UserUID, req.UserUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.Authz{}
+
+		err = rows.Scan(&res.UID, &res.UserUID, &res.RoleUID, &res.ValidFrom, &res.ValidUntil)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (ar *AuthzRepositoryImpl) DeleteAuthzByUIDs(ctx context.Context, req *model.DeleteAuthzByUIDsReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(req.UIDs) == 0 {
+		return nil
+	}
+
+	args := []interface{}{req.UpdatedBy, orgUID}
+	for _, v := range req.UIDs {
+		args = append(args, v)
+	}
+
+	_, err = ar.db.ExecContext(ctx, fmt.Sprintf(deleteAuthzByUIDs, placeholders(len(req.UIDs))), args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
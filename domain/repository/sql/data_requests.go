@@ -0,0 +1,90 @@
+package sql
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/service/users/repository"
+)
+
+const (
+	insertDataRequest = `INSERT INTO data_requests (uid, organization_uid, user_uid, kind, status, note, created_by, completed_by, completed_at)
+	VALUES (?,?,?,?,?,?,?,?,?)`
+	selectDataRequests = `SELECT id, uid, user_uid, kind, status, note, archive_key, error, created_by, created_at, completed_by,
+	completed_at, expires_at FROM data_requests WHERE organization_uid = ? AND (? = '' OR user_uid = ?) AND (? = '' OR kind = ?)
+	AND (? = '' OR status = ?) AND (? IS NULL OR expires_at < ?) ORDER BY id DESC LIMIT ?`
+	updateDataRequestStatus = `UPDATE data_requests SET status = ?, archive_key = COALESCE(NULLIF(?, ''), archive_key), error = ?,
+	completed_by = COALESCE(NULLIF(?, ''), completed_by), completed_at = IF(? IN ('completed', 'failed'), now(), completed_at),
+	expires_at = COALESCE(?, expires_at) WHERE organization_uid = ? AND uid = ? AND status = ?`
+)
+
+type DataRequestsRepositoryImpl struct {
+	db DBExecutor
+}
+
+func NewDataRequestsRepository(db DBExecutor) repository.DataRequestsRepository {
+	return &DataRequestsRepositoryImpl{db: db}
+}
+
+func (dr *DataRequestsRepositoryImpl) CreateDataRequest(ctx context.Context, req *model.DataRequest) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dr.db.ExecContext(ctx, insertDataRequest, req.UID, orgUID, req.UserUID, req.Kind, req.Status, req.Note,
+		req.CreatedBy, req.CompletedBy, req.CompletedAt)
+	return err
+}
+
+// ReadDataRequests returns the requests matching req, the latest first.
+func (dr *DataRequestsRepositoryImpl) ReadDataRequests(ctx context.Context, req *model.ReadDataRequestsReq) (resp []*model.DataRequest, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := dr.db.QueryContext(ctx, selectDataRequests, orgUID, req.UserUID, req.UserUID, req.Kind, req.Kind,
+		req.Status, req.Status, req.ExpiredBefore, req.ExpiredBefore, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.DataRequest{}
+
+		err = rows.Scan(&res.ID, &res.UID, &res.UserUID, &res.Kind, &res.Status, &res.Note, &res.ArchiveKey, &res.Error,
+			&res.CreatedBy, &res.CreatedAt, &res.CompletedBy, &res.CompletedAt, &res.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+// UpdateDataRequestStatus tells whether the request was still in req.From,
+// the workers claiming a pending request this way.
+func (dr *DataRequestsRepositoryImpl) UpdateDataRequestStatus(ctx context.Context, req *model.UpdateDataRequestStatusReq) (bool, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := dr.db.ExecContext(ctx, updateDataRequestStatus, req.Status, req.ArchiveKey, req.Error, req.CompletedBy,
+		req.Status, req.ExpiresAt, orgUID, req.UID, req.From)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
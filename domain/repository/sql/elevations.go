@@ -0,0 +1,125 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/service/elevations/repository"
+	"github/yogabagas/join-app/shared/constant"
+)
+
+const (
+	insertElevationRequest = `INSERT INTO elevation_requests (uid, organization_uid, user_uid, role_uid, justification, duration_minutes,
+	status, decision_note, created_by, updated_by) VALUES (?,?,?,?,?,?,?,'',?,?)`
+	selectElevationRequests = `SELECT id, uid, user_uid, role_uid, justification, duration_minutes, status, decided_by, decided_at,
+	decision_note, authz_uid, created_by, created_at, updated_by, updated_at FROM elevation_requests
+	WHERE organization_uid = ? AND (? = '' OR user_uid = ?) AND (? = '' OR status = ?) ORDER BY id DESC`
+	selectElevationRequestByUID = `SELECT id, uid, user_uid, role_uid, justification, duration_minutes, status, decided_by, decided_at,
+	decision_note, authz_uid, created_by, created_at, updated_by, updated_at FROM elevation_requests
+	WHERE organization_uid = ? AND uid = ?`
+	updateElevationDecision = `UPDATE elevation_requests SET status = ?, decided_by = ?, decided_at = now(), decision_note = ?,
+	authz_uid = ?, updated_by = ?, updated_at = now() WHERE organization_uid = ? AND uid = ? AND status = ?`
+)
+
+type ElevationsRepositoryImpl struct {
+	db DBExecutor
+}
+
+func NewElevationsRepository(db DBExecutor) repository.ElevationsRepository {
+	return &ElevationsRepositoryImpl{db: db}
+}
+
+func (er *ElevationsRepositoryImpl) CreateElevationRequest(ctx context.Context, req *model.ElevationRequest) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = er.db.ExecContext(ctx, insertElevationRequest, req.UID, orgUID, req.UserUID, req.RoleUID, req.Justification,
+		req.Duration, req.Status, req.CreatedBy, req.UpdatedBy)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (er *ElevationsRepositoryImpl) ReadElevationRequests(ctx context.Context, req *model.ReadElevationRequestsReq) (resp []*model.ElevationRequest, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := er.db.QueryContext(ctx, selectElevationRequests, orgUID, req.UserUID, req.UserUID, req.Status, req.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.ElevationRequest{}
+
+		err = rows.Scan(&res.ID, &res.UID, &res.UserUID, &res.RoleUID, &res.Justification, &res.Duration, &res.Status,
+			&res.DecidedBy, &res.DecidedAt, &res.DecisionNote, &res.AuthzUID, &res.CreatedBy, &res.CreatedAt,
+			&res.UpdatedBy, &res.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (er *ElevationsRepositoryImpl) ReadElevationRequestByUID(ctx context.Context, req *model.ReadElevationRequestByUIDReq) (*model.ElevationRequest, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &model.ElevationRequest{}
+
+	err = er.db.QueryRowContext(ctx, selectElevationRequestByUID, orgUID, req.UID).
+		Scan(&res.ID, &res.UID, &res.UserUID, &res.RoleUID, &res.Justification, &res.Duration, &res.Status,
+			&res.DecidedBy, &res.DecidedAt, &res.DecisionNote, &res.AuthzUID, &res.CreatedBy, &res.CreatedAt,
+			&res.UpdatedBy, &res.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DecideElevationRequest only moves pending requests, so two approvers racing
+// on the same request can't both grant it.
+func (er *ElevationsRepositoryImpl) DecideElevationRequest(ctx context.Context, req *model.DecideElevationRequestReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := er.db.ExecContext(ctx, updateElevationDecision, req.Status, req.DecidedBy, req.DecisionNote, req.AuthzUID,
+		req.DecidedBy, orgUID, req.UID, constant.ElevationPending.String())
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.New("elevation request is no longer pending")
+	}
+
+	return nil
+}
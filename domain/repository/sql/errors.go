@@ -0,0 +1,32 @@
+package sql
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// ErrDuplicate is wrapped by the repositories when a write breaks a unique
+// key, such as an email already taken in the organization.
+var ErrDuplicate = errors.New("duplicate entry")
+
+// ErrNoRow is wrapped by the repositories when the row to update doesn't
+// exist, and ErrUnchanged when it exists but isn't in the state the update
+// moves it from.
+var (
+	ErrNoRow     = errors.New("no such row")
+	ErrUnchanged = errors.New("row is not in the expected state")
+)
+
+// mysqlDuplicateEntry is the error number of MySQL for a unique key broken.
+const mysqlDuplicateEntry = 1062
+
+// duplicateOf wraps ErrDuplicate around err when it broke a unique key.
+func duplicateOf(err error) error {
+	var me *mysql.MySQLError
+	if errors.As(err, &me) && me.Number == mysqlDuplicateEntry {
+		return fmt.Errorf("%w: %s", ErrDuplicate, me.Message)
+	}
+	return err
+}
@@ -0,0 +1,217 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/service/expertises/repository"
+	"strings"
+)
+
+const (
+	insertExpertise  = `INSERT INTO expertises (uid, organization_uid, name, created_by, updated_by) VALUES (?,?,?,?,?)`
+	selectExpertises = `SELECT id, uid, name, is_deleted, created_by, created_at, updated_by, updated_at FROM expertises
+	WHERE organization_uid = ? AND is_deleted = false AND name LIKE ? ORDER BY name ASC`
+	selectExpertisesByUIDs = `SELECT id, uid, name, is_deleted, created_by, created_at, updated_by, updated_at FROM expertises
+	WHERE organization_uid = ? AND is_deleted = false AND uid IN (%s) ORDER BY name ASC`
+	updateExpertise = `UPDATE expertises SET name = ?, updated_by = ?, updated_at = now()
+	WHERE organization_uid = ? AND uid = ? AND is_deleted = false`
+	deleteExpertise = `UPDATE expertises SET is_deleted = true, updated_by = ?, updated_at = now()
+	WHERE organization_uid = ? AND uid = ? AND is_deleted = false`
+	selectUserExpertises = `SELECT ue.user_uid, e.uid, e.name FROM user_expertises ue JOIN expertises e ON e.uid = ue.expertise_uid
+	WHERE e.organization_uid = ? AND e.is_deleted = false AND ue.user_uid IN (%s) ORDER BY e.name ASC`
+	deleteUserExpertises = `DELETE ue FROM user_expertises ue JOIN users u ON u.uid = ue.user_uid
+	WHERE u.organization_uid = ? AND ue.user_uid = ?`
+	insertUserExpertises = `INSERT INTO user_expertises (user_uid, expertise_uid) SELECT u.uid, e.uid FROM users u
+	JOIN expertises e ON e.organization_uid = u.organization_uid
+	WHERE u.organization_uid = ? AND u.uid = ? AND u.is_deleted = false AND e.is_deleted = false AND e.uid IN (%s)`
+)
+
+// likeEscape escapes the LIKE wildcards of a value, which is then matched as
+// typed.
+var likeEscape = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type ExpertisesRepositoryImpl struct {
+	db DBExecutor
+}
+
+func NewExpertisesRepository(db DBExecutor) repository.ExpertisesRepository {
+	return &ExpertisesRepositoryImpl{db: db}
+}
+
+func (er *ExpertisesRepositoryImpl) CreateExpertise(ctx context.Context, req *model.Expertise) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = er.db.ExecContext(ctx, insertExpertise, req.UID, orgUID, req.Name, req.CreatedBy, req.UpdatedBy)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (er *ExpertisesRepositoryImpl) ReadExpertises(ctx context.Context, req *model.ReadExpertisesReq) ([]*model.Expertise, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := selectExpertises
+	args := []interface{}{orgUID, likeEscape.Replace(req.Prefix) + "%"}
+
+	if req.Limit > 0 {
+		q += " LIMIT ?"
+		args = append(args, req.Limit)
+	}
+
+	return er.readExpertises(ctx, q, args...)
+}
+
+func (er *ExpertisesRepositoryImpl) ReadExpertisesByUIDs(ctx context.Context, req *model.ReadExpertisesByUIDsReq) ([]*model.Expertise, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.UIDs) == 0 {
+		return nil, nil
+	}
+
+	args := []interface{}{orgUID}
+	for _, v := range req.UIDs {
+		args = append(args, v)
+	}
+
+	return er.readExpertises(ctx, fmt.Sprintf(selectExpertisesByUIDs, placeholders(len(req.UIDs))), args...)
+}
+
+func (er *ExpertisesRepositoryImpl) readExpertises(ctx context.Context, q string, args ...interface{}) (resp []*model.Expertise, err error) {
+
+	rows, err := er.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.Expertise{}
+
+		err = rows.Scan(&res.ID, &res.UID, &res.Name, &res.IsDeleted, &res.CreatedBy, &res.CreatedAt, &res.UpdatedBy, &res.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (er *ExpertisesRepositoryImpl) UpdateExpertise(ctx context.Context, req *model.UpdateExpertiseReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = er.db.ExecContext(ctx, updateExpertise, req.Name, req.UpdatedBy, orgUID, req.UID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (er *ExpertisesRepositoryImpl) DeleteExpertise(ctx context.Context, req *model.DeleteExpertiseReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := er.db.ExecContext(ctx, deleteExpertise, req.UpdatedBy, orgUID, req.UID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.New("expertise is not found")
+	}
+
+	return nil
+}
+
+func (er *ExpertisesRepositoryImpl) ReadUserExpertises(ctx context.Context, req *model.ReadUserExpertisesReq) (resp []*model.UserExpertise, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.UserUIDs) == 0 {
+		return nil, nil
+	}
+
+	args := []interface{}{orgUID}
+	for _, v := range req.UserUIDs {
+		args = append(args, v)
+	}
+
+	rows, err := er.db.QueryContext(ctx, fmt.Sprintf(selectUserExpertises, placeholders(len(req.UserUIDs))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.UserExpertise{}
+
+		if err = rows.Scan(&res.UserUID, &res.ExpertiseUID, &res.Name); err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+// ReplaceUserExpertises swaps the expertises of the user for the given ones,
+// it should run in a transaction.
+func (er *ExpertisesRepositoryImpl) ReplaceUserExpertises(ctx context.Context, req *model.ReplaceUserExpertisesReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err = er.db.ExecContext(ctx, deleteUserExpertises, orgUID, req.UserUID); err != nil {
+		return err
+	}
+
+	if len(req.ExpertiseUIDs) == 0 {
+		return nil
+	}
+
+	args := []interface{}{orgUID, req.UserUID}
+	for _, v := range req.ExpertiseUIDs {
+		args = append(args, v)
+	}
+
+	_, err = er.db.ExecContext(ctx, fmt.Sprintf(insertUserExpertises, placeholders(len(req.ExpertiseUIDs))), args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
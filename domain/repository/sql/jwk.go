@@ -9,6 +9,9 @@ import (
 	"time"
 )
 
+// Signing keys are shared by every organization, the tenant travels inside the
+// signed token instead, so unlike the other repositories this one is not
+// scoped by the organization in ctx.
 const (
 	insertJWK              = "INSERT INTO jwk (id, `key`, expired_at) VALUES (?,?,?)"
 	updateJWK              = "UPDATE jwk SET `key` = ?, expired_at = ? WHERE id = ?"
@@ -0,0 +1,212 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/service/mentors/repository"
+	"github/yogabagas/join-app/shared/constant"
+	"strings"
+)
+
+const (
+	// fromMentors reaches the users of the organization holding the role
+	// named by its first argument, with their rating and years of experience.
+	fromMentors = ` FROM users u
+	LEFT JOIN (SELECT f.mentor_uid, AVG(f.rating) AS rating, COUNT(*) AS rating_count FROM user_feedbacks f
+	GROUP BY f.mentor_uid) fb ON fb.mentor_uid = u.uid
+	LEFT JOIN (SELECT w.user_uid, TIMESTAMPDIFF(YEAR, MIN(w.start_date), CURDATE()) AS years FROM user_work_experiences w
+	WHERE w.is_deleted = false GROUP BY w.user_uid) we ON we.user_uid = u.uid
+	WHERE EXISTS (SELECT 1 FROM authz a JOIN roles r ON r.uid = a.role_uid WHERE a.user_uid = u.uid AND r.name = ?
+	AND a.is_deleted = false AND r.is_deleted = false AND ` + authzInWindow + `) AND u.organization_uid = ? AND u.is_deleted = false`
+	selectMentors = `SELECT u.uid, u.first_name, u.last_name, u.description, u.gender, u.country, IFNULL(u.photo, ''),
+	IFNULL((SELECT GROUP_CONCAT(l.language ORDER BY l.position) FROM user_languages l WHERE l.user_uid = u.uid AND l.is_deleted = false), ''),
+	IFNULL(we.years, 0) AS years, IFNULL(fb.rating, 0) AS rating, IFNULL(fb.rating_count, 0) AS rating_count, %s AS relevance,
+	u.created_at` + fromMentors + `%s ORDER BY %s LIMIT ? OFFSET ?`
+	selectCountMentors = `SELECT COUNT(*)` + fromMentors + `%s`
+
+	mentorRelevance = `MATCH (u.first_name, u.last_name) AGAINST (? IN BOOLEAN MODE)`
+)
+
+// mentorFacets count the mentors matched by the subquery in place of %s.
+var mentorFacets = []struct {
+	field constant.MentorFacet
+	query string
+}{
+	{constant.FacetExpertise, `SELECT e.uid, e.name, COUNT(*) FROM user_expertises ue
+	JOIN expertises e ON e.uid = ue.expertise_uid AND e.is_deleted = false JOIN (%s) m ON m.uid = ue.user_uid
+	GROUP BY e.uid, e.name ORDER BY COUNT(*) DESC, e.name ASC`},
+	{constant.FacetCountry, `SELECT m.country, m.country, COUNT(*) FROM (%s) m GROUP BY m.country
+	ORDER BY COUNT(*) DESC, m.country ASC`},
+	{constant.FacetLanguage, `SELECT l.language, l.language, COUNT(*) FROM user_languages l JOIN (%s) m ON m.uid = l.user_uid
+	WHERE l.is_deleted = false GROUP BY l.language ORDER BY COUNT(*) DESC, l.language ASC`},
+	{constant.FacetGender, `SELECT m.gender, m.gender, COUNT(*) FROM (%s) m GROUP BY m.gender ORDER BY m.gender ASC`},
+}
+
+var mentorOrders = map[string]string{
+	constant.SortRelevance.String(): "relevance DESC, rating DESC, u.id DESC",
+	constant.SortRating.String():    "rating DESC, rating_count DESC, u.id DESC",
+	constant.SortNewest.String():    "u.created_at DESC, u.id DESC",
+}
+
+type MentorsRepositoryImpl struct {
+	db DBExecutor
+}
+
+func NewMentorsRepository(db DBExecutor) repository.MentorsRepository {
+	return &MentorsRepositoryImpl{db: db}
+}
+
+func (mr *MentorsRepositoryImpl) SearchMentors(ctx context.Context, req *model.SearchMentorsReq) (*model.SearchMentorsResp, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, ok := mentorOrders[req.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort %q", req.Sort)
+	}
+
+	cond, condArgs := mentorConditions(req, "")
+	resp := &model.SearchMentorsResp{}
+
+	args := append([]interface{}{req.RoleName, orgUID}, condArgs...)
+
+	err = mr.db.QueryRowContext(ctx, fmt.Sprintf(selectCountMentors, cond), args...).Scan(&resp.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	relevance := "0"
+	if req.Query != "" {
+		relevance = mentorRelevance
+		args = append([]interface{}{req.Query}, args...)
+	}
+	args = append(args, req.Limit, req.Offset)
+
+	rows, err := mr.db.QueryContext(ctx, fmt.Sprintf(selectMentors, relevance, cond, order), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.Mentor{}
+		var languages string
+
+		err = rows.Scan(&res.UID, &res.FirstName, &res.LastName, &res.Description, &res.Gender, &res.Country, &res.Photo,
+			&languages, &res.YearsOfExperience, &res.Rating, &res.RatingCount, &res.Relevance, &res.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if languages != "" {
+			res.Languages = strings.Split(languages, ",")
+		}
+
+		resp.Mentors = append(resp.Mentors, res)
+	}
+
+	return resp, rows.Err()
+}
+
+// ReadMentorFacets counts the mentors per expertise, country, language and
+// gender, each facet ignoring its own filter so the other values stay
+// selectable.
+func (mr *MentorsRepositoryImpl) ReadMentorFacets(ctx context.Context, req *model.SearchMentorsReq) (resp []*model.MentorFacet, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, facet := range mentorFacets {
+		cond, condArgs := mentorConditions(req, facet.field)
+
+		matched := "SELECT u.uid, u.country, u.gender" + fromMentors + cond
+		args := append([]interface{}{req.RoleName, orgUID}, condArgs...)
+
+		rows, err := mr.db.QueryContext(ctx, fmt.Sprintf(facet.query, matched), args...)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			res := &model.MentorFacet{Field: facet.field.String()}
+
+			if err = rows.Scan(&res.Value, &res.Label, &res.Count); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			resp = append(resp, res)
+		}
+
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// mentorConditions turns the filters of req, but the one on the facet skip,
+// into AND clauses and their arguments.
+func mentorConditions(req *model.SearchMentorsReq, skip constant.MentorFacet) (string, []interface{}) {
+
+	var cond strings.Builder
+	args := []interface{}{}
+
+	in := func(column string, values []string) {
+		cond.WriteString(fmt.Sprintf(" AND %s IN (%s)", column, placeholders(len(values))))
+		for _, v := range values {
+			args = append(args, v)
+		}
+	}
+
+	if req.Query != "" {
+		cond.WriteString(" AND " + mentorRelevance)
+		args = append(args, req.Query)
+	}
+
+	if len(req.ExpertiseUIDs) > 0 && skip != constant.FacetExpertise {
+		cond.WriteString(" AND EXISTS (SELECT 1 FROM user_expertises ue WHERE ue.user_uid = u.uid")
+		in("ue.expertise_uid", req.ExpertiseUIDs)
+		cond.WriteString(")")
+	}
+
+	if len(req.Countries) > 0 && skip != constant.FacetCountry {
+		in("u.country", req.Countries)
+	}
+
+	if len(req.Languages) > 0 && skip != constant.FacetLanguage {
+		cond.WriteString(" AND EXISTS (SELECT 1 FROM user_languages l WHERE l.user_uid = u.uid AND l.is_deleted = false")
+		in("l.language", req.Languages)
+		cond.WriteString(")")
+	}
+
+	if req.Gender != nil && skip != constant.FacetGender {
+		cond.WriteString(" AND u.gender = ?")
+		args = append(args, *req.Gender)
+	}
+
+	if req.MinYears != nil {
+		cond.WriteString(" AND IFNULL(we.years, 0) >= ?")
+		args = append(args, *req.MinYears)
+	}
+
+	if req.MaxYears != nil {
+		cond.WriteString(" AND IFNULL(we.years, 0) <= ?")
+		args = append(args, *req.MaxYears)
+	}
+
+	if req.MinRating != nil {
+		cond.WriteString(" AND IFNULL(fb.rating, 0) >= ?")
+		args = append(args, *req.MinRating)
+	}
+
+	return cond.String(), args
+}
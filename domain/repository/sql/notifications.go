@@ -0,0 +1,85 @@
+package sql
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/service/notifications/repository"
+)
+
+const (
+	insertNotification = `INSERT INTO notifications (uid, organization_uid, user_uid, kind, subject_uid, payload, created_by)
+	VALUES (?,?,?,?,?,?,?)`
+	selectNotifications = `SELECT id, uid, user_uid, kind, subject_uid, payload, read_at, created_by, created_at FROM notifications
+	WHERE organization_uid = ? AND user_uid = ? AND (? = false OR read_at IS NULL) ORDER BY id DESC LIMIT ?`
+	updateNotificationRead = `UPDATE notifications SET read_at = IFNULL(read_at, now())
+	WHERE organization_uid = ? AND uid = ? AND user_uid = ?`
+)
+
+type NotificationsRepositoryImpl struct {
+	db DBExecutor
+}
+
+func NewNotificationsRepository(db DBExecutor) repository.NotificationsRepository {
+	return &NotificationsRepositoryImpl{db: db}
+}
+
+func (nr *NotificationsRepositoryImpl) CreateNotification(ctx context.Context, req *model.Notification) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = nr.db.ExecContext(ctx, insertNotification, req.UID, orgUID, req.UserUID, req.Kind, req.SubjectUID, req.Payload,
+		req.CreatedBy)
+	return err
+}
+
+func (nr *NotificationsRepositoryImpl) ReadNotifications(ctx context.Context, req *model.ReadNotificationsReq) (resp []*model.Notification, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := nr.db.QueryContext(ctx, selectNotifications, orgUID, req.UserUID, req.Unread, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.Notification{}
+
+		err = rows.Scan(&res.ID, &res.UID, &res.UserUID, &res.Kind, &res.SubjectUID, &res.Payload, &res.ReadAt,
+			&res.CreatedBy, &res.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+// MarkNotificationRead marks the notification of the user read, answering
+// false when the user has no such notification.
+func (nr *NotificationsRepositoryImpl) MarkNotificationRead(ctx context.Context, req *model.MarkNotificationReadReq) (bool, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := nr.db.ExecContext(ctx, updateNotificationRead, orgUID, req.UID, req.UserUID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
@@ -0,0 +1,75 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/service/organizations/repository"
+)
+
+// Organizations are the tenants themselves, so unlike the other repositories
+// this one is not scoped by the organization in ctx.
+const (
+	insertOrganization  = `INSERT INTO organizations (uid, name, slug, created_by, updated_by) VALUES (?,?,?,?,?)`
+	selectOrganizations = `SELECT id, uid, name, slug, is_deleted, created_by, created_at, updated_by, updated_at
+	FROM organizations WHERE is_deleted = false ORDER BY id ASC`
+	selectOrganizationByKey = `SELECT id, uid, name, slug, is_deleted, created_by, created_at, updated_by, updated_at
+	FROM organizations WHERE (uid = ? OR slug = ?) AND is_deleted = false LIMIT 1`
+)
+
+type OrganizationsRepositoryImpl struct {
+	db DBExecutor
+}
+
+func NewOrganizationsRepository(db DBExecutor) repository.OrganizationsRepository {
+	return &OrganizationsRepositoryImpl{db: db}
+}
+
+func (or *OrganizationsRepositoryImpl) CreateOrganization(ctx context.Context, req *model.Organization) error {
+
+	_, err := or.db.ExecContext(ctx, insertOrganization, req.UID, req.Name, req.Slug, req.CreatedBy, req.UpdatedBy)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (or *OrganizationsRepositoryImpl) ReadOrganizations(ctx context.Context) (resp []*model.Organization, err error) {
+
+	rows, err := or.db.QueryContext(ctx, selectOrganizations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.Organization{}
+
+		err = rows.Scan(&res.ID, &res.UID, &res.Name, &res.Slug, &res.IsDeleted, &res.CreatedBy, &res.CreatedAt,
+			&res.UpdatedBy, &res.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (or *OrganizationsRepositoryImpl) ReadOrganizationByKey(ctx context.Context, req *model.ReadOrganizationByKeyReq) (*model.Organization, error) {
+
+	res := &model.Organization{}
+
+	err := or.db.QueryRowContext(ctx, selectOrganizationByKey, req.Key, req.Key).
+		Scan(&res.ID, &res.UID, &res.Name, &res.Slug, &res.IsDeleted, &res.CreatedBy, &res.CreatedAt,
+			&res.UpdatedBy, &res.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,80 @@
+package sql
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/service/userCredentials/repository"
+)
+
+const (
+	insertPasswordHistory = `INSERT INTO password_histories (user_uid, password) 
+	SELECT uid, ? FROM users WHERE organization_uid = ? AND uid = ?`
+	selectPasswordHistories = `SELECT ph.id, ph.user_uid, ph.password, ph.created_at 
+	FROM password_histories ph JOIN users u ON ph.user_uid = u.uid 
+	WHERE u.organization_uid = ? AND ph.user_uid = ? ORDER BY ph.id DESC LIMIT ?`
+	// deletePasswordHistories keeps the latest histories through a derived
+	// table, MySQL refusing a LIMIT in the subquery of an IN.
+	deletePasswordHistories = `DELETE ph FROM password_histories ph JOIN users u ON ph.user_uid = u.uid 
+	LEFT JOIN (SELECT id FROM password_histories WHERE user_uid = ? ORDER BY id DESC LIMIT ?) kept ON ph.id = kept.id 
+	WHERE u.organization_uid = ? AND ph.user_uid = ? AND kept.id IS NULL`
+)
+
+type PasswordHistoriesRepositoryImpl struct {
+	db DBExecutor
+}
+
+func NewPasswordHistoriesRepository(db DBExecutor) repository.PasswordHistoriesRepository {
+	return &PasswordHistoriesRepositoryImpl{db: db}
+}
+
+func (ph *PasswordHistoriesRepositoryImpl) InsertPasswordHistory(ctx context.Context, req *model.PasswordHistory) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = ph.db.ExecContext(ctx, insertPasswordHistory, req.Password, orgUID, req.UserUID)
+	return err
+}
+
+// ReadPasswordHistories returns the Limit latest histories of the user, the
+// latest first.
+func (ph *PasswordHistoriesRepositoryImpl) ReadPasswordHistories(ctx context.Context, req *model.ReadPasswordHistoriesReq) (resp []*model.PasswordHistory, err error) {
+
+	if req.Limit <= 0 {
+		return nil, nil
+	}
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ph.db.QueryContext(ctx, selectPasswordHistories, orgUID, req.UserUID, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h model.PasswordHistory
+		if err = rows.Scan(&h.ID, &h.UserUID, &h.Password, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		resp = append(resp, &h)
+	}
+
+	return resp, rows.Err()
+}
+
+func (ph *PasswordHistoriesRepositoryImpl) DeletePasswordHistories(ctx context.Context, req *model.DeletePasswordHistoriesReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = ph.db.ExecContext(ctx, deletePasswordHistories, req.UserUID, req.Keep, orgUID, req.UserUID)
+	return err
+}
@@ -0,0 +1,161 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/service/users/repository"
+)
+
+const (
+	// selectFeedbacks returns the feedbacks the user gave or received, the
+	// mentor scoping them to the organization.
+	selectFeedbacks = `SELECT f.uid, f.mentor_uid, f.rating, f.comment, f.created_by, f.created_at FROM user_feedbacks f
+	JOIN users u ON f.mentor_uid = u.uid WHERE u.organization_uid = ? AND (f.created_by = ? OR f.mentor_uid = ?) ORDER BY f.id`
+	selectAuditEntries = `SELECT 'role_grant', a.uid, a.role_uid, IF(a.is_deleted, 'revoked', 'granted'), a.created_by, '', a.created_at
+	FROM authz a WHERE a.organization_uid = ? AND a.user_uid = ?
+	UNION ALL SELECT 'elevation_request', e.uid, e.role_uid, e.status, e.decided_by, e.justification, e.created_at
+	FROM elevation_requests e WHERE e.organization_uid = ? AND e.user_uid = ?
+	UNION ALL SELECT 'access_review_item', i.uid, i.role_uid, i.decision, i.decided_by, i.note, IFNULL(i.decided_at, r.created_at)
+	FROM access_review_items i JOIN access_reviews r ON r.uid = i.review_uid WHERE i.organization_uid = ? AND i.user_uid = ?
+	UNION ALL SELECT 'access_change', h.uid, h.role_uid, CONCAT('version ', h.version), h.created_by, h.note, h.created_at
+	FROM access_histories h WHERE h.organization_uid = ? AND h.created_by = ?
+	UNION ALL SELECT 'status_change', s.uid, '', IF(s.is_active, 'active', 'suspended'), s.created_by, s.reason, s.created_at
+	FROM user_status_histories s WHERE s.organization_uid = ? AND s.user_uid = ?
+	ORDER BY 7, 2`
+
+	selectUserPhotoForUpdate = `SELECT IFNULL(photo, '') FROM users WHERE organization_uid = ? AND uid = ? FOR UPDATE`
+	anonymizeUser            = `UPDATE users SET first_name = ?, last_name = ?, email = ?, birthdate = '1900-01-01', description = '',
+	gender = 0, country = '', photo = NULL, is_deleted = true, updated_by = ?, updated_at = now()
+	WHERE organization_uid = ? AND uid = ?`
+	anonymizeUserCredentials = `UPDATE user_credentials c JOIN users u ON u.uid = c.user_uid SET c.username = ?, c.password = ?,
+	c.is_active = false, c.status_reason = ?, c.status_updated_by = ?, c.status_updated_at = now()
+	WHERE u.organization_uid = ? AND c.user_uid = ?`
+	anonymizeUserFeedbacks = `UPDATE user_feedbacks f JOIN users u ON u.uid = f.mentor_uid SET f.comment = ''
+	WHERE u.organization_uid = ? AND (f.created_by = ? OR f.mentor_uid = ?)`
+	// deleteUserOwned drops the rows of the user from the table %s, the
+	// tables having no organization_uid of their own.
+	deleteUserOwned         = `DELETE t FROM %s t JOIN users u ON u.uid = t.user_uid WHERE u.organization_uid = ? AND t.user_uid = ?`
+	anonymizeUserElevations = `UPDATE elevation_requests SET justification = '' WHERE organization_uid = ? AND user_uid = ?`
+	anonymizeUserStatuses   = `UPDATE user_status_histories SET reason = '' WHERE organization_uid = ? AND user_uid = ?`
+	revokeUserAuthz         = `UPDATE authz SET is_deleted = true, updated_by = ?, updated_at = now()
+	WHERE organization_uid = ? AND user_uid = ? AND is_deleted = false`
+)
+
+// userOwnedTables hold rows of the user only, dropped on erasure.
+var userOwnedTables = []string{"password_histories", "user_educations", "user_work_experiences", "user_languages",
+	"user_socials", "user_expertises"}
+
+type PersonalDataRepositoryImpl struct {
+	db DBExecutor
+}
+
+func NewPersonalDataRepository(db DBExecutor) repository.PersonalDataRepository {
+	return &PersonalDataRepositoryImpl{db: db}
+}
+
+func (pd *PersonalDataRepositoryImpl) ReadFeedbacks(ctx context.Context, req *model.ReadPersonalDataReq) (resp []*model.Feedback, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pd.db.QueryContext(ctx, selectFeedbacks, orgUID, req.UserUID, req.UserUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.Feedback{}
+		if err = rows.Scan(&res.UID, &res.MentorUID, &res.Rating, &res.Comment, &res.CreatedBy, &res.CreatedAt); err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+// ReadAuditEntries returns the role grants, elevation requests, access review
+// items and status changes about the user, and the access changes they made,
+// the oldest first.
+func (pd *PersonalDataRepositoryImpl) ReadAuditEntries(ctx context.Context, req *model.ReadPersonalDataReq) (resp []*model.AuditEntry, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []interface{}{}
+	for i := 0; i < 5; i++ {
+		args = append(args, orgUID, req.UserUID)
+	}
+
+	rows, err := pd.db.QueryContext(ctx, selectAuditEntries, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.AuditEntry{}
+		if err = rows.Scan(&res.Kind, &res.UID, &res.RoleUID, &res.Status, &res.ActorUID, &res.Note, &res.At); err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+// AnonymizeUser overwrites the personal columns of the user and of the rows
+// about them, drops the rows only they own and revokes their roles. The user
+// keeps their uid, so the created_by and updated_by referring to them stay
+// valid. It returns nil when the user isn't in the organization, and is meant
+// to run in a transaction.
+func (pd *PersonalDataRepositoryImpl) AnonymizeUser(ctx context.Context, req *model.AnonymizeUserReq) (*model.AnonymizeUserResp, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &model.AnonymizeUserResp{}
+
+	err = pd.db.QueryRowContext(ctx, selectUserPhotoForUpdate, orgUID, req.UserUID).Scan(&resp.Photo)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	type statement struct {
+		query string
+		args  []interface{}
+	}
+
+	statements := []statement{
+		{anonymizeUser, []interface{}{req.FirstName, req.LastName, req.Email, req.UpdatedBy, orgUID, req.UserUID}},
+		{anonymizeUserCredentials, []interface{}{req.Username, req.Password, req.Reason, req.UpdatedBy, orgUID, req.UserUID}},
+		{anonymizeUserFeedbacks, []interface{}{orgUID, req.UserUID, req.UserUID}},
+		{anonymizeUserElevations, []interface{}{orgUID, req.UserUID}},
+		{anonymizeUserStatuses, []interface{}{orgUID, req.UserUID}},
+		{revokeUserAuthz, []interface{}{req.UpdatedBy, orgUID, req.UserUID}},
+	}
+
+	for _, table := range userOwnedTables {
+		statements = append(statements, statement{fmt.Sprintf(deleteUserOwned, table), []interface{}{orgUID, req.UserUID}})
+	}
+
+	for _, v := range statements {
+		if _, err = pd.db.ExecContext(ctx, v.query, v.args...); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
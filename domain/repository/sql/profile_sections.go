@@ -0,0 +1,228 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/service/users/repository"
+	"strings"
+)
+
+// The profile sections belong to a user, so they are scoped to the
+// organization through users rather than a column of their own. Every section
+// table has uid, user_uid, position and is_deleted, the 1.1.0 profile
+// sections migration adding those user_educations and user_work_experiences
+// lacked. %[1]s is the table, %[2]s its own columns and %[3]s their values.
+const (
+	insertProfileSection = `INSERT INTO %[1]s (uid, user_uid, %[2]s, position)
+	SELECT ?, u.uid, %[3]s, (SELECT IFNULL(MAX(s.position), 0) + 1 FROM %[1]s s WHERE s.user_uid = u.uid)
+	FROM users u WHERE u.organization_uid = ? AND u.uid = ? AND u.is_deleted = false`
+	selectProfileSection = `SELECT s.uid, s.user_uid, %[2]s, s.position, s.is_deleted, s.created_at, s.updated_at
+	FROM %[1]s s JOIN users u ON u.uid = s.user_uid
+	WHERE u.organization_uid = ? AND s.user_uid = ? AND s.is_deleted = false ORDER BY s.position, s.created_at ASC`
+	updateProfileSection = `UPDATE %[1]s s JOIN users u ON u.uid = s.user_uid SET %[2]s, s.updated_at = now()
+	WHERE u.organization_uid = ? AND s.user_uid = ? AND s.uid = ? AND s.is_deleted = false`
+	updateProfileSectionPosition = `UPDATE %s s JOIN users u ON u.uid = s.user_uid SET s.position = ?, s.updated_at = now()
+	WHERE u.organization_uid = ? AND s.user_uid = ? AND s.uid = ?`
+	deleteProfileSection = `UPDATE %s s JOIN users u ON u.uid = s.user_uid SET s.is_deleted = true, s.updated_at = now()
+	WHERE u.organization_uid = ? AND s.user_uid = ? AND s.uid = ? AND s.is_deleted = false`
+)
+
+// profileSectionTable describes the table of the section of entries E.
+// fields points at the fields of an entry holding columns, in their order; the
+// driver dereferences them when they are written.
+type profileSectionTable[E any] struct {
+	name    string
+	columns []string
+	fields  func(e *E) []interface{}
+	// onDuplicate ends the insert into the tables keyed by their content, to
+	// bring back an entry deleted earlier.
+	onDuplicate string
+}
+
+var (
+	userEducationsTable = profileSectionTable[model.UserEducation]{
+		name:    "user_educations",
+		columns: []string{"college", "degree", "major", "start_year", "end_year"},
+		fields: func(e *model.UserEducation) []interface{} {
+			return []interface{}{&e.College, &e.Degree, &e.Major, &e.StartYear, &e.EndYear}
+		},
+	}
+	userWorkExperiencesTable = profileSectionTable[model.UserWorkExperience]{
+		name:    "user_work_experiences",
+		columns: []string{"role", "company", "industry", "start_date", "end_date", "description"},
+		fields: func(e *model.UserWorkExperience) []interface{} {
+			return []interface{}{&e.Role, &e.Company, &e.Industry, &e.StartDate, &e.EndDate, &e.Description}
+		},
+	}
+	userLanguagesTable = profileSectionTable[model.UserLanguage]{
+		name:    "user_languages",
+		columns: []string{"language"},
+		fields: func(e *model.UserLanguage) []interface{} {
+			return []interface{}{&e.Language}
+		},
+		onDuplicate: ` ON DUPLICATE KEY UPDATE is_deleted = false, position = VALUES(position), updated_at = now()`,
+	}
+	userSocialsTable = profileSectionTable[model.UserSocial]{
+		name:    "user_socials",
+		columns: []string{"title", "link"},
+		fields: func(e *model.UserSocial) []interface{} {
+			return []interface{}{&e.Title, &e.Link}
+		},
+	}
+)
+
+// ProfileSectionsRepositoryImpl stores a section of entries E in table.
+type ProfileSectionsRepositoryImpl[E any, PE interface {
+	*E
+	Entry() *model.ProfileSection
+}] struct {
+	db    DBExecutor
+	table profileSectionTable[E]
+}
+
+func NewUserEducationsRepository(db DBExecutor) repository.ProfileSectionsRepository[model.UserEducation] {
+	return &ProfileSectionsRepositoryImpl[model.UserEducation, *model.UserEducation]{db: db, table: userEducationsTable}
+}
+
+func NewUserWorkExperiencesRepository(db DBExecutor) repository.ProfileSectionsRepository[model.UserWorkExperience] {
+	return &ProfileSectionsRepositoryImpl[model.UserWorkExperience, *model.UserWorkExperience]{db: db, table: userWorkExperiencesTable}
+}
+
+func NewUserLanguagesRepository(db DBExecutor) repository.ProfileSectionsRepository[model.UserLanguage] {
+	return &ProfileSectionsRepositoryImpl[model.UserLanguage, *model.UserLanguage]{db: db, table: userLanguagesTable}
+}
+
+func NewUserSocialsRepository(db DBExecutor) repository.ProfileSectionsRepository[model.UserSocial] {
+	return &ProfileSectionsRepositoryImpl[model.UserSocial, *model.UserSocial]{db: db, table: userSocialsTable}
+}
+
+func (pr *ProfileSectionsRepositoryImpl[E, PE]) CreateSection(ctx context.Context, req *E) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	entry := PE(req).Entry()
+
+	q := fmt.Sprintf(insertProfileSection, pr.table.name, strings.Join(pr.table.columns, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(pr.table.columns)), ", ")) + pr.table.onDuplicate
+
+	args := append([]interface{}{entry.UID}, pr.table.fields(req)...)
+
+	res, err := pr.db.ExecContext(ctx, q, append(args, orgUID, entry.UserUID)...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.New("user is not found")
+	}
+
+	return nil
+}
+
+func (pr *ProfileSectionsRepositoryImpl[E, PE]) ReadSection(ctx context.Context, req *model.ReadProfileSectionReq) (resp []*E, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, 0, len(pr.table.columns))
+	for _, v := range pr.table.columns {
+		columns = append(columns, "s."+v)
+	}
+
+	rows, err := pr.db.QueryContext(ctx, fmt.Sprintf(selectProfileSection, pr.table.name, strings.Join(columns, ", ")),
+		orgUID, req.UserUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := new(E)
+		entry := PE(res).Entry()
+
+		dest := append([]interface{}{&entry.UID, &entry.UserUID}, pr.table.fields(res)...)
+		dest = append(dest, &entry.Position, &entry.IsDeleted, &entry.CreatedAt, &entry.UpdatedAt)
+
+		if err = rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (pr *ProfileSectionsRepositoryImpl[E, PE]) UpdateSection(ctx context.Context, req *E) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	entry := PE(req).Entry()
+
+	set := make([]string, 0, len(pr.table.columns))
+	for _, v := range pr.table.columns {
+		set = append(set, "s."+v+" = ?")
+	}
+
+	args := append(pr.table.fields(req), orgUID, entry.UserUID, entry.UID)
+
+	_, err = pr.db.ExecContext(ctx, fmt.Sprintf(updateProfileSection, pr.table.name, strings.Join(set, ", ")), args...)
+
+	return err
+}
+
+func (pr *ProfileSectionsRepositoryImpl[E, PE]) DeleteSection(ctx context.Context, req *model.DeleteProfileSectionReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := pr.db.ExecContext(ctx, fmt.Sprintf(deleteProfileSection, pr.table.name), orgUID, req.UserUID, req.UID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%w: %s entry %s", ErrNoRow, pr.table.name, req.UID)
+	}
+
+	return nil
+}
+
+func (pr *ProfileSectionsRepositoryImpl[E, PE]) ReorderSection(ctx context.Context, req *model.ReorderProfileSectionReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	q := fmt.Sprintf(updateProfileSectionPosition, pr.table.name)
+
+	for i, uid := range req.UIDs {
+		if _, err = pr.db.ExecContext(ctx, q, i+1, orgUID, req.UserUID, uid); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
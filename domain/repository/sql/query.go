@@ -0,0 +1,282 @@
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/shared/util"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ColumnKind int
+
+const (
+	KindString ColumnKind = iota
+	KindInt
+	KindBool
+	KindTime
+)
+
+// ListColumn is a field a listing can be filtered or sorted on. Only the
+// fields and operators declared this way ever reach the SQL, the values
+// always being bound.
+type ListColumn struct {
+	// Expr is the SQL expression of the field.
+	Expr string
+	Kind ColumnKind
+	Ops  []constant.FilterOp
+	// Cond, when set, is the condition filtering on the field, %s standing
+	// for the operator and its placeholders, e.g. "= ?" or "IN (?, ?)".
+	Cond string
+	// Fulltext lists the FULLTEXT indexed columns the match operator runs
+	// against.
+	Fulltext string
+	Sortable bool
+}
+
+// ListQuery builds the parameterized WHERE, ORDER BY and LIMIT clauses of a
+// listing from a validated model.ListReq. Its rows are ordered by key, the
+// ULID uid of the rows, after the sorts asked for, and the cursors seek on key
+// so a page doesn't shift when rows are added before it.
+type ListQuery struct {
+	columns map[string]ListColumn
+	key     string
+	where   []string
+	args    []interface{}
+	seek    string
+	seekArg interface{}
+	order   []string
+}
+
+func NewListQuery(columns map[string]ListColumn, key string) *ListQuery {
+	return &ListQuery{columns: columns, key: key}
+}
+
+// Where adds a condition the listing always applies.
+func (q *ListQuery) Where(cond string, args ...interface{}) *ListQuery {
+	q.where = append(q.where, cond)
+	q.args = append(q.args, args...)
+	return q
+}
+
+// Filter adds the conditions of the filters, failing on a field or an
+// operator the listing doesn't declare and on values of the wrong kind.
+func (q *ListQuery) Filter(filters []model.ListFilter) error {
+
+	for _, f := range filters {
+		column, ok := q.columns[f.Field]
+		if !ok {
+			return fmt.Errorf("can't filter on %q", f.Field)
+		}
+
+		op := constant.FilterOp(f.Op)
+		if f.Op == "" {
+			op = constant.FilterEq
+		}
+
+		if !column.allows(op) {
+			return fmt.Errorf("can't filter %q with %q", f.Field, op)
+		}
+
+		if len(f.Values) == 0 {
+			return fmt.Errorf("filter on %q needs a value", f.Field)
+		}
+
+		if op != constant.FilterIn && len(f.Values) > 1 {
+			return fmt.Errorf("filter on %q takes one value", f.Field)
+		}
+
+		if op == constant.FilterMatch {
+			query := util.FulltextQuery(f.Values[0])
+			if query == "" {
+				continue
+			}
+			q.Where(fmt.Sprintf("MATCH (%s) AGAINST (? IN BOOLEAN MODE)", column.Fulltext), query)
+			continue
+		}
+
+		args := make([]interface{}, 0, len(f.Values))
+		for _, v := range f.Values {
+			arg, err := column.value(op, v)
+			if err != nil {
+				return fmt.Errorf("filter on %q: %w", f.Field, err)
+			}
+			args = append(args, arg)
+		}
+
+		comparison := listOperators[op]
+		if op == constant.FilterIn {
+			comparison = fmt.Sprintf(comparison, placeholders(len(args)))
+		}
+
+		if column.Cond != "" {
+			q.Where(fmt.Sprintf(column.Cond, comparison), args...)
+		} else {
+			q.Where(column.Expr+" "+comparison, args...)
+		}
+	}
+
+	return nil
+}
+
+// Sort orders the listing by the sorts of req, then by key, which keeps the
+// pages stable and orders the listing when no sort is given. The cursors only
+// page the default order, seeking past the uid they hold.
+func (q *ListQuery) Sort(req *model.ListReq) error {
+
+	if len(req.Sorts) > 0 && (req.After != "" || req.Before != "") {
+		return errors.New("cursors only page the default order, use page with sort")
+	}
+
+	for _, s := range req.Sorts {
+		column, ok := q.columns[s.Field]
+		if !ok || !column.Sortable {
+			return fmt.Errorf("can't sort on %q", s.Field)
+		}
+
+		direction := "ASC"
+		if s.Desc {
+			direction = "DESC"
+		}
+
+		q.order = append(q.order, column.Expr+" "+direction)
+	}
+
+	switch {
+	case req.Before != "":
+		// The rows before the cursor are read backward, listPage restoring
+		// their order.
+		q.seek, q.seekArg = q.key+" < ?", req.Before
+		q.order = append(q.order, q.key+" DESC")
+	case req.After != "":
+		q.seek, q.seekArg = q.key+" > ?", req.After
+		q.order = append(q.order, q.key+" ASC")
+	default:
+		q.order = append(q.order, q.key+" ASC")
+	}
+
+	return nil
+}
+
+// Select appends the clauses to the SELECT ... FROM query for the page req
+// asks for, and returns the arguments to run it with. It reads a row past the
+// page, telling listPage whether more rows follow.
+func (q *ListQuery) Select(query string, req *model.ListReq) (string, []interface{}) {
+
+	where, args := q.where, append([]interface{}{}, q.args...)
+	if q.seek != "" {
+		where = append(append([]string{}, where...), q.seek)
+		args = append(args, q.seekArg)
+	}
+
+	query += whereClause(where)
+
+	if len(q.order) > 0 {
+		query += " ORDER BY " + strings.Join(q.order, ", ")
+	}
+
+	args = append(args, req.Limit+1, req.Offset)
+
+	return query + " LIMIT ? OFFSET ?", args
+}
+
+// Count appends the conditions to the SELECT COUNT(*) FROM query, the count
+// ignoring the cursor.
+func (q *ListQuery) Count(query string) (string, []interface{}) {
+	return query + whereClause(q.where), q.args
+}
+
+func whereClause(where []string) string {
+	if len(where) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(where, " AND ")
+}
+
+// listPage drops the extra row read by Select and restores the order of the
+// rows read backward. In the default order, it bounds the page of the total
+// rows by the uids of its first and last rows when rows precede or follow
+// them.
+func listPage[T any](req *model.ListReq, rows []T, total int, uid func(T) string) ([]T, model.ListPage) {
+
+	page := model.ListPage{Total: total}
+
+	more := len(rows) > req.Limit
+	if more {
+		rows = rows[:req.Limit]
+	}
+
+	if req.Before != "" {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	if len(req.Sorts) > 0 || len(rows) == 0 {
+		return rows, page
+	}
+
+	hasNext, hasPrev := more, req.After != "" || req.Offset > 0
+	if req.Before != "" {
+		hasNext, hasPrev = true, more
+	}
+
+	if hasNext {
+		page.NextUID = uid(rows[len(rows)-1])
+	}
+
+	if hasPrev {
+		page.PrevUID = uid(rows[0])
+	}
+
+	return rows, page
+}
+
+var listOperators = map[constant.FilterOp]string{
+	constant.FilterEq:     "= ?",
+	constant.FilterNe:     "<> ?",
+	constant.FilterGt:     "> ?",
+	constant.FilterGte:    ">= ?",
+	constant.FilterLt:     "< ?",
+	constant.FilterLte:    "<= ?",
+	constant.FilterIn:     "IN (%s)",
+	constant.FilterLike:   "LIKE ?",
+	constant.FilterPrefix: "LIKE ?",
+}
+
+func (c ListColumn) allows(op constant.FilterOp) bool {
+	for _, v := range c.Ops {
+		if v == op {
+			return true
+		}
+	}
+	return false
+}
+
+// value converts v to the kind of the column, wrapping the LIKE patterns.
+func (c ListColumn) value(op constant.FilterOp, v string) (interface{}, error) {
+
+	switch op {
+	case constant.FilterLike:
+		return "%" + likeEscape.Replace(v) + "%", nil
+	case constant.FilterPrefix:
+		return likeEscape.Replace(v) + "%", nil
+	}
+
+	switch c.Kind {
+	case KindInt:
+		return strconv.Atoi(v)
+	case KindBool:
+		return strconv.ParseBool(v)
+	case KindTime:
+		if t, err := time.Parse(time.DateOnly, v); err == nil {
+			return t, nil
+		}
+		return time.Parse(time.RFC3339, v)
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,227 @@
+package sql
+
+import (
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/shared/constant"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testListColumns = map[string]ListColumn{
+	"name": {
+		Expr: "u.name", Kind: KindString, Sortable: true,
+		Ops: []constant.FilterOp{constant.FilterEq, constant.FilterIn, constant.FilterLike, constant.FilterPrefix},
+	},
+	"age":    {Expr: "u.age", Kind: KindInt, Ops: []constant.FilterOp{constant.FilterGte, constant.FilterLt}},
+	"active": {Expr: "c.is_active", Kind: KindBool, Ops: []constant.FilterOp{constant.FilterEq}},
+	"role": {
+		Expr: "a.role_uid", Kind: KindString, Ops: []constant.FilterOp{constant.FilterEq},
+		Cond: "EXISTS (SELECT 1 FROM authz a WHERE a.user_uid = u.uid AND a.role_uid %s)",
+	},
+	"q": {Kind: KindString, Ops: []constant.FilterOp{constant.FilterMatch}, Fulltext: "u.name, u.email"},
+}
+
+func TestListQueryFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		filters   []model.ListFilter
+		wantWhere string
+		wantArgs  []interface{}
+		wantErr   string
+	}{
+		{
+			name:      "default operator",
+			filters:   []model.ListFilter{{Field: "name", Values: []string{"ann"}}},
+			wantWhere: " WHERE u.name = ?",
+			wantArgs:  []interface{}{"ann"},
+		},
+		{
+			name:      "typed values",
+			filters:   []model.ListFilter{{Field: "age", Op: "gte", Values: []string{"18"}}, {Field: "active", Values: []string{"true"}}},
+			wantWhere: " WHERE u.age >= ? AND c.is_active = ?",
+			wantArgs:  []interface{}{18, true},
+		},
+		{
+			name:      "in list",
+			filters:   []model.ListFilter{{Field: "name", Op: "in", Values: []string{"ann", "bob"}}},
+			wantWhere: " WHERE u.name IN (?, ?)",
+			wantArgs:  []interface{}{"ann", "bob"},
+		},
+		{
+			name:      "condition",
+			filters:   []model.ListFilter{{Field: "role", Values: []string{"admin"}}},
+			wantWhere: " WHERE EXISTS (SELECT 1 FROM authz a WHERE a.user_uid = u.uid AND a.role_uid = ?)",
+			wantArgs:  []interface{}{"admin"},
+		},
+		{
+			name:      "quote in a value",
+			filters:   []model.ListFilter{{Field: "name", Values: []string{"' OR 1=1 --"}}},
+			wantWhere: " WHERE u.name = ?",
+			wantArgs:  []interface{}{"' OR 1=1 --"},
+		},
+		{
+			name:      "statement in an in list",
+			filters:   []model.ListFilter{{Field: "name", Op: "in", Values: []string{"a'); DROP TABLE users; --"}}},
+			wantWhere: " WHERE u.name IN (?)",
+			wantArgs:  []interface{}{"a'); DROP TABLE users; --"},
+		},
+		{
+			name:      "wildcards in a like",
+			filters:   []model.ListFilter{{Field: "name", Op: "like", Values: []string{`50%_\`}}},
+			wantWhere: " WHERE u.name LIKE ?",
+			wantArgs:  []interface{}{`%50\%\_\\%`},
+		},
+		{
+			name:      "wildcards in a prefix",
+			filters:   []model.ListFilter{{Field: "name", Op: "prefix", Values: []string{"%"}}},
+			wantWhere: " WHERE u.name LIKE ?",
+			wantArgs:  []interface{}{`\%%`},
+		},
+		{
+			name:      "boolean operators in a match",
+			filters:   []model.ListFilter{{Field: "q", Op: "match", Values: []string{`ann -bob "x" @y`}}},
+			wantWhere: " WHERE MATCH (u.name, u.email) AGAINST (? IN BOOLEAN MODE)",
+			wantArgs:  []interface{}{"+ann* +bob* +x* +y*"},
+		},
+		{
+			name:    "match without words",
+			filters: []model.ListFilter{{Field: "q", Op: "match", Values: []string{"-*()"}}},
+		},
+		{
+			name:    "undeclared field",
+			filters: []model.ListFilter{{Field: "password", Values: []string{"x"}}},
+			wantErr: `can't filter on "password"`,
+		},
+		{
+			name:    "expression as a field",
+			filters: []model.ListFilter{{Field: "u.name = u.name OR 1", Values: []string{"x"}}},
+			wantErr: `can't filter on "u.name = u.name OR 1"`,
+		},
+		{
+			name:    "undeclared operator",
+			filters: []model.ListFilter{{Field: "age", Op: "eq", Values: []string{"18"}}},
+			wantErr: `can't filter "age" with "eq"`,
+		},
+		{
+			name:    "sql as an operator",
+			filters: []model.ListFilter{{Field: "name", Op: "= 1 OR 1 =", Values: []string{"x"}}},
+			wantErr: `can't filter "name" with "= 1 OR 1 ="`,
+		},
+		{
+			name:    "no value",
+			filters: []model.ListFilter{{Field: "name"}},
+			wantErr: `filter on "name" needs a value`,
+		},
+		{
+			name:    "values outside in",
+			filters: []model.ListFilter{{Field: "name", Values: []string{"a", "b"}}},
+			wantErr: `filter on "name" takes one value`,
+		},
+		{
+			name:    "value of the wrong kind",
+			filters: []model.ListFilter{{Field: "age", Op: "gte", Values: []string{"1 OR 1=1"}}},
+			wantErr: `filter on "age"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewListQuery(testListColumns, "u.uid")
+
+			err := q.Filter(tt.filters)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Filter() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Filter() error = %v", err)
+			}
+
+			query, args := q.Count("SELECT COUNT(*) FROM users u")
+			if where := strings.TrimPrefix(query, "SELECT COUNT(*) FROM users u"); where != tt.wantWhere {
+				t.Fatalf("Count() where = %q, want %q", where, tt.wantWhere)
+			}
+			if len(args) != len(tt.wantArgs) || (len(args) > 0 && !reflect.DeepEqual(args, tt.wantArgs)) {
+				t.Fatalf("Count() args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestListQuerySort(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       model.ListReq
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   string
+	}{
+		{
+			name:      "default order",
+			req:       model.ListReq{Limit: 10},
+			wantQuery: " ORDER BY u.uid ASC LIMIT ? OFFSET ?",
+			wantArgs:  []interface{}{11, 0},
+		},
+		{
+			name:      "sorted",
+			req:       model.ListReq{Sorts: []model.ListSort{{Field: "name", Desc: true}}, Limit: 10, Offset: 20},
+			wantQuery: " ORDER BY u.name DESC, u.uid ASC LIMIT ? OFFSET ?",
+			wantArgs:  []interface{}{11, 20},
+		},
+		{
+			name:      "after a cursor",
+			req:       model.ListReq{After: "01H", Limit: 5},
+			wantQuery: " WHERE u.uid > ? ORDER BY u.uid ASC LIMIT ? OFFSET ?",
+			wantArgs:  []interface{}{"01H", 6, 0},
+		},
+		{
+			name:      "before a cursor",
+			req:       model.ListReq{Before: "01H", Limit: 5},
+			wantQuery: " WHERE u.uid < ? ORDER BY u.uid DESC LIMIT ? OFFSET ?",
+			wantArgs:  []interface{}{"01H", 6, 0},
+		},
+		{
+			name:    "undeclared field",
+			req:     model.ListReq{Sorts: []model.ListSort{{Field: "u.password"}}},
+			wantErr: `can't sort on "u.password"`,
+		},
+		{
+			name:    "field not sortable",
+			req:     model.ListReq{Sorts: []model.ListSort{{Field: "age"}}},
+			wantErr: `can't sort on "age"`,
+		},
+		{
+			name:    "sort with a cursor",
+			req:     model.ListReq{Sorts: []model.ListSort{{Field: "name"}}, After: "01H"},
+			wantErr: "cursors only page the default order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewListQuery(testListColumns, "u.uid")
+
+			err := q.Sort(&tt.req)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Sort() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Sort() error = %v", err)
+			}
+
+			query, args := q.Select("SELECT u.uid FROM users u", &tt.req)
+			if got := strings.TrimPrefix(query, "SELECT u.uid FROM users u"); got != tt.wantQuery {
+				t.Fatalf("Select() query = %q, want %q", got, tt.wantQuery)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Fatalf("Select() args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -3,10 +3,18 @@ package sql
 import (
 	"context"
 	"database/sql"
+	"github/yogabagas/join-app/domain/model"
 	accessRepo "github/yogabagas/join-app/service/access/repository"
+	accessReviewsRepo "github/yogabagas/join-app/service/accessReviews/repository"
 	authzRepo "github/yogabagas/join-app/service/authz/repository"
+	elevationsRepo "github/yogabagas/join-app/service/elevations/repository"
+	expertisesRepo "github/yogabagas/join-app/service/expertises/repository"
 	jwkRepo "github/yogabagas/join-app/service/jwk/repository"
+	mentorsRepo "github/yogabagas/join-app/service/mentors/repository"
+	notificationsRepo "github/yogabagas/join-app/service/notifications/repository"
+	organizationsRepo "github/yogabagas/join-app/service/organizations/repository"
 	resourcesRepo "github/yogabagas/join-app/service/resources/repository"
+	roleConstraintsRepo "github/yogabagas/join-app/service/roleConstraints/repository"
 	rolesRepo "github/yogabagas/join-app/service/roles/repository"
 	userCredentialsRepo "github/yogabagas/join-app/service/userCredentials/repository"
 	usersRepo "github/yogabagas/join-app/service/users/repository"
@@ -22,11 +30,26 @@ type RepositoryRegistryImpl struct {
 
 type RepositoryRegistry interface {
 	AccessRepository() accessRepo.AccessRepository
+	AccessHistoriesRepository() accessRepo.AccessHistoriesRepository
+	AccessReviewsRepository() accessReviewsRepo.AccessReviewsRepository
 	AuthzRepository() authzRepo.AuthzRepository
+	DataRequestsRepository() usersRepo.DataRequestsRepository
+	ElevationsRepository() elevationsRepo.ElevationsRepository
+	ExpertisesRepository() expertisesRepo.ExpertisesRepository
 	JWKRepository() jwkRepo.JWKRepository
+	MentorsRepository() mentorsRepo.MentorsRepository
+	NotificationsRepository() notificationsRepo.NotificationsRepository
+	OrganizationsRepository() organizationsRepo.OrganizationsRepository
+	PasswordHistoriesRepository() userCredentialsRepo.PasswordHistoriesRepository
+	PersonalDataRepository() usersRepo.PersonalDataRepository
+	RoleConstraintsRepository() roleConstraintsRepo.RoleConstraintsRepository
 	RolesRepository() rolesRepo.RolesRepository
 	ResourcesRepository() resourcesRepo.ResourcesRepository
 	UserCredentialsRepository() userCredentialsRepo.UserCredentialsRepository
+	UserEducationsRepository() usersRepo.ProfileSectionsRepository[model.UserEducation]
+	UserLanguagesRepository() usersRepo.ProfileSectionsRepository[model.UserLanguage]
+	UserSocialsRepository() usersRepo.ProfileSectionsRepository[model.UserSocial]
+	UserWorkExperiencesRepository() usersRepo.ProfileSectionsRepository[model.UserWorkExperience]
 	UsersRepository() usersRepo.UsersRepository
 
 	DoInTransaction(ctx context.Context, txFunc InTransaction) (out interface{}, err error)
@@ -43,6 +66,20 @@ func (r RepositoryRegistryImpl) AccessRepository() accessRepo.AccessRepository {
 	return NewAccessRepository(r.db)
 }
 
+func (r RepositoryRegistryImpl) AccessHistoriesRepository() accessRepo.AccessHistoriesRepository {
+	if r.dbExecutor != nil {
+		return NewAccessHistoriesRepository(r.dbExecutor)
+	}
+	return NewAccessHistoriesRepository(r.db)
+}
+
+func (r RepositoryRegistryImpl) AccessReviewsRepository() accessReviewsRepo.AccessReviewsRepository {
+	if r.dbExecutor != nil {
+		return NewAccessReviewsRepository(r.dbExecutor)
+	}
+	return NewAccessReviewsRepository(r.db)
+}
+
 func (r RepositoryRegistryImpl) AuthzRepository() authzRepo.AuthzRepository {
 	if r.dbExecutor != nil {
 		return NewAuthzRepository(r.dbExecutor)
@@ -50,6 +87,27 @@ func (r RepositoryRegistryImpl) AuthzRepository() authzRepo.AuthzRepository {
 	return NewAuthzRepository(r.db)
 }
 
+func (r RepositoryRegistryImpl) DataRequestsRepository() usersRepo.DataRequestsRepository {
+	if r.dbExecutor != nil {
+		return NewDataRequestsRepository(r.dbExecutor)
+	}
+	return NewDataRequestsRepository(r.db)
+}
+
+func (r RepositoryRegistryImpl) ElevationsRepository() elevationsRepo.ElevationsRepository {
+	if r.dbExecutor != nil {
+		return NewElevationsRepository(r.dbExecutor)
+	}
+	return NewElevationsRepository(r.db)
+}
+
+func (r RepositoryRegistryImpl) ExpertisesRepository() expertisesRepo.ExpertisesRepository {
+	if r.dbExecutor != nil {
+		return NewExpertisesRepository(r.dbExecutor)
+	}
+	return NewExpertisesRepository(r.db)
+}
+
 func (r RepositoryRegistryImpl) JWKRepository() jwkRepo.JWKRepository {
 	if r.dbExecutor != nil {
 		return NewJWKRepository(r.dbExecutor)
@@ -57,6 +115,48 @@ func (r RepositoryRegistryImpl) JWKRepository() jwkRepo.JWKRepository {
 	return NewJWKRepository(r.db)
 }
 
+func (r RepositoryRegistryImpl) MentorsRepository() mentorsRepo.MentorsRepository {
+	if r.dbExecutor != nil {
+		return NewMentorsRepository(r.dbExecutor)
+	}
+	return NewMentorsRepository(r.db)
+}
+
+func (r RepositoryRegistryImpl) NotificationsRepository() notificationsRepo.NotificationsRepository {
+	if r.dbExecutor != nil {
+		return NewNotificationsRepository(r.dbExecutor)
+	}
+	return NewNotificationsRepository(r.db)
+}
+
+func (r RepositoryRegistryImpl) OrganizationsRepository() organizationsRepo.OrganizationsRepository {
+	if r.dbExecutor != nil {
+		return NewOrganizationsRepository(r.dbExecutor)
+	}
+	return NewOrganizationsRepository(r.db)
+}
+
+func (r RepositoryRegistryImpl) PasswordHistoriesRepository() userCredentialsRepo.PasswordHistoriesRepository {
+	if r.dbExecutor != nil {
+		return NewPasswordHistoriesRepository(r.dbExecutor)
+	}
+	return NewPasswordHistoriesRepository(r.db)
+}
+
+func (r RepositoryRegistryImpl) PersonalDataRepository() usersRepo.PersonalDataRepository {
+	if r.dbExecutor != nil {
+		return NewPersonalDataRepository(r.dbExecutor)
+	}
+	return NewPersonalDataRepository(r.db)
+}
+
+func (r RepositoryRegistryImpl) RoleConstraintsRepository() roleConstraintsRepo.RoleConstraintsRepository {
+	if r.dbExecutor != nil {
+		return NewRoleConstraintsRepository(r.dbExecutor)
+	}
+	return NewRoleConstraintsRepository(r.db)
+}
+
 func (r RepositoryRegistryImpl) RolesRepository() rolesRepo.RolesRepository {
 	if r.dbExecutor != nil {
 		return NewRolesRepository(r.dbExecutor)
@@ -78,6 +178,34 @@ func (r RepositoryRegistryImpl) UserCredentialsRepository() userCredentialsRepo.
 	return NewUserCredentialsRepository(r.db)
 }
 
+func (r RepositoryRegistryImpl) UserEducationsRepository() usersRepo.ProfileSectionsRepository[model.UserEducation] {
+	if r.dbExecutor != nil {
+		return NewUserEducationsRepository(r.dbExecutor)
+	}
+	return NewUserEducationsRepository(r.db)
+}
+
+func (r RepositoryRegistryImpl) UserLanguagesRepository() usersRepo.ProfileSectionsRepository[model.UserLanguage] {
+	if r.dbExecutor != nil {
+		return NewUserLanguagesRepository(r.dbExecutor)
+	}
+	return NewUserLanguagesRepository(r.db)
+}
+
+func (r RepositoryRegistryImpl) UserSocialsRepository() usersRepo.ProfileSectionsRepository[model.UserSocial] {
+	if r.dbExecutor != nil {
+		return NewUserSocialsRepository(r.dbExecutor)
+	}
+	return NewUserSocialsRepository(r.db)
+}
+
+func (r RepositoryRegistryImpl) UserWorkExperiencesRepository() usersRepo.ProfileSectionsRepository[model.UserWorkExperience] {
+	if r.dbExecutor != nil {
+		return NewUserWorkExperiencesRepository(r.dbExecutor)
+	}
+	return NewUserWorkExperiencesRepository(r.db)
+}
+
 func (r RepositoryRegistryImpl) UsersRepository() usersRepo.UsersRepository {
 	if r.dbExecutor != nil {
 		return NewUsersRepository(r.dbExecutor)
@@ -5,18 +5,29 @@ import (
 	"database/sql"
 	"github/yogabagas/join-app/domain/model"
 	"github/yogabagas/join-app/service/resources/repository"
+	"github/yogabagas/join-app/shared/constant"
 	"strings"
 )
 
 const (
-	insertResources                = `INSERT INTO resources (uid, name, parent_uid, type, action, created_by, updated_by) VALUES (?,?,?,?,?,?,?)`
+	insertResources = `INSERT INTO resources (uid, organization_uid, name, parent_uid, type, action, created_by, updated_by) 
+	VALUES (?,?,?,?,?,?,?,?)`
 	selectResourcesHierarchyByType = `WITH RECURSIVE menu_hierarchy AS (
-		SELECT uid, name, action, type, parent_uid, 1 as level FROM resources WHERE parent_uid IS NULL
+		SELECT uid, name, action, type, parent_uid, 1 as level FROM resources WHERE organization_uid = ? AND parent_uid IS NULL
 		UNION ALL
 		SELECT m.uid, m.name, m.action, m.type, m.parent_uid, mh.level + 1 FROM resources m
-		JOIN menu_hierarchy mh ON m.parent_uid = mh.uid)
+		JOIN menu_hierarchy mh ON m.parent_uid = mh.uid WHERE m.organization_uid = ?)
 	  	SELECT uid, name, action, type, parent_uid, level
 	  	FROM menu_hierarchy WHERE type = ?`
+	selectResources = `SELECT uid, name, type, action, parent_uid FROM resources 
+	WHERE organization_uid = ? AND is_deleted = false ORDER BY id ASC`
+	selectResourcesFlatByType = `SELECT uid, name, type, action, parent_uid FROM resources 
+	WHERE organization_uid = ? AND type = ? AND is_deleted = false ORDER BY id ASC`
+	selectResourcesByKey = `SELECT uid, name, type, action FROM resources 
+	WHERE organization_uid = ? AND (uid = ? OR name = ?) AND action = ? AND is_deleted = false`
+	selectResourcesWithPagination = `SELECT rs.id, rs.uid, rs.name, rs.type, rs.action, rs.parent_uid, rs.created_by, rs.created_at,
+	rs.updated_by, rs.updated_at FROM resources rs`
+	selectCountResourcesWithPagination = `SELECT COUNT(*) FROM resources rs`
 )
 
 type ResourcesRepositoryImpl struct {
@@ -29,13 +40,18 @@ func NewResourcesRepository(db DBExecutor) repository.ResourcesRepository {
 
 func (rr *ResourcesRepositoryImpl) CreateResources(ctx context.Context, req *model.Resource) error {
 
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
 	parentUID := sql.NullString{}
 	if req.ParentUID != "" {
 		parentUID.String = req.ParentUID
 		parentUID.Valid = true
 	}
 
-	_, err := rr.db.ExecContext(ctx, insertResources, req.UID, req.Name, parentUID, req.Type, req.Action,
+	_, err = rr.db.ExecContext(ctx, insertResources, req.UID, orgUID, req.Name, parentUID, req.Type, req.Action,
 		req.CreatedBy, req.UpdatedBy)
 	if err != nil && !strings.Contains(err.Error(), "duplicate") {
 		return err
@@ -46,7 +62,12 @@ func (rr *ResourcesRepositoryImpl) CreateResources(ctx context.Context, req *mod
 
 func (rr *ResourcesRepositoryImpl) ReadResourcesByType(ctx context.Context, req *model.ReadResourcesByTypeReq) (resp []*model.ReadResourcesByTypeResp, err error) {
 
-	rows, err := rr.db.QueryContext(ctx, selectResourcesHierarchyByType, req.Type)
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.QueryContext(ctx, selectResourcesHierarchyByType, orgUID, orgUID, req.Type)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
 	}
@@ -63,3 +84,155 @@ func (rr *ResourcesRepositoryImpl) ReadResourcesByType(ctx context.Context, req
 
 	return
 }
+
+func (rr *ResourcesRepositoryImpl) ReadResourcesByKey(ctx context.Context, req *model.ReadResourcesByKeyReq) (resp []*model.Resource, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.QueryContext(ctx, selectResourcesByKey, orgUID, req.Key, req.Key, req.Action)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.Resource{}
+
+		err = rows.Scan(&res.UID, &res.Name, &res.Type, &res.Action)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (rr *ResourcesRepositoryImpl) ReadResourcesFlatByType(ctx context.Context, req *model.ReadResourcesByTypeReq) (resp []*model.Resource, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.QueryContext(ctx, selectResourcesFlatByType, orgUID, req.Type)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.Resource{}
+		parentUID := sql.NullString{}
+
+		err = rows.Scan(&res.UID, &res.Name, &res.Type, &res.Action, &parentUID)
+		if err != nil {
+			return nil, err
+		}
+		res.ParentUID = parentUID.String
+
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (rr *ResourcesRepositoryImpl) ReadResources(ctx context.Context) (resp []*model.Resource, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.QueryContext(ctx, selectResources, orgUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.Resource{}
+		parentUID := sql.NullString{}
+
+		err = rows.Scan(&res.UID, &res.Name, &res.Type, &res.Action, &parentUID)
+		if err != nil {
+			return nil, err
+		}
+		res.ParentUID = parentUID.String
+
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+// resourceColumns are the fields the resources listing filters and sorts on.
+var resourceColumns = map[string]ListColumn{
+	"name": {Expr: "rs.name", Ops: []constant.FilterOp{constant.FilterEq, constant.FilterIn, constant.FilterLike,
+		constant.FilterPrefix}, Sortable: true},
+	"type":       {Expr: "rs.type", Kind: KindInt, Ops: []constant.FilterOp{constant.FilterEq, constant.FilterIn}, Sortable: true},
+	"action":     {Expr: "rs.action", Ops: []constant.FilterOp{constant.FilterEq, constant.FilterIn}, Sortable: true},
+	"parent_uid": {Expr: "rs.parent_uid", Ops: []constant.FilterOp{constant.FilterEq, constant.FilterIn}},
+	"created_at": {Expr: "rs.created_at", Kind: KindTime, Ops: []constant.FilterOp{constant.FilterGt, constant.FilterGte,
+		constant.FilterLt, constant.FilterLte}, Sortable: true},
+}
+
+func (rr *ResourcesRepositoryImpl) ReadResourcesWithPagination(ctx context.Context, req *model.ListReq) (*model.ReadResourcesWithPaginationResp, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := NewListQuery(resourceColumns, "rs.uid").Where("rs.organization_uid = ?", orgUID).Where("rs.is_deleted = false")
+
+	if err = q.Filter(req.Filters); err != nil {
+		return nil, err
+	}
+
+	if err = q.Sort(req); err != nil {
+		return nil, err
+	}
+
+	var total int
+
+	query, args := q.Count(selectCountResourcesWithPagination)
+	if err = rr.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	query, args = q.Select(selectResourcesWithPagination, req)
+
+	rows, err := rr.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resources := []*model.Resource{}
+	for rows.Next() {
+		res := &model.Resource{}
+		parentUID := sql.NullString{}
+
+		err = rows.Scan(&res.ID, &res.UID, &res.Name, &res.Type, &res.Action, &parentUID, &res.CreatedBy, &res.CreatedAt,
+			&res.UpdatedBy, &res.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res.ParentUID = parentUID.String
+
+		resources = append(resources, res)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &model.ReadResourcesWithPaginationResp{}
+	resp.Resources, resp.Page = listPage(req, resources, total, func(v *model.Resource) string { return v.UID })
+
+	return resp, nil
+}
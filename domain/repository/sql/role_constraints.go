@@ -0,0 +1,114 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/service/roleConstraints/repository"
+	"strings"
+)
+
+const (
+	insertRoleConstraint      = `INSERT INTO role_constraints (uid, organization_uid, name, created_by, updated_by) VALUES (?,?,?,?,?)`
+	insertRoleConstraintRoles = `INSERT INTO role_constraint_roles (constraint_uid, role_uid) VALUES %s`
+	selectRoleConstraints     = `SELECT c.id, c.uid, c.name, c.is_deleted, c.created_by, c.created_at, c.updated_by, c.updated_at, cr.role_uid
+	FROM role_constraints c JOIN role_constraint_roles cr ON cr.constraint_uid = c.uid
+	WHERE c.organization_uid = ? AND c.is_deleted = false ORDER BY c.id, cr.role_uid ASC`
+	deleteRoleConstraint = `UPDATE role_constraints SET is_deleted = true, updated_by = ?, updated_at = now()
+	WHERE organization_uid = ? AND uid = ? AND is_deleted = false`
+)
+
+type RoleConstraintsRepositoryImpl struct {
+	db DBExecutor
+}
+
+func NewRoleConstraintsRepository(db DBExecutor) repository.RoleConstraintsRepository {
+	return &RoleConstraintsRepositoryImpl{db: db}
+}
+
+func (rr *RoleConstraintsRepositoryImpl) CreateRoleConstraint(ctx context.Context, req *model.RoleConstraint) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = rr.db.ExecContext(ctx, insertRoleConstraint, req.UID, orgUID, req.Name, req.CreatedBy, req.UpdatedBy)
+	if err != nil {
+		return err
+	}
+
+	values := []string{}
+	args := []interface{}{}
+
+	for _, v := range req.RoleUIDs {
+		values = append(values, "(?,?)")
+		args = append(args, req.UID, v)
+	}
+
+	_, err = rr.db.ExecContext(ctx, fmt.Sprintf(insertRoleConstraintRoles, strings.Join(values, ", ")), args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (rr *RoleConstraintsRepositoryImpl) ReadRoleConstraints(ctx context.Context) (resp []*model.RoleConstraint, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.QueryContext(ctx, selectRoleConstraints, orgUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var last *model.RoleConstraint
+
+	for rows.Next() {
+		res := &model.RoleConstraint{}
+		var roleUID string
+
+		err = rows.Scan(&res.ID, &res.UID, &res.Name, &res.IsDeleted, &res.CreatedBy, &res.CreatedAt,
+			&res.UpdatedBy, &res.UpdatedAt, &roleUID)
+		if err != nil {
+			return nil, err
+		}
+
+		if last == nil || last.UID != res.UID {
+			last = res
+			resp = append(resp, res)
+		}
+		last.RoleUIDs = append(last.RoleUIDs, roleUID)
+	}
+
+	return resp, rows.Err()
+}
+
+func (rr *RoleConstraintsRepositoryImpl) DeleteRoleConstraint(ctx context.Context, req *model.DeleteRoleConstraintReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := rr.db.ExecContext(ctx, deleteRoleConstraint, req.UpdatedBy, orgUID, req.UID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("role constraint %s is not found", req.UID)
+	}
+
+	return nil
+}
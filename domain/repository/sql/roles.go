@@ -5,12 +5,19 @@ import (
 	"database/sql"
 	"github/yogabagas/join-app/domain/model"
 	"github/yogabagas/join-app/service/roles/repository"
+	"github/yogabagas/join-app/shared/constant"
 )
 
 const (
-	insertRoles     = `INSERT INTO roles (uid, name, created_by, updated_by) VALUES (?,?,?,?)`
-	selectRolesByID = `SELECT id, uid, name, is_deleted, created_by, created_at, updated_by, updated_at 
-	FROM roles WHERE id = ?`
+	insertRoles     = `INSERT INTO roles (uid, organization_uid, name, parent_uid, created_by, updated_by) VALUES (?,?,?,?,?,?)`
+	selectRolesByID = `SELECT id, uid, name, parent_uid, is_deleted, created_by, created_at, updated_by, updated_at 
+	FROM roles WHERE organization_uid = ? AND id = ?`
+	selectRoles = `SELECT id, uid, name, parent_uid, is_deleted, created_by, created_at, updated_by, updated_at 
+	FROM roles WHERE organization_uid = ? AND is_deleted = false ORDER BY id ASC`
+	updateRoleParent          = `UPDATE roles SET parent_uid = ?, updated_by = ?, updated_at = now() WHERE organization_uid = ? AND uid = ?`
+	selectRolesWithPagination = `SELECT r.id, r.uid, r.name, r.parent_uid, r.is_deleted, r.created_by, r.created_at, r.updated_by,
+	r.updated_at FROM roles r`
+	selectCountRolesWithPagination = `SELECT COUNT(*) FROM roles r`
 )
 
 type RolesRepositoryImpl struct {
@@ -23,7 +30,14 @@ func NewRolesRepository(db DBExecutor) repository.RolesRepository {
 
 func (rr *RolesRepositoryImpl) CreateRoles(ctx context.Context, req *model.Role) error {
 
-	_, err := rr.db.ExecContext(ctx, insertRoles, req.UID, req.Name, req.CreatedBy, req.UpdatedBy)
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	parentUID := sql.NullString{String: req.ParentUID, Valid: req.ParentUID != ""}
+
+	_, err = rr.db.ExecContext(ctx, insertRoles, req.UID, orgUID, req.Name, parentUID, req.CreatedBy, req.UpdatedBy)
 	if err != nil {
 		return err
 	}
@@ -33,13 +47,127 @@ func (rr *RolesRepositoryImpl) CreateRoles(ctx context.Context, req *model.Role)
 
 func (rr *RolesRepositoryImpl) ReadRolesByID(ctx context.Context, req *model.ReadRolesByIDReq) (resp *model.Role, err error) {
 
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	resp = &model.Role{}
+	parentUID := sql.NullString{}
 
-	err = rr.db.QueryRowContext(ctx, selectRolesByID, req.ID).
-		Scan(&resp.ID, &resp.UID, &resp.Name, &resp.IsDeleted, &resp.CreatedBy, &resp.CreatedAt, &resp.UpdatedBy, &resp.UpdatedAt)
+	err = rr.db.QueryRowContext(ctx, selectRolesByID, orgUID, req.ID).
+		Scan(&resp.ID, &resp.UID, &resp.Name, &parentUID, &resp.IsDeleted, &resp.CreatedBy, &resp.CreatedAt, &resp.UpdatedBy, &resp.UpdatedAt)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
 	}
+	resp.ParentUID = parentUID.String
+
+	return resp, nil
+}
+
+func (rr *RolesRepositoryImpl) ReadRoles(ctx context.Context) (resp []*model.Role, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.QueryContext(ctx, selectRoles, orgUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res := &model.Role{}
+		parentUID := sql.NullString{}
+
+		err = rows.Scan(&res.ID, &res.UID, &res.Name, &parentUID, &res.IsDeleted, &res.CreatedBy, &res.CreatedAt, &res.UpdatedBy, &res.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res.ParentUID = parentUID.String
+
+		resp = append(resp, res)
+	}
+
+	return resp, rows.Err()
+}
+
+func (rr *RolesRepositoryImpl) UpdateRoleParent(ctx context.Context, req *model.UpdateRoleParentReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	parentUID := sql.NullString{String: req.ParentUID, Valid: req.ParentUID != ""}
+
+	_, err = rr.db.ExecContext(ctx, updateRoleParent, parentUID, req.UpdatedBy, orgUID, req.UID)
+	return err
+}
+
+// roleColumns are the fields the roles listing filters and sorts on.
+var roleColumns = map[string]ListColumn{
+	"name": {Expr: "r.name", Fulltext: "r.name", Ops: []constant.FilterOp{constant.FilterEq, constant.FilterIn,
+		constant.FilterLike, constant.FilterPrefix, constant.FilterMatch}, Sortable: true},
+	"parent_uid": {Expr: "r.parent_uid", Ops: []constant.FilterOp{constant.FilterEq, constant.FilterIn}},
+	"created_at": {Expr: "r.created_at", Kind: KindTime, Ops: []constant.FilterOp{constant.FilterGt, constant.FilterGte,
+		constant.FilterLt, constant.FilterLte}, Sortable: true},
+}
+
+func (rr *RolesRepositoryImpl) ReadRolesWithPagination(ctx context.Context, req *model.ListReq) (*model.ReadRolesWithPaginationResp, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := NewListQuery(roleColumns, "r.uid").Where("r.organization_uid = ?", orgUID).Where("r.is_deleted = false")
+
+	if err = q.Filter(req.Filters); err != nil {
+		return nil, err
+	}
+
+	if err = q.Sort(req); err != nil {
+		return nil, err
+	}
+
+	var total int
+
+	query, args := q.Count(selectCountRolesWithPagination)
+	if err = rr.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	query, args = q.Select(selectRolesWithPagination, req)
+
+	rows, err := rr.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []*model.Role{}
+	for rows.Next() {
+		res := &model.Role{}
+		parentUID := sql.NullString{}
+
+		err = rows.Scan(&res.ID, &res.UID, &res.Name, &parentUID, &res.IsDeleted, &res.CreatedBy, &res.CreatedAt, &res.UpdatedBy, &res.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res.ParentUID = parentUID.String
+
+		roles = append(roles, res)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &model.ReadRolesWithPaginationResp{}
+	resp.Roles, resp.Page = listPage(req, roles, total, func(v *model.Role) string { return v.UID })
 
 	return resp, nil
 }
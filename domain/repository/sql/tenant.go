@@ -0,0 +1,26 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"github/yogabagas/join-app/shared/constant"
+)
+
+// ErrTenantMissing is returned by every tenant scoped repository when ctx
+// carries no organization, so a forgotten scope fails instead of reading
+// across organizations.
+var ErrTenantMissing = errors.New("organization is missing from context")
+
+// WithTenant scopes every repository call made with the returned context to
+// the organization orgUID.
+func WithTenant(ctx context.Context, orgUID string) context.Context {
+	return context.WithValue(ctx, constant.Tenant, orgUID)
+}
+
+func TenantFromContext(ctx context.Context) (string, error) {
+	orgUID, ok := ctx.Value(constant.Tenant).(string)
+	if !ok || orgUID == "" {
+		return "", ErrTenantMissing
+	}
+	return orgUID, nil
+}
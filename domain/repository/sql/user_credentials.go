@@ -3,14 +3,28 @@ package sql
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"github/yogabagas/join-app/domain/model"
 	"github/yogabagas/join-app/service/userCredentials/repository"
 )
 
 const (
-	insertUserCredentials = `INSERT INTO user_credentials (user_uid, username, password) 
-	VALUES (?,?,?)`
-	selectCredentialsByUserUIDAndPassword = `SELECT (1) FROM user_credentials WHERE user_uid = ? AND password = ?`
+	insertUserCredentials = `INSERT INTO user_credentials (user_uid, organization_uid, username, password) 
+	SELECT uid, organization_uid, ?, ? FROM users WHERE organization_uid = ? AND uid = ?`
+	selectCredentialsByUserUIDAndPassword = `SELECT uc.is_active FROM user_credentials uc JOIN users u ON uc.user_uid = u.uid 
+	WHERE u.organization_uid = ? AND uc.user_uid = ? AND uc.password = ? AND u.is_deleted = false`
+	selectCredentialByUserUID = `SELECT uc.user_uid, uc.username, uc.password, uc.is_active, uc.status_reason, 
+	IFNULL(uc.status_updated_by, ''), uc.status_updated_at, uc.created_at FROM user_credentials uc JOIN users u ON uc.user_uid = u.uid 
+	WHERE u.organization_uid = ? AND uc.user_uid = ? AND u.is_deleted = false`
+	updatePassword = `UPDATE user_credentials uc JOIN users u ON uc.user_uid = u.uid SET uc.password = ? 
+	WHERE u.organization_uid = ? AND uc.user_uid = ?`
+	updateCredentialActive = `UPDATE user_credentials uc JOIN users u ON uc.user_uid = u.uid SET uc.is_active = ?, 
+	uc.status_reason = ?, uc.status_updated_by = ?, uc.status_updated_at = now() 
+	WHERE u.organization_uid = ? AND uc.user_uid = ? AND u.is_deleted = false`
+	insertStatusHistory = `INSERT INTO user_status_histories (uid, organization_uid, user_uid, is_active, reason, created_by)
+	VALUES (?,?,?,?,?,?)`
+	selectUsernames = `SELECT username FROM user_credentials WHERE organization_uid = ? AND username IN (%s)`
 )
 
 type UserCredentialsRepositoryImpl struct {
@@ -22,23 +36,164 @@ func NewUserCredentialsRepository(db DBExecutor) repository.UserCredentialsRepos
 }
 
 func (uc *UserCredentialsRepositoryImpl) InsertCredential(ctx context.Context, req *model.UserCredential) error {
-	_, err := uc.db.ExecContext(ctx, insertUserCredentials, req.UserUID, req.Username, req.Password)
+	orgUID, err := TenantFromContext(ctx)
 	if err != nil {
 		return err
 	}
+
+	res, err := uc.db.ExecContext(ctx, insertUserCredentials, req.Username, req.Password, orgUID, req.UserUID)
+	if err != nil {
+		return duplicateOf(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.New("user is not found in the organization")
+	}
 	return nil
 }
 
 func (uc *UserCredentialsRepositoryImpl) ReadCredentialsByUserUIDAndPassword(ctx context.Context, req *model.ReadCredentialsByUserUIDAndPasswordReq) (resp *model.ReadCredentialsByUserUIDAndPasswordResp, err error) {
 
-	var valid int
-	err = uc.db.QueryRowContext(ctx, selectCredentialsByUserUIDAndPassword, req.UserUID, req.Password).
-		Scan(&valid)
-	if err != nil && err != sql.ErrNoRows {
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &model.ReadCredentialsByUserUIDAndPasswordResp{}
+
+	err = uc.db.QueryRowContext(ctx, selectCredentialsByUserUIDAndPassword, orgUID, req.UserUID, req.Password).
+		Scan(&resp.IsActive)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return resp, nil
+		}
+		return nil, err
+	}
+
+	resp.Valid = true
+
+	return resp, nil
+}
+
+// ReadCredentialByUserUID returns nil when the user has no credential in the
+// organization.
+func (uc *UserCredentialsRepositoryImpl) ReadCredentialByUserUID(ctx context.Context, req *model.ReadCredentialByUserUIDReq) (*model.UserCredential, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &model.UserCredential{}
+
+	err = uc.db.QueryRowContext(ctx, selectCredentialByUserUID, orgUID, req.UserUID).
+		Scan(&res.UserUID, &res.Username, &res.Password, &res.IsActive, &res.StatusReason, &res.StatusUpdatedBy,
+			&res.StatusUpdatedAt, &res.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
 		return nil, err
 	}
 
-	return &model.ReadCredentialsByUserUIDAndPasswordResp{
-		Valid: valid > 0,
-	}, nil
+	return res, nil
+}
+
+func (uc *UserCredentialsRepositoryImpl) UpdatePassword(ctx context.Context, req *model.UpdatePasswordReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := uc.db.ExecContext(ctx, updatePassword, req.Password, orgUID, req.UserUID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.New("user is not found in the organization")
+	}
+	return nil
+}
+
+func (uc *UserCredentialsRepositoryImpl) InsertStatusHistory(ctx context.Context, req *model.UserStatusHistory) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.db.ExecContext(ctx, insertStatusHistory, req.UID, orgUID, req.UserUID, req.IsActive, req.Reason, req.CreatedBy)
+
+	return err
+}
+
+func (uc *UserCredentialsRepositoryImpl) UpdateCredentialActive(ctx context.Context, req *model.UpdateCredentialActiveReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := uc.db.ExecContext(ctx, updateCredentialActive, req.IsActive, req.Reason, req.UpdatedBy, orgUID, req.UserUID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.New("user is not found in the organization")
+	}
+	return nil
+}
+
+// ReadUsernames returns the usernames of req already taken in the
+// organization.
+func (uc *UserCredentialsRepositoryImpl) ReadUsernames(ctx context.Context, req *model.ReadUsernamesReq) (resp []string, err error) {
+
+	if len(req.Usernames) == 0 {
+		return nil, nil
+	}
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]interface{}, 0, len(req.Usernames)+1)
+	args = append(args, orgUID)
+	for _, v := range req.Usernames {
+		args = append(args, v)
+	}
+
+	rows, err := uc.db.QueryContext(ctx, fmt.Sprintf(selectUsernames, placeholders(len(req.Usernames))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var username string
+		if err = rows.Scan(&username); err != nil {
+			return nil, err
+		}
+		resp = append(resp, username)
+	}
+
+	return resp, rows.Err()
 }
@@ -4,22 +4,39 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-
 	"fmt"
+
 	"github/yogabagas/join-app/domain/model"
 	"github/yogabagas/join-app/service/users/repository"
-	"strings"
+	"github/yogabagas/join-app/shared/constant"
 )
 
+// userIsActive tells whether the credential of the user u is active, the
+// users without any credential counting as active.
+const userIsActive = `IFNULL((SELECT uc.is_active FROM user_credentials uc WHERE uc.user_uid = u.uid LIMIT 1), true)`
+
 const (
-	insertUsers = `INSERT INTO users (uid, first_name, last_name, email, birthdate, description, gender, country, photo, created_by, updated_by) 
-	VALUES (?,?,?,?,?,?,?,?,?,?,?)`
+	insertUsers = `INSERT INTO users (uid, organization_uid, first_name, last_name, email, birthdate, description, gender, country, photo, created_by, updated_by) 
+	VALUES (?,?,?,?,?,?,?,?,?,?,?,?)`
 	selectUsersByEmail = `SELECT u.uid, a.role_uid, r.name as role_name, a.last_active FROM users u JOIN authz a ON u.uid = a.user_uid 
-	JOIN roles r ON a.role_uid = r.uid WHERE u.email = ? ORDER BY r.id ASC LIMIT 1`
-	selectUsersWithPagination = `SELECT u.uid, u.first_name, u.last_name, u.email, u.birthdate, u.username, u.created_at, 
-	(SELECT COUNT(*) from users us WHERE us.id = u.id) as per_page, r.name as role_name FROM users u JOIN authz a ON u.uid = a.user_uid 
-	JOIN roles r ON a.role_uid = r.uid %s`
-	selectCountUsers = `SELECT COUNT(*) FROM users WHERE is_deleted = ?`
+	JOIN roles r ON a.role_uid = r.uid WHERE u.organization_uid = ? AND u.email = ? AND u.is_deleted = false AND a.is_deleted = false AND r.is_deleted = false AND ` + authzInWindow + `
+	ORDER BY r.id ASC LIMIT 1`
+	selectUsersByEmails       = `SELECT uid, email FROM users WHERE organization_uid = ? AND is_deleted = false AND email IN (%s)`
+	selectUsersWithPagination = `SELECT u.uid, u.first_name, u.last_name, u.email, u.birthdate, u.gender, u.country, u.description,
+	IFNULL((SELECT uc.username FROM user_credentials uc WHERE uc.user_uid = u.uid LIMIT 1), ''), ` + userIsActive + `, u.created_at,
+	IFNULL((SELECT r.name FROM authz a JOIN roles r ON r.uid = a.role_uid WHERE a.user_uid = u.uid AND a.is_deleted = false
+	AND r.is_deleted = false AND ` + authzInWindow + ` ORDER BY r.id ASC LIMIT 1), '') FROM users u`
+	selectCountUsersWithPagination = `SELECT COUNT(*) FROM users u`
+	selectUserByUID                = `SELECT id, uid, first_name, last_name, email, birthdate, description, gender, country, IFNULL(photo, ''),
+	is_deleted, created_by, created_at, updated_by, updated_at FROM users WHERE organization_uid = ? AND uid = ? AND is_deleted = false`
+	updateUserProfile = `UPDATE users SET first_name = COALESCE(?, first_name), last_name = COALESCE(?, last_name),
+	birthdate = COALESCE(?, birthdate), description = COALESCE(?, description), gender = COALESCE(?, gender),
+	country = COALESCE(?, country), photo = COALESCE(?, photo), updated_by = ?, updated_at = now()
+	WHERE organization_uid = ? AND uid = ? AND is_deleted = false`
+	updateUserDeleted = `UPDATE users SET is_deleted = ?, updated_by = ?, updated_at = now()
+	WHERE organization_uid = ? AND uid = ? AND is_deleted = ?`
+	selectUserExists = `SELECT 1 FROM users WHERE organization_uid = ? AND uid = ?`
+	selectCountUsers = `SELECT COUNT(*) FROM users WHERE organization_uid = ? AND is_deleted = ?`
 )
 
 type UsersRepositoryImpl struct {
@@ -32,19 +49,25 @@ func NewUsersRepository(db DBExecutor) repository.UsersRepository {
 
 func (ur *UsersRepositoryImpl) CreateUsers(ctx context.Context, req *model.User) error {
 
-	_, err := ur.db.ExecContext(ctx, insertUsers, req.UID, req.FirstName, req.LastName, req.Email, req.Birthdate,
-		req.Description, req.Gender, req.Country, req.Photo, req.CreatedBy, req.UpdatedBy)
-	if err != nil && !strings.Contains(err.Error(), "duplicate") {
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	_, err = ur.db.ExecContext(ctx, insertUsers, req.UID, orgUID, req.FirstName, req.LastName, req.Email, req.Birthdate,
+		req.Description, req.Gender, req.Country, req.Photo, req.CreatedBy, req.UpdatedBy)
+	return duplicateOf(err)
 }
 
 func (ur *UsersRepositoryImpl) ReadUserByEmail(ctx context.Context, req *model.ReadUserByEmailReq) (resp *model.ReadUserByEmailResp, err error) {
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	resp = &model.ReadUserByEmailResp{}
 
-	err = ur.db.QueryRowContext(ctx, selectUsersByEmail, req.Email).
+	err = ur.db.QueryRowContext(ctx, selectUsersByEmail, orgUID, req.Email).
 		Scan(&resp.UserUID, &resp.RoleUID, &resp.RoleName, &resp.LastActive)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -56,45 +79,201 @@ func (ur *UsersRepositoryImpl) ReadUserByEmail(ctx context.Context, req *model.R
 	return resp, nil
 }
 
-func (ur *UsersRepositoryImpl) ReadUsersWithPagination(ctx context.Context, req *model.ReadUsersWithPaginationReq) (resp *model.ReadUsersWithPaginationResp, err error) {
+// ReadUsersByEmails returns the users of the organization holding the emails
+// of req, with their uid and email only.
+func (ur *UsersRepositoryImpl) ReadUsersByEmails(ctx context.Context, req *model.ReadUsersByEmailsReq) (resp []*model.User, err error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Emails) == 0 {
+		return nil, nil
+	}
 
-	cond := fmt.Sprintf("WHERE MATCH (u.first_name, u.last_name) AGAINST ('%s*' IN BOOLEAN MODE) LIMIT %d OFFSET %d", req.Fullname, req.Limit, req.Offset)
+	args := []interface{}{orgUID}
+	for _, v := range req.Emails {
+		args = append(args, v)
+	}
 
-	if req.Fullname == "" {
-		cond = fmt.Sprintf("LIMIT %d OFFSET %d", req.Limit, req.Offset)
+	rows, err := ur.db.QueryContext(ctx, fmt.Sprintf(selectUsersByEmails, placeholders(len(req.Emails))), args...)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	q := fmt.Sprintf(selectUsersWithPagination, cond)
+	for rows.Next() {
+		res := &model.User{}
+		if err = rows.Scan(&res.UID, &res.Email); err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
 
-	rows, err := ur.db.QueryContext(ctx, q)
+	return resp, rows.Err()
+}
 
-	if err != nil && err != sql.ErrNoRows {
+func (ur *UsersRepositoryImpl) ReadUserByUID(ctx context.Context, req *model.ReadUserByUIDReq) (*model.User, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &model.User{}
+
+	err = ur.db.QueryRowContext(ctx, selectUserByUID, orgUID, req.UID).
+		Scan(&res.ID, &res.UID, &res.FirstName, &res.LastName, &res.Email, &res.Birthdate, &res.Description, &res.Gender,
+			&res.Country, &res.Photo, &res.IsDeleted, &res.CreatedBy, &res.CreatedAt, &res.UpdatedBy, &res.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ur *UsersRepositoryImpl) UpdateUserProfile(ctx context.Context, req *model.UpdateUserProfileReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = ur.db.ExecContext(ctx, updateUserProfile, req.FirstName, req.LastName, req.Birthdate, req.Description,
+		req.Gender, req.Country, req.Photo, req.UpdatedBy, orgUID, req.UID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateUserDeleted flips is_deleted, failing when the user is missing or
+// already in the requested state.
+func (ur *UsersRepositoryImpl) UpdateUserDeleted(ctx context.Context, req *model.UpdateUserDeletedReq) error {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := ur.db.ExecContext(ctx, updateUserDeleted, req.IsDeleted, req.UpdatedBy, orgUID, req.UID, !req.IsDeleted)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected > 0 {
+		return nil
+	}
+
+	var exists int
+	if err = ur.db.QueryRowContext(ctx, selectUserExists, orgUID, req.UID).Scan(&exists); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: user %s", ErrNoRow, req.UID)
+		}
+		return err
+	}
+
+	return fmt.Errorf("%w: user %s", ErrUnchanged, req.UID)
+}
+
+// userColumns are the fields the users listing filters and sorts on.
+var userColumns = map[string]ListColumn{
+	"name": {Expr: "CONCAT(u.first_name, ' ', u.last_name)", Fulltext: "u.first_name, u.last_name",
+		Ops: []constant.FilterOp{constant.FilterMatch, constant.FilterLike, constant.FilterPrefix}, Sortable: true},
+	"first_name": {Expr: "u.first_name", Ops: []constant.FilterOp{constant.FilterEq, constant.FilterLike, constant.FilterPrefix}, Sortable: true},
+	"last_name":  {Expr: "u.last_name", Ops: []constant.FilterOp{constant.FilterEq, constant.FilterLike, constant.FilterPrefix}, Sortable: true},
+	"email": {Expr: "u.email", Ops: []constant.FilterOp{constant.FilterEq, constant.FilterIn, constant.FilterLike,
+		constant.FilterPrefix}, Sortable: true},
+	"country": {Expr: "u.country", Ops: []constant.FilterOp{constant.FilterEq, constant.FilterNe, constant.FilterIn}, Sortable: true},
+	"gender":  {Expr: "u.gender", Kind: KindInt, Ops: []constant.FilterOp{constant.FilterEq, constant.FilterIn}},
+	"birthdate": {Expr: "u.birthdate", Kind: KindTime, Ops: []constant.FilterOp{constant.FilterEq, constant.FilterGt,
+		constant.FilterGte, constant.FilterLt, constant.FilterLte}, Sortable: true},
+	"created_at": {Expr: "u.created_at", Kind: KindTime, Ops: []constant.FilterOp{constant.FilterGt, constant.FilterGte,
+		constant.FilterLt, constant.FilterLte}, Sortable: true},
+	"role": {Cond: `EXISTS (SELECT 1 FROM authz a JOIN roles r ON r.uid = a.role_uid WHERE a.user_uid = u.uid
+	AND a.is_deleted = false AND r.is_deleted = false AND ` + authzInWindow + ` AND r.name %s)`,
+		Ops: []constant.FilterOp{constant.FilterEq, constant.FilterIn}},
+	"status": {Cond: `IF(` + userIsActive + `, 'active', 'suspended') %s`,
+		Ops: []constant.FilterOp{constant.FilterEq, constant.FilterIn}},
+	"expertise": {Cond: `EXISTS (SELECT 1 FROM user_expertises ue WHERE ue.user_uid = u.uid AND ue.expertise_uid %s)`,
+		Ops: []constant.FilterOp{constant.FilterEq, constant.FilterIn}},
+}
+
+func (ur *UsersRepositoryImpl) ReadUsersWithPagination(ctx context.Context, req *model.ListReq) (*model.ReadUsersWithPaginationResp, error) {
+
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	resp = &model.ReadUsersWithPaginationResp{}
+	q := NewListQuery(userColumns, "u.uid").Where("u.organization_uid = ?", orgUID).Where("u.is_deleted = false")
+
+	if err = q.Filter(req.Filters); err != nil {
+		return nil, err
+	}
+
+	if err = q.Sort(req); err != nil {
+		return nil, err
+	}
 
+	var total int
+
+	query, args := q.Count(selectCountUsersWithPagination)
+	if err = ur.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	query, args = q.Select(selectUsersWithPagination, req)
+
+	rows, err := ur.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []model.UserWithRole{}
 	for rows.Next() {
 		user := model.UserWithRole{}
-		var perPage int
 
-		err = rows.Scan(&user.UID, &user.FirstName, &user.LastName, &user.Email, &user.Birthdate, &user.Username,
-			&user.CreatedAt, &perPage, &user.RoleName)
+		err = rows.Scan(&user.UID, &user.FirstName, &user.LastName, &user.Email, &user.Birthdate, &user.Gender,
+			&user.Country, &user.Description, &user.Username, &user.IsActive, &user.CreatedAt, &user.RoleName)
 		if err != nil {
 			return nil, err
 		}
 
-		resp.PerPage += perPage
-		resp.Users = append(resp.Users, user)
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
 	}
+
+	resp := &model.ReadUsersWithPaginationResp{}
+	resp.Users, resp.Page = listPage(req, users, total, func(v model.UserWithRole) string { return v.UID })
+
 	return resp, nil
 }
 
 func (ur *UsersRepositoryImpl) CountUsers(ctx context.Context, req *model.CountUsersReq) (resp *model.CountUsersResp, err error) {
 
+	orgUID, err := TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	resp = &model.CountUsersResp{}
 
-	err = ur.db.QueryRowContext(ctx, selectCountUsers, req.IsDeleted).Scan(&resp.Total)
+	err = ur.db.QueryRowContext(ctx, selectCountUsers, orgUID, req.IsDeleted).Scan(&resp.Total)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
 	}
@@ -1,17 +1,33 @@
 package service
 
-import "time"
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
 
 type UpsertAccessReq struct {
-	RoleUID     string   `json:"role_uid"`
-	ResourceUID []string `json:"resources_uid"`
-	CreatedBy   string
-	CreatedAt   time.Time
-	UpdatedBy   string
-	UpdatedAt   time.Time
+	RoleUID     string            `json:"role_uid"`
+	ResourceUID []string          `json:"resources_uid"`
+	Conditions  map[string]string `json:"conditions,omitempty"`
+	// ActorRoleUID is the role of the caller, which must sit above RoleUID
+	// and hold every resource it grants.
+	ActorRoleUID string `json:"-"`
+	CreatedBy    string
+	CreatedAt    time.Time
+	UpdatedBy    string
+	UpdatedAt    time.Time
+}
+
+func (r UpsertAccessReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.RoleUID, validation.Required),
+		validation.Field(&r.ResourceUID, validation.Each(validation.Required)),
+	)
 }
 
 type GetAccessByRoleUIDReq struct {
+	UserUID string
 	RoleUID string
 	Type    int
 }
@@ -25,3 +41,47 @@ type GetAccessByRoleUIDResp struct {
 	Level     int                      `json:"level"`
 	Child     []GetAccessByRoleUIDResp `json:"child,omitempty"`
 }
+
+type GetAccessHistoryReq struct {
+	RoleUID string
+}
+
+type AccessHistoryResp struct {
+	Version   int                 `json:"version"`
+	Note      string              `json:"note,omitempty"`
+	Total     int                 `json:"total"`
+	Added     []AccessHistoryItem `json:"added"`
+	Removed   []AccessHistoryItem `json:"removed"`
+	Changed   []AccessHistoryItem `json:"changed"`
+	CreatedBy string              `json:"created_by"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+type AccessHistoryItem struct {
+	ResourceUID string `json:"resource_uid"`
+	Name        string `json:"name"`
+	Type        int    `json:"type"`
+	Action      string `json:"action"`
+	Condition   string `json:"condition,omitempty"`
+}
+
+type DiffAccessHistoryReq struct {
+	RoleUID string
+	From    int
+	To      int
+}
+
+type DiffAccessHistoryResp struct {
+	From    int                 `json:"from"`
+	To      int                 `json:"to"`
+	Added   []AccessHistoryItem `json:"added"`
+	Removed []AccessHistoryItem `json:"removed"`
+	Changed []AccessHistoryItem `json:"changed"`
+}
+
+type RollbackAccessReq struct {
+	RoleUID      string
+	Version      int
+	ActorRoleUID string
+	UpdatedBy    string
+}
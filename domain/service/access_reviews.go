@@ -0,0 +1,94 @@
+package service
+
+import (
+	"github/yogabagas/join-app/shared/constant"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CreateAccessReviewReq struct {
+	Name     string    `json:"name"`
+	Deadline time.Time `json:"deadline"`
+	// Reviewers maps a role uid to the user reviewing its grants, the others
+	// going to DefaultReviewerUID. Grants held by their reviewer are escalated
+	// to the creator of the campaign.
+	Reviewers          map[string]string `json:"reviewers,omitempty"`
+	DefaultReviewerUID string            `json:"default_reviewer_uid"`
+	CreatedBy          string            `json:"-"`
+}
+
+func (r CreateAccessReviewReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, notBlank),
+		validation.Field(&r.Deadline, validation.Required, isFuture()),
+		validation.Field(&r.Reviewers, validation.Each(validation.Required)),
+		validation.Field(&r.DefaultReviewerUID, validation.Required),
+	)
+}
+
+type GetAccessReviewsReq struct {
+	Status string
+}
+
+type AccessReviewResp struct {
+	UID       string     `json:"uid"`
+	Name      string     `json:"name"`
+	Deadline  time.Time  `json:"deadline"`
+	Status    string     `json:"status"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+	Items     int        `json:"items,omitempty"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type GetAccessReviewItemsReq struct {
+	ReviewUID   string
+	ReviewerUID string
+	Pending     bool
+}
+
+type AccessReviewItemResp struct {
+	UID         string     `json:"uid"`
+	AuthzUID    string     `json:"authz_uid"`
+	UserUID     string     `json:"user_uid"`
+	UserEmail   string     `json:"user_email"`
+	RoleUID     string     `json:"role_uid"`
+	RoleName    string     `json:"role_name"`
+	ReviewerUID string     `json:"reviewer_uid"`
+	Decision    string     `json:"decision"`
+	DecidedBy   string     `json:"decided_by,omitempty"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+	Note        string     `json:"note,omitempty"`
+}
+
+type DecideAccessReviewItemReq struct {
+	ReviewUID string `json:"-"`
+	ItemUID   string `json:"-"`
+	// Decision is either confirmed or revoked.
+	Decision string `json:"decision"`
+	Note     string `json:"note"`
+	ActorUID string `json:"-"`
+}
+
+func (r DecideAccessReviewItemReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Decision, validation.Required,
+			validation.In(constant.ReviewConfirmed.String(), constant.ReviewRevoked.String())),
+	)
+}
+
+type ExportAccessReviewReq struct {
+	ReviewUID string
+}
+
+type AccessReviewReportResp struct {
+	Review AccessReviewResp
+	Items  []AccessReviewItemResp
+}
+
+type CloseOverdueAccessReviewsResp struct {
+	Closed   int      `json:"closed"`
+	Revoked  int      `json:"revoked"`
+	UserUIDs []string `json:"user_uids"`
+}
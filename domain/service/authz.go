@@ -1,17 +1,30 @@
 package service
 
-import "time"
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
 
 type JWTClaims struct {
 	Sub        string    `json:"sub"`
 	RoleUID    string    `json:"role_uid"`
+	OrgUID     string    `json:"org_uid"`
+	SessionUID string    `json:"sid"`
 	LastActive time.Time `json:"last_active"`
 	ExpiredAt  time.Time `json:"expired_at"`
 }
 
 type LoginReq struct {
-	Email    string `json:"email" validate:"required"`
-	Password string `json:"password" validate:"required"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (r LoginReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Email, validation.Required, isEmail),
+		validation.Field(&r.Password, validation.Required),
+	)
 }
 
 type LoginResp struct {
@@ -19,6 +32,23 @@ type LoginResp struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+type RefreshReq struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (r RefreshReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.RefreshToken, validation.Required),
+	)
+}
+
+// RefreshTokenReq holds the claims of a verified refresh token.
+type RefreshTokenReq struct {
+	UserUID    string
+	OrgUID     string
+	SessionUID string
+}
+
 type VerifyTokenReq struct {
 	Token string
 }
@@ -27,14 +57,120 @@ type VerifyTokenResp struct {
 	Valid      bool      `json:"valid"`
 	UserUID    string    `json:"user_uid"`
 	RoleUID    string    `json:"role_uid"`
+	OrgUID     string    `json:"org_uid"`
+	SessionUID string    `json:"sid"`
 	LastActive time.Time `json:"last_active"`
 	ExpiredAt  time.Time `json:"expired_at"`
 }
 
 type HasAuthenticatedReq struct {
-	Sub string `json:"sub"`
+	Sub        string `json:"sub"`
+	RoleUID    string `json:"role_uid"`
+	SessionUID string `json:"sid"`
 }
 
 type HasAuthenticatedResp struct {
 	Valid bool `json:"valid"`
 }
+
+type AuthorizeReq struct {
+	Claims   JWTClaims
+	Resource string
+	Action   string
+	Attrs    map[string]interface{}
+	Context  map[string]interface{}
+}
+
+type AuthorizeResp struct {
+	Allowed bool `json:"allowed"`
+}
+
+type CheckPermissionReq struct {
+	UserUID    string                 `json:"user_uid"`
+	Action     string                 `json:"action"`
+	Resource   string                 `json:"resource"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Context    map[string]interface{} `json:"context,omitempty"`
+	Explain    bool                   `json:"explain"`
+}
+
+func (r CheckPermissionReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.UserUID, validation.Required),
+		validation.Field(&r.Action, validation.Required),
+		validation.Field(&r.Resource, validation.Required),
+	)
+}
+
+type CheckPermissionResp struct {
+	Allowed     bool         `json:"allowed"`
+	Decision    string       `json:"decision"`
+	MatchedRule *MatchedRule `json:"matched_rule,omitempty"`
+	Explanation []string     `json:"explanation,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+type MatchedRule struct {
+	AccessUID    string `json:"access_uid"`
+	RoleUID      string `json:"role_uid"`
+	RoleName     string `json:"role_name"`
+	ResourceUID  string `json:"resource_uid"`
+	ResourceName string `json:"resource_name"`
+	Action       string `json:"action"`
+	Condition    string `json:"condition,omitempty"`
+}
+
+type CheckPermissionBatchReq struct {
+	Checks  []CheckPermissionReq `json:"checks"`
+	Explain bool                 `json:"explain"`
+}
+
+// MaxPermissionChecks bounds the checks of a batch.
+const MaxPermissionChecks = 100
+
+// Validate bounds the batch only, each check being validated on its own so a
+// bad check fails alone.
+func (r CheckPermissionBatchReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Checks, validation.Required, validation.Length(1, MaxPermissionChecks), validation.Skip),
+	)
+}
+
+// CheckPermissionBatchResp holds the result of each check in order, the
+// checks that couldn't be made denying with their Error.
+type CheckPermissionBatchResp struct {
+	Results []CheckPermissionResp `json:"results"`
+}
+
+type GrantRoleReq struct {
+	UserUID    string     `json:"user_uid"`
+	RoleUID    string     `json:"role_uid"`
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+	// ActorRoleUID is the role of the caller, which must sit above RoleUID.
+	ActorRoleUID string `json:"-"`
+	CreatedBy    string `json:"-"`
+}
+
+func (r GrantRoleReq) Validate() error {
+
+	validUntil := []validation.Rule{isFuture()}
+	if r.ValidFrom != nil {
+		validUntil = append(validUntil, validation.Min(*r.ValidFrom).Exclusive().Error("must be after valid_from"))
+	}
+
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.UserUID, validation.Required),
+		validation.Field(&r.RoleUID, validation.Required),
+		validation.Field(&r.ValidUntil, validUntil...),
+	)
+}
+
+type GrantRoleResp struct {
+	UID string `json:"uid"`
+}
+
+type SweepExpiredAuthzResp struct {
+	Revoked  int      `json:"revoked"`
+	UserUIDs []string `json:"user_uids"`
+}
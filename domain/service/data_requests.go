@@ -0,0 +1,120 @@
+package service
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// DataRequestReq asks for an export or the erasure of the personal data of
+// UserUID.
+type DataRequestReq struct {
+	UserUID  string `json:"-"`
+	Note     string `json:"note"`
+	ActorUID string `json:"-"`
+}
+
+func (r DataRequestReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Note, validation.Length(0, 255)),
+	)
+}
+
+// GetDataRequestsReq filters the data requests on the fields that are set.
+type GetDataRequestsReq struct {
+	UserUID string
+	Kind    string
+	Status  string
+	Limit   int
+}
+
+// DataRequestResp is a data request, DownloadURL serving the archive of a
+// completed export until ExpiresAt.
+type DataRequestResp struct {
+	UID         string     `json:"uid"`
+	UserUID     string     `json:"user_uid"`
+	Kind        string     `json:"kind"`
+	Status      string     `json:"status"`
+	Note        string     `json:"note"`
+	Error       string     `json:"error,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"`
+	CreatedBy   string     `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedBy string     `json:"completed_by,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// EraseUserReq anonymizes the personal data of the user UID.
+type EraseUserReq struct {
+	UID          string `json:"-"`
+	Reason       string `json:"reason"`
+	ActorUID     string `json:"-"`
+	ActorRoleUID string `json:"-"`
+}
+
+func (r EraseUserReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Reason, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+// DataExportArchive is the JSON archive of the personal data of a user
+// compiled by an export.
+type DataExportArchive struct {
+	GeneratedAt       time.Time            `json:"generated_at"`
+	Profile           UserDetailResp       `json:"profile"`
+	Educations        []EducationResp      `json:"educations"`
+	WorkExperiences   []WorkExperienceResp `json:"work_experiences"`
+	Languages         []LanguageResp       `json:"languages"`
+	Socials           []SocialResp         `json:"socials"`
+	Expertises        []ExpertiseTagResp   `json:"expertises"`
+	Credential        CredentialExport     `json:"credential"`
+	Sessions          []SessionExport      `json:"sessions"`
+	FeedbacksGiven    []FeedbackExport     `json:"feedbacks_given"`
+	FeedbacksReceived []FeedbackExport     `json:"feedbacks_received"`
+	AuditEntries      []AuditEntryExport   `json:"audit_entries"`
+	DataRequests      []DataRequestResp    `json:"data_requests"`
+}
+
+// CredentialExport is the metadata of the credential of a user, leaving out
+// their password hashes.
+type CredentialExport struct {
+	Username          string      `json:"username"`
+	Status            string      `json:"status"`
+	StatusReason      string      `json:"status_reason,omitempty"`
+	CreatedAt         time.Time   `json:"created_at"`
+	PasswordChangedAt []time.Time `json:"password_changed_at"`
+}
+
+type SessionExport struct {
+	SessionUID string `json:"session_uid,omitempty"`
+	ExpiresIn  int    `json:"expires_in"`
+}
+
+type FeedbackExport struct {
+	UID       string    `json:"uid"`
+	MentorUID string    `json:"mentor_uid"`
+	Rating    float64   `json:"rating"`
+	Comment   string    `json:"comment"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AuditEntryExport struct {
+	Kind     string    `json:"kind"`
+	UID      string    `json:"uid"`
+	RoleUID  string    `json:"role_uid,omitempty"`
+	Status   string    `json:"status,omitempty"`
+	ActorUID string    `json:"actor_uid"`
+	Note     string    `json:"note,omitempty"`
+	At       time.Time `json:"at"`
+}
+
+// ProcessDataRequestsResp counts the exports compiled, failed and expired
+// by a run of the data request job.
+type ProcessDataRequestsResp struct {
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+	Expired   int `json:"expired"`
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CreateElevationReq struct {
+	RoleUID         string `json:"role_uid"`
+	Justification   string `json:"justification"`
+	DurationMinutes int    `json:"duration_minutes"`
+	UserUID         string `json:"-"`
+}
+
+func (r CreateElevationReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.RoleUID, validation.Required),
+		validation.Field(&r.Justification, validation.Required, notBlank),
+		validation.Field(&r.DurationMinutes, validation.Required, validation.Min(1)),
+	)
+}
+
+type GetElevationsReq struct {
+	UserUID string
+	Status  string
+}
+
+type DecideElevationReq struct {
+	UID  string `json:"-"`
+	Note string `json:"note"`
+	// ActorUID and ActorRoleUID identify the approver, whose role must be one
+	// of the configured elevation approvers.
+	ActorUID     string `json:"-"`
+	ActorRoleUID string `json:"-"`
+}
+
+type ElevationResp struct {
+	UID             string     `json:"uid"`
+	UserUID         string     `json:"user_uid"`
+	RoleUID         string     `json:"role_uid"`
+	Justification   string     `json:"justification"`
+	DurationMinutes int        `json:"duration_minutes"`
+	Status          string     `json:"status"`
+	DecidedBy       string     `json:"decided_by,omitempty"`
+	DecidedAt       *time.Time `json:"decided_at,omitempty"`
+	DecisionNote    string     `json:"decision_note,omitempty"`
+	AuthzUID        string     `json:"authz_uid,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// ElevationDecisionEvent is the payload of the elevation_decision
+// notification stored for the requester once a request is approved or denied.
+type ElevationDecisionEvent struct {
+	RequestUID string     `json:"request_uid"`
+	OrgUID     string     `json:"org_uid"`
+	UserUID    string     `json:"user_uid"`
+	RoleUID    string     `json:"role_uid"`
+	Status     string     `json:"status"`
+	DecidedBy  string     `json:"decided_by"`
+	Note       string     `json:"note,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+}
@@ -0,0 +1,19 @@
+package service
+
+import "errors"
+
+// ErrForbidden is wrapped by use cases refusing an operation the caller is not
+// entitled to, so handlers can answer 403 instead of 400.
+var ErrForbidden = errors.New("forbidden")
+
+// ErrConflict is wrapped by use cases refusing an operation that would break
+// an invariant of the existing data, so handlers can answer 409.
+var ErrConflict = errors.New("conflict")
+
+// ErrNotFound is wrapped by use cases looking for something that doesn't
+// exist, so handlers can answer 404.
+var ErrNotFound = errors.New("not found")
+
+// ErrUnauthorized is wrapped by use cases refusing credentials or a session
+// that can't be verified, so handlers can answer 401.
+var ErrUnauthorized = errors.New("unauthorized")
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CreateExpertiseReq struct {
+	Name      string `json:"name"`
+	CreatedBy string `json:"-"`
+}
+
+func (r CreateExpertiseReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, notBlank, validation.RuneLength(0, 255)),
+	)
+}
+
+type UpdateExpertiseReq struct {
+	UID       string `json:"-"`
+	Name      string `json:"name"`
+	UpdatedBy string `json:"-"`
+}
+
+func (r UpdateExpertiseReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, notBlank, validation.RuneLength(0, 255)),
+	)
+}
+
+type DeleteExpertiseReq struct {
+	UID       string `json:"-"`
+	UpdatedBy string `json:"-"`
+}
+
+// AutocompleteExpertisesReq looks up the expertises whose name starts with
+// Query, at most Limit of them.
+type AutocompleteExpertisesReq struct {
+	Query string
+	Limit int
+}
+
+type ExpertiseResp struct {
+	UID       string    `json:"uid"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ExpertiseTagResp struct {
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+}
+
+// SetUserExpertisesReq replaces the expertises of a user with ExpertiseUIDs.
+type SetUserExpertisesReq struct {
+	UserUID       string   `json:"-"`
+	ExpertiseUIDs []string `json:"expertise_uids"`
+	ActorUID      string   `json:"-"`
+	ActorRoleUID  string   `json:"-"`
+}
+
+func (r SetUserExpertisesReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.ExpertiseUIDs, validation.Each(validation.Required)),
+	)
+}
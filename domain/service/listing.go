@@ -0,0 +1,23 @@
+package service
+
+// ListReq is the filter[field][op]=value, sort=-field and pagination query of
+// the list endpoints. Cursor, a next_cursor or prev_cursor of a previous page,
+// takes precedence over Page.
+type ListReq struct {
+	Filters []ListFilter
+	Sorts   []ListSort
+	Limit   int
+	Page    int
+	Cursor  string
+}
+
+type ListFilter struct {
+	Field  string
+	Op     string
+	Values []string
+}
+
+type ListSort struct {
+	Field string
+	Desc  bool
+}
@@ -0,0 +1,47 @@
+package service
+
+// SearchMentorsReq filters the mentors of the organization, Sort being one of
+// relevance, rating or newest. Relevance only applies along a Query and is
+// the default then, rating being the default otherwise.
+type SearchMentorsReq struct {
+	Query         string
+	ExpertiseUIDs []string
+	Countries     []string
+	Languages     []string
+	Gender        *int
+	MinYears      *int
+	MaxYears      *int
+	MinRating     *float64
+	Sort          string
+	Limit         int
+	Page          int
+}
+
+type MentorResp struct {
+	UID               string            `json:"uid"`
+	Fullname          string            `json:"name"`
+	Bio               string            `json:"bio"`
+	Gender            int               `json:"gender"`
+	Country           string            `json:"country"`
+	Photo             string            `json:"photo"`
+	PhotoThumbnails   map[string]string `json:"photo_thumbnails,omitempty"`
+	Expertises        []string          `json:"expertises"`
+	Languages         []string          `json:"languages"`
+	YearsOfExperience int               `json:"years_of_experience"`
+	Rating            float64           `json:"rating"`
+	RatingCount       int               `json:"rating_count"`
+}
+
+type FacetResp struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// SearchMentorsResp holds a page of mentors, and the facets counting the
+// mentors per expertise, country, language and gender.
+type SearchMentorsResp struct {
+	Mentors    []MentorResp           `json:"mentors"`
+	Facets     map[string][]FacetResp `json:"facets"`
+	Pagination Pagination             `json:"pagination"`
+}
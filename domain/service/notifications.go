@@ -0,0 +1,31 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// GetNotificationsReq lists the notifications of UserUID, only the unread ones
+// when Unread is set.
+type GetNotificationsReq struct {
+	UserUID string
+	Unread  bool
+	Limit   int
+}
+
+type ReadNotificationReq struct {
+	UID     string
+	UserUID string
+}
+
+// NotificationResp is a notification of the caller, Payload depending on its
+// kind, e.g. an ElevationDecisionEvent for elevation_decision.
+type NotificationResp struct {
+	UID        string          `json:"uid"`
+	Kind       string          `json:"kind"`
+	SubjectUID string          `json:"subject_uid"`
+	Payload    json.RawMessage `json:"payload"`
+	ReadAt     *time.Time      `json:"read_at,omitempty"`
+	CreatedBy  string          `json:"created_by"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CreateOrganizationReq struct {
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	CreatedBy string `json:"-"`
+}
+
+func (r CreateOrganizationReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, notBlank),
+		validation.Field(&r.Slug, validation.Required, isSlug),
+	)
+}
+
+type OrganizationResp struct {
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type ResolveOrganizationReq struct {
+	Key string
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type ChangePasswordReq struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+	UserUID         string `json:"-"`
+	SessionUID      string `json:"-"`
+}
+
+func (r ChangePasswordReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.CurrentPassword, validation.Required),
+		validation.Field(&r.NewPassword, validation.Required),
+	)
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"io"
+	"time"
+)
+
+type UploadPhotoReq struct {
+	UserUID      string
+	ActorUID     string
+	ActorRoleUID string
+	Data         []byte
+}
+
+type DeletePhotoReq struct {
+	UserUID      string
+	ActorUID     string
+	ActorRoleUID string
+}
+
+// PhotoResp holds the URLs of a photo. The URLs of an uploaded photo are
+// signed and expire at ExpiresAt.
+type PhotoResp struct {
+	URL        string            `json:"url"`
+	Thumbnails map[string]string `json:"thumbnails,omitempty"`
+	ExpiresAt  *time.Time        `json:"expires_at,omitempty"`
+}
+
+// GetBlobReq is a signed URL of a blob served by the app.
+type GetBlobReq struct {
+	Key       string
+	Expires   string
+	Signature string
+}
+
+type BlobResp struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// ProfileSectionRef names the entry UID of a section of the user UserUID,
+// changed by the actor ActorUID holding ActorRoleUID, who must be the user or
+// hold a role above every role of the user. Every profile section request
+// embeds it.
+type ProfileSectionRef struct {
+	UID          string `json:"-"`
+	UserUID      string `json:"-"`
+	ActorUID     string `json:"-"`
+	ActorRoleUID string `json:"-"`
+}
+
+// SetRef fills in the ref of the request embedding r.
+func (r *ProfileSectionRef) SetRef(ref ProfileSectionRef) {
+	*r = ref
+}
+
+type GetProfileSectionReq struct {
+	ProfileSectionRef
+}
+
+type DeleteProfileSectionReq struct {
+	ProfileSectionRef
+}
+
+// ReorderProfileSectionReq lists every entry of the section, in the order
+// they should be shown.
+type ReorderProfileSectionReq struct {
+	ProfileSectionRef
+	UIDs []string `json:"uids"`
+}
+
+func (r ReorderProfileSectionReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.UIDs, validation.Required, validation.Each(validation.Required)),
+	)
+}
+
+type EducationReq struct {
+	ProfileSectionRef
+	College   string `json:"college"`
+	Degree    string `json:"degree"`
+	Major     string `json:"major"`
+	StartYear int    `json:"start_year"`
+	EndYear   *int   `json:"end_year"`
+}
+
+func (r EducationReq) Validate() error {
+
+	endYear := []validation.Rule{validation.Max(time.Now().Year() + 10)}
+	if r.StartYear > 0 {
+		endYear = append(endYear, validation.Min(r.StartYear).Error("can't be before start_year"))
+	}
+
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.College, validation.Required, notBlank),
+		validation.Field(&r.StartYear, validation.Required, validation.Min(1901), validation.Max(time.Now().Year())),
+		validation.Field(&r.EndYear, endYear...),
+	)
+}
+
+type EducationResp struct {
+	UID       string    `json:"uid"`
+	College   string    `json:"college"`
+	Degree    string    `json:"degree"`
+	Major     string    `json:"major"`
+	StartYear int       `json:"start_year"`
+	EndYear   *int      `json:"end_year"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WorkExperienceReq takes its dates as YYYY-MM-DD, an empty end date
+// meaning the user is still in the role.
+type WorkExperienceReq struct {
+	ProfileSectionRef
+	Role        string  `json:"role"`
+	Company     string  `json:"company"`
+	Industry    string  `json:"industry"`
+	StartDate   string  `json:"start_date"`
+	EndDate     *string `json:"end_date"`
+	Description string  `json:"description"`
+}
+
+func (r WorkExperienceReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Role, validation.Required, notBlank),
+		validation.Field(&r.Company, validation.Required, notBlank),
+		validation.Field(&r.StartDate, validation.Required, isPastDate()),
+		validation.Field(&r.EndDate, validation.Date(time.DateOnly).Error("must be a YYYY-MM-DD date")),
+	)
+}
+
+type WorkExperienceResp struct {
+	UID         string    `json:"uid"`
+	Role        string    `json:"role"`
+	Company     string    `json:"company"`
+	Industry    string    `json:"industry"`
+	StartDate   string    `json:"start_date"`
+	EndDate     *string   `json:"end_date"`
+	Description string    `json:"description"`
+	Position    int       `json:"position"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// LanguageReq takes the ISO 639-1 code of the language.
+type LanguageReq struct {
+	ProfileSectionRef
+	Language string `json:"language"`
+}
+
+func (r LanguageReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Language, validation.Required, notBlank),
+	)
+}
+
+type LanguageResp struct {
+	UID       string    `json:"uid"`
+	Language  string    `json:"language"`
+	Name      string    `json:"name"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SocialReq struct {
+	ProfileSectionRef
+	Title string `json:"title"`
+	Link  string `json:"link"`
+}
+
+func (r SocialReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Title, validation.Required, notBlank),
+		validation.Field(&r.Link, validation.Required, isHTTPURL),
+	)
+}
+
+type SocialResp struct {
+	UID       string    `json:"uid"`
+	Title     string    `json:"title"`
+	Link      string    `json:"link"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,66 @@
+package service
+
+type RBACManifest struct {
+	Roles     []RBACRole     `json:"roles" yaml:"roles"`
+	Resources []RBACResource `json:"resources" yaml:"resources"`
+	Grants    []RBACGrant    `json:"grants" yaml:"grants"`
+}
+
+type RBACRole struct {
+	Name   string `json:"name" yaml:"name"`
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
+}
+
+type RBACResource struct {
+	Name     string         `json:"name" yaml:"name"`
+	Type     string         `json:"type" yaml:"type"`
+	Action   string         `json:"action" yaml:"action"`
+	Children []RBACResource `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+type RBACGrant struct {
+	Role      string              `json:"role" yaml:"role"`
+	Resources []RBACGrantResource `json:"resources" yaml:"resources"`
+}
+
+type RBACGrantResource struct {
+	Name      string `json:"name" yaml:"name"`
+	Type      string `json:"type,omitempty" yaml:"type,omitempty"`
+	Action    string `json:"action" yaml:"action"`
+	Condition string `json:"condition,omitempty" yaml:"condition,omitempty"`
+}
+
+type ApplyRBACReq struct {
+	Manifest  RBACManifest
+	DryRun    bool
+	CreatedBy string
+}
+
+type ApplyRBACResp struct {
+	Roles     []RBACRoleChange     `json:"roles"`
+	Resources []RBACResourceChange `json:"resources"`
+	Grants    []RBACGrantChange    `json:"grants"`
+	Applied   bool                 `json:"applied"`
+}
+
+type RBACRoleChange struct {
+	Op     string `json:"op"`
+	Name   string `json:"name"`
+	Parent string `json:"parent,omitempty"`
+}
+
+type RBACResourceChange struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Action string `json:"action"`
+	Parent string `json:"parent,omitempty"`
+}
+
+type RBACGrantChange struct {
+	Op        string `json:"op"`
+	Role      string `json:"role"`
+	Resource  string `json:"resource"`
+	Type      string `json:"type"`
+	Action    string `json:"action"`
+	Condition string `json:"condition,omitempty"`
+}
@@ -1,5 +1,12 @@
 package service
 
+import (
+	"github/yogabagas/join-app/shared/constant"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
 type CreateResourcesReq struct {
 	Name      string `json:"name"`
 	Type      int    `json:"type"`
@@ -8,6 +15,15 @@ type CreateResourcesReq struct {
 	CreatedBy string `json:"-"`
 }
 
+func (r CreateResourcesReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, notBlank),
+		validation.Field(&r.Type, validation.Required, validation.In(constant.Menu.Int(), constant.API.Int()).
+			Error("must be 1 (menu) or 2 (api)")),
+		validation.Field(&r.Action, validation.Required, notBlank),
+	)
+}
+
 type GetResourcesByTypeReq struct {
 	Type int `json:"type"`
 }
@@ -21,3 +37,40 @@ type GetResourcesByTypeResp struct {
 	Level     int                      `json:"level"`
 	Child     []GetResourcesByTypeResp `json:"child,omitempty"`
 }
+
+type APIRoute struct {
+	Path   string `json:"path"`
+	Method string `json:"method"`
+}
+
+type SyncAPIResourcesReq struct {
+	Routes    []APIRoute
+	DryRun    bool
+	CreatedBy string
+}
+
+type SyncAPIResourcesResp struct {
+	Created   []APIRoute         `json:"created"`
+	Unchanged int                `json:"unchanged"`
+	Orphaned  []OrphanedResource `json:"orphaned"`
+}
+
+type OrphanedResource struct {
+	UID    string `json:"uid"`
+	Path   string `json:"path"`
+	Method string `json:"method"`
+}
+
+type ResourceResp struct {
+	UID       string    `json:"uid"`
+	Name      string    `json:"name"`
+	Type      int       `json:"type"`
+	Action    string    `json:"action"`
+	ParentUID string    `json:"parent_uid,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetResourcesResp struct {
+	Resources  []ResourceResp `json:"resources"`
+	Pagination Pagination     `json:"pagination"`
+}
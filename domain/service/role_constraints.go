@@ -0,0 +1,38 @@
+package service
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CreateRoleConstraintReq struct {
+	Name      string   `json:"name"`
+	RoleUIDs  []string `json:"role_uids"`
+	CreatedBy string   `json:"-"`
+}
+
+func (r CreateRoleConstraintReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, notBlank),
+		validation.Field(&r.RoleUIDs, validation.Required, validation.Length(2, 0).Error("needs at least two roles"),
+			validation.Each(validation.Required)),
+	)
+}
+
+type RoleConstraintResp struct {
+	UID      string   `json:"uid"`
+	Name     string   `json:"name"`
+	RoleUIDs []string `json:"role_uids"`
+}
+
+type DeleteRoleConstraintReq struct {
+	UID       string `json:"-"`
+	UpdatedBy string `json:"-"`
+}
+
+type RoleConstraintViolationResp struct {
+	ConstraintUID  string   `json:"constraint_uid"`
+	ConstraintName string   `json:"constraint_name"`
+	UserUID        string   `json:"user_uid"`
+	RoleUIDs       []string `json:"role_uids"`
+	AuthzUIDs      []string `json:"authz_uids"`
+}
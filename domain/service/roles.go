@@ -1,6 +1,33 @@
 package service
 
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
 type CreateRolesReq struct {
-	Name      string `json:"name"`
-	CreatedBy string `json:"created_by"`
+	Name         string `json:"name"`
+	ParentUID    string `json:"parent_uid"`
+	CreatedBy    string `json:"created_by"`
+	ActorRoleUID string `json:"-"`
+}
+
+func (r CreateRolesReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, notBlank),
+		validation.Field(&r.ParentUID, validation.Required, notBlank),
+	)
+}
+
+type RoleResp struct {
+	UID       string    `json:"uid"`
+	Name      string    `json:"name"`
+	ParentUID string    `json:"parent_uid,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetRolesResp struct {
+	Roles      []RoleResp `json:"roles"`
+	Pagination Pagination `json:"pagination"`
 }
@@ -0,0 +1,59 @@
+package service
+
+import "io"
+
+// ImportUsersReq is a CSV of the users to create, its header naming the
+// columns. Nothing is written on a dry run, the rows only being validated.
+type ImportUsersReq struct {
+	Data         []byte
+	DryRun       bool
+	ActorUID     string
+	ActorRoleUID string
+}
+
+// ImportUsersResp reports every row of the CSV, the rows being numbered as
+// lines of the file, the header being line 1.
+type ImportUsersResp struct {
+	DryRun  bool            `json:"dry_run"`
+	Total   int             `json:"total"`
+	Created int             `json:"created"`
+	Failed  int             `json:"failed"`
+	Rows    []ImportRowResp `json:"rows"`
+}
+
+type ImportRowResp struct {
+	Row    int              `json:"row"`
+	Email  string           `json:"email"`
+	UID    string           `json:"uid,omitempty"`
+	Status string           `json:"status"`
+	Errors []ImportRowError `json:"errors,omitempty"`
+}
+
+type ImportRowError struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ExportUsersReq writes the users matched by the filters and sorts of List to
+// Writer, whatever its page, as CSV or JSON lines.
+type ExportUsersReq struct {
+	List   ListReq
+	Format string
+	Writer io.Writer
+}
+
+// UserExportRow is a user as exported, its CSV columns being importable back.
+type UserExportRow struct {
+	UID        string   `json:"uid"`
+	FirstName  string   `json:"first_name"`
+	LastName   string   `json:"last_name"`
+	Email      string   `json:"email"`
+	Birthdate  string   `json:"birthdate"`
+	Gender     string   `json:"gender"`
+	Country    string   `json:"country"`
+	Bio        string   `json:"bio"`
+	Username   string   `json:"username"`
+	Role       string   `json:"role"`
+	Expertises []string `json:"expertises"`
+	CreatedAt  string   `json:"created_at"`
+}
@@ -1,5 +1,11 @@
 package service
 
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
 type CreateUsersReq struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
@@ -15,14 +21,22 @@ type CreateUsersReq struct {
 	CreatedBy string `json:"-"`
 }
 
-type LogoutReq struct {
-	UserUID string `json:"user_uid"`
+func (r CreateUsersReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.FirstName, validation.Required, notBlank),
+		validation.Field(&r.LastName, validation.Required, notBlank),
+		validation.Field(&r.Birthdate, validation.Required, isPastDate()),
+		validation.Field(&r.Gender, isGender),
+		validation.Field(&r.Email, validation.Required, isEmail),
+		validation.Field(&r.RoleID, validation.Required),
+		validation.Field(&r.Username, validation.Required, notBlank),
+		validation.Field(&r.Password, validation.Required),
+	)
 }
 
-type GetUsersWithPaginationReq struct {
-	Fullname string `json:"name"`
-	Limit    int    `json:"limit"`
-	Page     int    `json:"page"`
+type LogoutReq struct {
+	UserUID    string `json:"user_uid"`
+	SessionUID string `json:"-"`
 }
 
 type GetUsersWithPaginationResp struct {
@@ -31,16 +45,106 @@ type GetUsersWithPaginationResp struct {
 }
 
 type UserResp struct {
+	UID        string   `json:"uid"`
+	Fullname   string   `json:"name"`
+	Username   string   `json:"username"`
+	Birthdate  string   `json:"birthdate"`
+	Email      string   `json:"email"`
+	Role       string   `json:"role"`
+	Status     string   `json:"status"`
+	Expertises []string `json:"expertises"`
+}
+
+type GetUserReq struct {
+	UID string
+	// ActorUID and ActorRoleUID identify the caller, who must be the user or
+	// hold a role above every role of the user.
+	ActorUID     string
+	ActorRoleUID string
+}
+
+// UpdateProfileReq only changes the fields that are set.
+type UpdateProfileReq struct {
+	UID          string  `json:"-"`
+	FirstName    *string `json:"first_name,omitempty"`
+	LastName     *string `json:"last_name,omitempty"`
+	Birthdate    *string `json:"birthdate,omitempty"`
+	Gender       *int    `json:"gender,omitempty"`
+	Country      *string `json:"country,omitempty"`
+	Photo        *string `json:"photo,omitempty"`
+	Bio          *string `json:"bio,omitempty"`
+	ActorUID     string  `json:"-"`
+	ActorRoleUID string  `json:"-"`
+}
+
+func (r UpdateProfileReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.FirstName, validation.NilOrNotEmpty, notBlank),
+		validation.Field(&r.LastName, validation.NilOrNotEmpty, notBlank),
+		validation.Field(&r.Birthdate, validation.NilOrNotEmpty, isPastDate()),
+		validation.Field(&r.Gender, isGender),
+	)
+}
+
+type DeleteUserReq struct {
+	UID          string
+	ActorUID     string
+	ActorRoleUID string
+}
+
+type RestoreUserReq struct {
+	UID          string
+	ActorUID     string
+	ActorRoleUID string
+}
+
+type UserDetailResp struct {
+	UID       string `json:"uid"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
 	Fullname  string `json:"name"`
-	Username  string `json:"username"`
-	Birthdate string `json:"birthdate"`
 	Email     string `json:"email"`
-	Role      string `json:"role"`
+	Birthdate string `json:"birthdate"`
+	Bio       string `json:"bio"`
+	Gender    int    `json:"gender"`
+	Country   string `json:"country"`
+	Photo     string `json:"photo"`
+	// PhotoThumbnails are the URLs of the square thumbnails of an uploaded
+	// photo by their size in pixels.
+	PhotoThumbnails map[string]string `json:"photo_thumbnails,omitempty"`
+	Roles           []string          `json:"roles"`
+	Expertises      []string          `json:"expertises"`
+	// Status is active or suspended, StatusReason telling why the user was
+	// last suspended or reactivated.
+	Status       string    `json:"status"`
+	StatusReason string    `json:"status_reason,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// Pagination describes the page of a listing. NextCursor and PrevCursor are
+// only set in the default order, when rows follow or precede the page, and
+// Page is 0 for the pages reached by cursor.
 type Pagination struct {
-	Page      int `json:"page"`
-	PerPage   int `json:"per_page"`
-	TotalPage int `json:"total_page"`
-	TotalData int `json:"total_data"`
+	Page       int    `json:"page"`
+	PerPage    int    `json:"per_page"`
+	TotalPage  int    `json:"total_page"`
+	TotalData  int    `json:"total_data"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// UpdateUserStatusReq suspends or reactivates the user UID. Reason is required
+// to suspend.
+type UpdateUserStatusReq struct {
+	UID          string `json:"-"`
+	Reason       string `json:"reason"`
+	ActorUID     string `json:"-"`
+	ActorRoleUID string `json:"-"`
+}
+
+func (r UpdateUserStatusReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Reason, validation.Length(0, 255)),
+	)
 }
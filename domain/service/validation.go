@@ -0,0 +1,48 @@
+package service
+
+import (
+	"github/yogabagas/join-app/shared/constant"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// The rules shared by the Validate methods of the requests. As every ozzo
+// rule but Required, they let empty values through.
+var (
+	// notBlank refuses the strings made of spaces only.
+	notBlank = validation.NewStringRuleWithError(func(s string) bool {
+		return strings.TrimSpace(s) != ""
+	}, validation.ErrRequired)
+
+	isEmail = validation.NewStringRuleWithError(func(s string) bool {
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	}, validation.NewError("validation_is_email", "must be a valid email address"))
+
+	isHTTPURL = validation.NewStringRuleWithError(func(s string) bool {
+		u, err := url.Parse(strings.TrimSpace(s))
+		return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	}, validation.NewError("validation_is_http_url", "must be an http or https URL"))
+
+	// isSlug takes the slugs in any case, the organizations lowering them.
+	isSlug = validation.Match(regexp.MustCompile(`(?i)^[a-z0-9][a-z0-9-]{1,98}[a-z0-9]$`)).
+		Error("must be 3 to 100 letters, digits or dashes")
+
+	isGender = validation.In(constant.Female.Int(), constant.Male.Int()).Error("must be 0 (female) or 1 (male)")
+)
+
+// isPastDate takes a YYYY-MM-DD date up to today.
+func isPastDate() validation.DateRule {
+	return validation.Date(time.DateOnly).Max(time.Now()).
+		Error("must be a YYYY-MM-DD date").RangeError("can't be in the future")
+}
+
+// isFuture takes a time after now.
+func isFuture() validation.ThresholdRule {
+	return validation.Min(time.Now()).Exclusive().Error("must be in the future")
+}
@@ -13,6 +13,7 @@ func (m *module) NewAccessRegistry() usecase.AccessService {
 	return usecase.NewAccessService(
 		m.NewRepositoryRegistry(),
 		m.NewCacheRegistry(),
+		m.NewLocalCacheRegistry(),
 		m.NewAccessPresenter(),
 	)
 }
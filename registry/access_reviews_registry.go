@@ -0,0 +1,17 @@
+package registry
+
+import (
+	"github/yogabagas/join-app/adapter/controller"
+	"github/yogabagas/join-app/service/accessReviews/usecase"
+)
+
+func (m *module) NewAccessReviewsRegistry() usecase.AccessReviewsService {
+	return usecase.NewAccessReviewsService(
+		m.NewRepositoryRegistry(),
+		m.NewCacheRegistry(),
+	)
+}
+
+func (m *module) NewAccessReviewsController() controller.AccessReviewsController {
+	return controller.NewAccessReviewsController(m.NewAccessReviewsRegistry())
+}
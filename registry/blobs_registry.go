@@ -0,0 +1,65 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/adapter/controller"
+	"github/yogabagas/join-app/config"
+	"github/yogabagas/join-app/domain/repository/blob"
+	"github/yogabagas/join-app/service/blobs/usecase"
+)
+
+// newBlobStore opens the store picked by the storage config, the local one
+// when no driver is set.
+func newBlobStore(cfg *config.Config) (blob.BlobStore, error) {
+
+	storage := cfg.Storage
+
+	switch storage.Driver {
+	case "", "local":
+		secret := storage.SigningSecret
+		if secret == "" {
+			secret = cfg.App.JWTSecret
+		}
+
+		if secret == "" {
+			return nil, errors.New("storage.signing_secret is required by the local store")
+		}
+
+		dir := storage.Local.Dir
+		if dir == "" {
+			dir = "storage"
+		}
+
+		baseURL := storage.Local.BaseURL
+		if baseURL == "" {
+			baseURL = cfg.App.Host + cfg.App.Port + "/v1/blobs"
+		}
+
+		return blob.NewLocalStore(dir, blob.NewURLSigner(baseURL, secret)), nil
+	case "s3":
+		return blob.NewS3Store(blob.S3Option{
+			Endpoint:       storage.S3.Endpoint,
+			PublicEndpoint: storage.S3.PublicEndpoint,
+			Region:         storage.S3.Region,
+			Bucket:         storage.S3.Bucket,
+			AccessKey:      storage.S3.AccessKey,
+			SecretKey:      storage.S3.SecretKey,
+			PathStyle:      storage.S3.PathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", storage.Driver)
+	}
+}
+
+func (m *module) NewBlobStore() blob.BlobStore {
+	return m.blob
+}
+
+func (m *module) NewBlobsRegistry() usecase.BlobsService {
+	return usecase.NewBlobsService(m.NewBlobStore())
+}
+
+func (m *module) NewBlobsController() controller.BlobsController {
+	return controller.NewBlobsController(m.NewBlobsRegistry())
+}
@@ -0,0 +1,17 @@
+package registry
+
+import (
+	"github/yogabagas/join-app/adapter/controller"
+	"github/yogabagas/join-app/service/elevations/usecase"
+)
+
+func (m *module) NewElevationsRegistry() usecase.ElevationsService {
+	return usecase.NewElevationsService(
+		m.NewRepositoryRegistry(),
+		m.NewCacheRegistry(),
+	)
+}
+
+func (m *module) NewElevationsController() controller.ElevationsController {
+	return controller.NewElevationsController(m.NewElevationsRegistry())
+}
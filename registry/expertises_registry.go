@@ -0,0 +1,14 @@
+package registry
+
+import (
+	"github/yogabagas/join-app/adapter/controller"
+	"github/yogabagas/join-app/service/expertises/usecase"
+)
+
+func (m *module) NewExpertisesRegistry() usecase.ExpertisesService {
+	return usecase.NewExpertisesService(m.NewRepositoryRegistry())
+}
+
+func (m *module) NewExpertisesController() controller.ExpertisesController {
+	return controller.NewExpertisesController(m.NewExpertisesRegistry())
+}
@@ -0,0 +1,14 @@
+package registry
+
+import (
+	"github/yogabagas/join-app/adapter/controller"
+	"github/yogabagas/join-app/service/mentors/usecase"
+)
+
+func (m *module) NewMentorsRegistry() usecase.MentorsService {
+	return usecase.NewMentorsService(m.NewRepositoryRegistry(), m.NewBlobStore())
+}
+
+func (m *module) NewMentorsController() controller.MentorsController {
+	return controller.NewMentorsController(m.NewMentorsRegistry())
+}
@@ -0,0 +1,16 @@
+package registry
+
+import (
+	"github/yogabagas/join-app/adapter/controller"
+	"github/yogabagas/join-app/service/notifications/usecase"
+)
+
+func (m *module) NewNotificationsRegistry() usecase.NotificationsService {
+	return usecase.NewNotificationsService(
+		m.NewRepositoryRegistry(),
+	)
+}
+
+func (m *module) NewNotificationsController() controller.NotificationsController {
+	return controller.NewNotificationsController(m.NewNotificationsRegistry())
+}
@@ -0,0 +1,14 @@
+package registry
+
+import (
+	"github/yogabagas/join-app/adapter/controller"
+	"github/yogabagas/join-app/service/organizations/usecase"
+)
+
+func (m *module) NewOrganizationsRegistry() usecase.OrganizationsService {
+	return usecase.NewOrganizationsService(m.NewRepositoryRegistry())
+}
+
+func (m *module) NewOrganizationsController() controller.OrganizationsController {
+	return controller.NewOrganizationsController(m.NewOrganizationsRegistry())
+}
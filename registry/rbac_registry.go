@@ -0,0 +1,18 @@
+package registry
+
+import (
+	"github/yogabagas/join-app/adapter/controller"
+	"github/yogabagas/join-app/service/rbac/usecase"
+)
+
+func (m *module) NewRBACRegistry() usecase.RBACService {
+	return usecase.NewRBACService(
+		m.NewRepositoryRegistry(),
+		m.NewCacheRegistry(),
+		m.NewLocalCacheRegistry(),
+	)
+}
+
+func (m *module) NewRBACController() controller.RBACController {
+	return controller.NewRBACController(m.NewRBACRegistry())
+}
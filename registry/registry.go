@@ -1,10 +1,16 @@
 package registry
 
 import (
+	"context"
 	"database/sql"
 	"github/yogabagas/join-app/adapter/controller"
+	"github/yogabagas/join-app/config"
+	"github/yogabagas/join-app/domain/repository/blob"
 	"github/yogabagas/join-app/domain/repository/cache"
 	repo "github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	"log"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 )
@@ -12,11 +18,17 @@ import (
 type module struct {
 	sqlDB *sql.DB
 	cache *redis.Client
+	local *cache.LocalCache
+	blob  blob.BlobStore
 	ns    string
 }
 
 type Registry interface {
 	NewAppController() controller.AppController
+	ListenCacheInvalidation(ctx context.Context)
+	SweepExpiredAuthz(ctx context.Context)
+	CloseOverdueAccessReviews(ctx context.Context)
+	ProcessDataRequests(ctx context.Context)
 }
 
 type Option func(*module)
@@ -28,6 +40,19 @@ func NewRegistry(opts ...Option) Registry {
 		o(m)
 	}
 
+	if config.GlobalCfg != nil {
+		m.local = cache.NewLocalCache(config.GlobalCfg.Cache.LocalSize,
+			time.Duration(config.GlobalCfg.Cache.LocalTTL)*time.Second)
+
+		store, err := newBlobStore(config.GlobalCfg)
+		if err != nil {
+			log.Fatalln("can't open blob store", err)
+		}
+		m.blob = store
+	} else {
+		m.local = cache.NewLocalCache(0, 0)
+	}
+
 	return m
 }
 
@@ -51,13 +76,136 @@ func (m *module) NewCacheRegistry() cache.Cache {
 	return cache.NewCacheRepository(m.cache, m.ns)
 }
 
+func (m *module) NewLocalCacheRegistry() *cache.LocalCache {
+	return m.local
+}
+
+// ListenCacheInvalidation keeps the local cache layer in sync with the
+// invalidations broadcast by every instance, until ctx is done.
+func (m *module) ListenCacheInvalidation(ctx context.Context) {
+	for {
+		err := m.local.Listen(ctx, m.NewCacheRegistry())
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Println("error listen cache invalidation, retrying", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// forEachOrganization runs fn for every organization, its context scoped to
+// the organization, then again every interval until ctx is done.
+func (m *module) forEachOrganization(ctx context.Context, interval time.Duration, fn func(ctx context.Context, org service.OrganizationResp)) {
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	organizationsSvc := m.NewOrganizationsRegistry()
+
+	for {
+		orgs, err := organizationsSvc.GetOrganizations(ctx)
+		if err != nil {
+			log.Println("error read organizations", err)
+		}
+
+		for _, org := range orgs {
+			fn(repo.WithTenant(ctx, org.UID), org)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweepInterval is authorization.sweep_interval, a minute by default.
+func sweepInterval() time.Duration {
+	if config.GlobalCfg != nil && config.GlobalCfg.Authorization.SweepInterval > 0 {
+		return time.Duration(config.GlobalCfg.Authorization.SweepInterval) * time.Second
+	}
+	return time.Minute
+}
+
+// SweepExpiredAuthz revokes grants whose validity window has closed in every
+// organization, every authorization.sweep_interval seconds, until ctx is done.
+func (m *module) SweepExpiredAuthz(ctx context.Context) {
+
+	authzSvc := m.NewAuthzRegistry()
+
+	m.forEachOrganization(ctx, sweepInterval(), func(ctx context.Context, org service.OrganizationResp) {
+		resp, err := authzSvc.SweepExpiredAuthz(ctx)
+		if err != nil {
+			log.Println("error sweep expired authz of", org.Slug, err)
+		} else if resp.Revoked > 0 {
+			log.Printf("expired authz of %s swept: %d grants of %d users revoked", org.Slug, resp.Revoked, len(resp.UserUIDs))
+		}
+	})
+}
+
+// CloseOverdueAccessReviews closes the access reviews past their deadline in
+// every organization, revoking the grants nobody reviewed, every
+// authorization.sweep_interval seconds, until ctx is done.
+func (m *module) CloseOverdueAccessReviews(ctx context.Context) {
+
+	accessReviewsSvc := m.NewAccessReviewsRegistry()
+
+	m.forEachOrganization(ctx, sweepInterval(), func(ctx context.Context, org service.OrganizationResp) {
+		resp, err := accessReviewsSvc.CloseOverdueAccessReviews(ctx)
+		if err != nil {
+			log.Println("error close overdue access reviews of", org.Slug, err)
+		} else if resp.Closed > 0 {
+			log.Printf("overdue access reviews of %s closed: %d reviews, %d unreviewed grants revoked", org.Slug, resp.Closed, resp.Revoked)
+		}
+	})
+}
+
+// ProcessDataRequests compiles the pending personal data exports of every
+// organization and drops the archives past their retention, every
+// privacy.job_interval seconds, until ctx is done.
+func (m *module) ProcessDataRequests(ctx context.Context) {
+
+	interval := 30 * time.Second
+	if config.GlobalCfg != nil && config.GlobalCfg.Privacy.JobInterval > 0 {
+		interval = time.Duration(config.GlobalCfg.Privacy.JobInterval) * time.Second
+	}
+
+	usersSvc := m.NewUsersRegistry()
+
+	m.forEachOrganization(ctx, interval, func(ctx context.Context, org service.OrganizationResp) {
+		resp, err := usersSvc.ProcessDataRequests(ctx)
+		if err != nil {
+			log.Println("error process data requests of", org.Slug, err)
+		} else if resp.Completed+resp.Failed+resp.Expired > 0 {
+			log.Printf("data requests of %s processed: %d exports completed, %d failed, %d expired",
+				org.Slug, resp.Completed, resp.Failed, resp.Expired)
+		}
+	})
+}
+
 func (m *module) NewAppController() controller.AppController {
 	return controller.AppController{
-		AccessController:    m.NewAccessController(),
-		AuthzController:     m.NewAuthzController(),
-		JWKController:       m.NewJWKController(),
-		ResourcesController: m.NewResourcesController(),
-		RolesController:     m.NewRolesController(),
-		UsersController:     m.NewUsersController(),
+		AccessController:          m.NewAccessController(),
+		AccessReviewsController:   m.NewAccessReviewsController(),
+		AuthzController:           m.NewAuthzController(),
+		BlobsController:           m.NewBlobsController(),
+		ElevationsController:      m.NewElevationsController(),
+		ExpertisesController:      m.NewExpertisesController(),
+		JWKController:             m.NewJWKController(),
+		MentorsController:         m.NewMentorsController(),
+		NotificationsController:   m.NewNotificationsController(),
+		OrganizationsController:   m.NewOrganizationsController(),
+		ResourcesController:       m.NewResourcesController(),
+		RoleConstraintsController: m.NewRoleConstraintsController(),
+		RolesController:           m.NewRolesController(),
+		RBACController:            m.NewRBACController(),
+		UsersController:           m.NewUsersController(),
 	}
 }
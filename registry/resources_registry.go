@@ -13,6 +13,7 @@ func (m *module) NewResourcesPresenter() presenter.ResourcesPresenter {
 func (m *module) NewResourcesRegistry() usecase.ResourcesService {
 	return usecase.NewResourcesService(
 		m.NewCacheRegistry(),
+		m.NewLocalCacheRegistry(),
 		m.NewRepositoryRegistry(),
 		m.NewResourcesPresenter())
 }
@@ -0,0 +1,14 @@
+package registry
+
+import (
+	"github/yogabagas/join-app/adapter/controller"
+	"github/yogabagas/join-app/service/roleConstraints/usecase"
+)
+
+func (m *module) NewRoleConstraintsRegistry() usecase.RoleConstraintsService {
+	return usecase.NewRoleConstraintsService(m.NewRepositoryRegistry(), m.NewCacheRegistry())
+}
+
+func (m *module) NewRoleConstraintsController() controller.RoleConstraintsController {
+	return controller.NewRoleConstraintsController(m.NewRoleConstraintsRegistry())
+}
@@ -14,6 +14,7 @@ func (m *module) NewUsersRegistry() usecase.UsersService {
 	return usecase.NewUsersService(
 		m.NewRepositoryRegistry(),
 		m.NewCacheRegistry(),
+		m.NewBlobStore(),
 		m.NewUsersPresenter())
 }
 
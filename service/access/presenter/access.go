@@ -10,6 +10,9 @@ type AccessPresenterImpl struct{}
 
 type AccessPresenter interface {
 	GetAccessByRoleUID(ctx context.Context, req []*model.ReadAccessByRoleUIDResp) ([]service.GetAccessByRoleUIDResp, error)
+	GetAccessHistory(ctx context.Context, histories []*model.AccessHistory, resources []*model.Resource) ([]service.AccessHistoryResp, error)
+	DiffAccessHistory(ctx context.Context, req service.DiffAccessHistoryReq, added, removed, changed []model.AccessSnapshotItem,
+		resources []*model.Resource) (service.DiffAccessHistoryResp, error)
 }
 
 func NewAccessPresenter() AccessPresenter {
@@ -56,3 +59,69 @@ func (ap *AccessPresenterImpl) GetAccessByRoleUID(ctx context.Context, req []*mo
 	}
 	return
 }
+
+func (ap *AccessPresenterImpl) GetAccessHistory(ctx context.Context, histories []*model.AccessHistory, resources []*model.Resource) (resp []service.AccessHistoryResp, err error) {
+
+	byUID := resourcesByUID(resources)
+
+	resp = []service.AccessHistoryResp{}
+
+	for _, v := range histories {
+		resp = append(resp, service.AccessHistoryResp{
+			Version:   v.Version,
+			Note:      v.Note,
+			Total:     len(v.Snapshot),
+			Added:     historyItems(v.Added, byUID),
+			Removed:   historyItems(v.Removed, byUID),
+			Changed:   historyItems(v.Changed, byUID),
+			CreatedBy: v.CreatedBy,
+			CreatedAt: v.CreatedAt,
+		})
+	}
+
+	return
+}
+
+func (ap *AccessPresenterImpl) DiffAccessHistory(ctx context.Context, req service.DiffAccessHistoryReq, added, removed, changed []model.AccessSnapshotItem,
+	resources []*model.Resource) (resp service.DiffAccessHistoryResp, err error) {
+
+	byUID := resourcesByUID(resources)
+
+	return service.DiffAccessHistoryResp{
+		From:    req.From,
+		To:      req.To,
+		Added:   historyItems(added, byUID),
+		Removed: historyItems(removed, byUID),
+		Changed: historyItems(changed, byUID),
+	}, nil
+}
+
+func resourcesByUID(resources []*model.Resource) map[string]*model.Resource {
+	byUID := make(map[string]*model.Resource, len(resources))
+	for _, v := range resources {
+		byUID[v.UID] = v
+	}
+	return byUID
+}
+
+func historyItems(items []model.AccessSnapshotItem, byUID map[string]*model.Resource) []service.AccessHistoryItem {
+
+	resp := make([]service.AccessHistoryItem, 0, len(items))
+
+	for _, v := range items {
+		item := service.AccessHistoryItem{
+			ResourceUID: v.ResourceUID,
+			Condition:   v.Condition,
+		}
+
+		if resource, ok := byUID[v.ResourceUID]; ok {
+			item.Name = resource.Name
+			item.Type = resource.Type
+			item.Action = resource.Action
+		}
+
+		resp = append(resp, item)
+	}
+
+	return resp
+}
@@ -8,4 +8,8 @@ import (
 type AccessRepository interface {
 	UpsertAccess(ctx context.Context, req []*model.Access) error
 	ReadAccessByRoleUID(ctx context.Context, req *model.ReadAccessByRoleUIDReq) ([]*model.ReadAccessByRoleUIDResp, error)
+	ReadAccessByResource(ctx context.Context, req *model.ReadAccessByResourceReq) ([]*model.ReadAccessByResourceResp, error)
+	ReadAccessByRoleUIDs(ctx context.Context, req *model.ReadAccessByRoleUIDsReq) ([]*model.Access, error)
+	DeleteAccessByRoleUID(ctx context.Context, req *model.DeleteAccessByRoleUIDReq) error
+	ReadAccessByRolesAndResources(ctx context.Context, req *model.ReadAccessByRolesAndResourcesReq) ([]*model.ReadAccessByResourceResp, error)
 }
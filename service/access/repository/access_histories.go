@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+)
+
+type AccessHistoriesRepository interface {
+	CreateAccessHistory(ctx context.Context, req *model.AccessHistory) error
+	ReadLatestAccessVersion(ctx context.Context, req *model.ReadLatestAccessVersionReq) (int, error)
+	ReadAccessHistoriesByRoleUID(ctx context.Context, req *model.ReadAccessHistoriesByRoleUIDReq) ([]*model.AccessHistory, error)
+	ReadAccessHistoryByVersion(ctx context.Context, req *model.ReadAccessHistoryByVersionReq) (*model.AccessHistory, error)
+}
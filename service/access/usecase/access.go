@@ -3,35 +3,48 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"github/yogabagas/join-app/config"
 	"github/yogabagas/join-app/domain/model"
 	"github/yogabagas/join-app/domain/repository/cache"
 	"github/yogabagas/join-app/domain/repository/sql"
 	"github/yogabagas/join-app/domain/service"
 	"github/yogabagas/join-app/service/access/presenter"
+	rolesUsecase "github/yogabagas/join-app/service/roles/usecase"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/shared/policy"
 	"github/yogabagas/join-app/shared/util"
+	"log"
+	"time"
 )
 
 type AccessServiceImpl struct {
 	repo      sql.RepositoryRegistry
 	cache     cache.Cache
+	local     *cache.LocalCache
 	presenter presenter.AccessPresenter
 }
 
 type AccessService interface {
 	UpsertAccess(ctx context.Context, req service.UpsertAccessReq) error
 	GetAccessByRoleUID(ctx context.Context, req service.GetAccessByRoleUIDReq) ([]service.GetAccessByRoleUIDResp, error)
+	GetAccessHistory(ctx context.Context, req service.GetAccessHistoryReq) ([]service.AccessHistoryResp, error)
+	DiffAccessHistory(ctx context.Context, req service.DiffAccessHistoryReq) (service.DiffAccessHistoryResp, error)
+	RollbackAccess(ctx context.Context, req service.RollbackAccessReq) error
 }
 
-func NewAccessService(repository sql.RepositoryRegistry, cache cache.Cache, presenter presenter.AccessPresenter) AccessService {
+func NewAccessService(repository sql.RepositoryRegistry, cache cache.Cache, local *cache.LocalCache, presenter presenter.AccessPresenter) AccessService {
 	return &AccessServiceImpl{
 		repo:      repository,
 		cache:     cache,
+		local:     local,
 		presenter: presenter}
 }
 
 func (as *AccessServiceImpl) UpsertAccess(ctx context.Context, req service.UpsertAccessReq) error {
 
-	accessRepo := as.repo.AccessRepository()
+	if err := validateConditions(req); err != nil {
+		return err
+	}
 
 	accessReqs := []*model.Access{}
 
@@ -42,6 +55,7 @@ func (as *AccessServiceImpl) UpsertAccess(ctx context.Context, req service.Upser
 				UID:         util.NewULIDGenerate(),
 				RoleUID:     req.RoleUID,
 				ResourceUID: resourceID,
+				Condition:   req.Conditions[resourceID],
 				CreatedBy:   req.CreatedBy,
 				UpdatedBy:   req.UpdatedBy,
 			}
@@ -49,34 +63,291 @@ func (as *AccessServiceImpl) UpsertAccess(ctx context.Context, req service.Upser
 		}
 	}
 
-	return accessRepo.UpsertAccess(ctx, accessReqs)
+	if len(accessReqs) == 0 {
+		return nil
+	}
+
+	if err := as.checkDelegation(ctx, req.ActorRoleUID, req.RoleUID, req.ResourceUID); err != nil {
+		return err
+	}
+
+	var InTransaction = func(rr sql.RepositoryRegistry) (out interface{}, err error) {
+		return nil, ReplaceAccess(ctx, rr, req.RoleUID, accessReqs, "", req.UpdatedBy)
+	}
+
+	if _, err := as.repo.DoInTransaction(ctx, InTransaction); err != nil {
+		return err
+	}
+
+	return cache.Invalidate(ctx, as.cache, as.local, fmt.Sprintf(constant.RoleMenuPattern.String(), req.RoleUID))
 }
 
 func (as *AccessServiceImpl) GetAccessByRoleUID(ctx context.Context, req service.GetAccessByRoleUIDReq) (resp []service.GetAccessByRoleUIDResp, err error) {
 
 	accessRepo := as.repo.AccessRepository()
 
-	keyCache := fmt.Sprintf("resources::role-uid:%s:type:%d", req.RoleUID, req.Type)
-	err = as.cache.GetObject(ctx, keyCache, &resp)
-	if err == nil {
-		return
+	keyCache := fmt.Sprintf(constant.RoleMenu.String(), req.RoleUID, req.Type)
+
+	if v, ok := as.local.Get(keyCache); ok {
+		return as.presenter.GetAccessByRoleUID(ctx, filterByCondition(req, v.([]*model.ReadAccessByRoleUIDResp)))
+	}
+
+	var res []*model.ReadAccessByRoleUIDResp
+
+	err = as.cache.GetObject(ctx, keyCache, &res)
+	if err != nil {
+		res, err = accessRepo.ReadAccessByRoleUID(ctx, &model.ReadAccessByRoleUIDReq{
+			RoleUID: req.RoleUID,
+			Type:    req.Type,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err = as.cache.Set(ctx, keyCache, res, config.GlobalCfg.Cache.TTL); err != nil {
+			log.Println("error set cache access", err)
+		}
 	}
 
-	res, err := accessRepo.ReadAccessByRoleUID(ctx, &model.ReadAccessByRoleUIDReq{
+	as.local.Set(keyCache, res)
+
+	return as.presenter.GetAccessByRoleUID(ctx, filterByCondition(req, res))
+}
+
+func (as *AccessServiceImpl) GetAccessHistory(ctx context.Context, req service.GetAccessHistoryReq) ([]service.AccessHistoryResp, error) {
+
+	historiesRepo := as.repo.AccessHistoriesRepository()
+	resourcesRepo := as.repo.ResourcesRepository()
+
+	histories, err := historiesRepo.ReadAccessHistoriesByRoleUID(ctx, &model.ReadAccessHistoriesByRoleUIDReq{
 		RoleUID: req.RoleUID,
-		Type:    req.Type,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err = as.presenter.GetAccessByRoleUID(ctx, res)
+	resources, err := resourcesRepo.ReadResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return as.presenter.GetAccessHistory(ctx, histories, resources)
+}
+
+func (as *AccessServiceImpl) DiffAccessHistory(ctx context.Context, req service.DiffAccessHistoryReq) (resp service.DiffAccessHistoryResp, err error) {
+
+	resourcesRepo := as.repo.ResourcesRepository()
+
+	from, err := as.readSnapshot(ctx, req.RoleUID, req.From)
+	if err != nil {
+		return resp, err
+	}
+
+	to, err := as.readSnapshot(ctx, req.RoleUID, req.To)
 	if err != nil {
 		return resp, err
 	}
 
-	go as.cache.Set(ctx, keyCache, resp, 0)
+	resources, err := resourcesRepo.ReadResources(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	added, removed, changed := model.DiffAccess(from, to)
+
+	return as.presenter.DiffAccessHistory(ctx, req, added, removed, changed, resources)
+}
+
+func (as *AccessServiceImpl) RollbackAccess(ctx context.Context, req service.RollbackAccessReq) error {
+
+	// Version 0 stands for no grant at all, not for the grants the role held
+	// before its history started, so restoring it would wipe them.
+	if req.Version < 1 {
+		return fmt.Errorf("version %d can't be restored, the recorded versions start at 1", req.Version)
+	}
+
+	snapshot, err := as.readSnapshot(ctx, req.RoleUID, req.Version)
+	if err != nil {
+		return err
+	}
+
+	resourceUIDs := make([]string, 0, len(snapshot))
+	for _, v := range snapshot {
+		resourceUIDs = append(resourceUIDs, v.ResourceUID)
+	}
+
+	if err = as.checkDelegation(ctx, req.ActorRoleUID, req.RoleUID, resourceUIDs); err != nil {
+		return err
+	}
+
+	access := make([]*model.Access, 0, len(snapshot))
+	for _, v := range snapshot {
+		access = append(access, &model.Access{
+			UID:         util.NewULIDGenerate(),
+			RoleUID:     req.RoleUID,
+			ResourceUID: v.ResourceUID,
+			Condition:   v.Condition,
+			CreatedBy:   req.UpdatedBy,
+			UpdatedBy:   req.UpdatedBy,
+		})
+	}
+
+	var InTransaction = func(rr sql.RepositoryRegistry) (out interface{}, err error) {
+		return nil, ReplaceAccess(ctx, rr, req.RoleUID, access, rollbackNote(req.Version), req.UpdatedBy)
+	}
+
+	if _, err = as.repo.DoInTransaction(ctx, InTransaction); err != nil {
+		return err
+	}
+
+	return cache.Invalidate(ctx, as.cache, as.local, fmt.Sprintf(constant.RoleMenuPattern.String(), req.RoleUID))
+}
+
+// checkDelegation makes sure the role and every resource belong to the
+// organization of ctx, the foreign keys alone would accept another tenant's,
+// then that the actor's role sits above the role and itself holds every
+// resource it is about to grant.
+func (as *AccessServiceImpl) checkDelegation(ctx context.Context, actorRoleUID, roleUID string, resourceUIDs []string) error {
+
+	roles, err := as.repo.RolesRepository().ReadRoles(ctx)
+	if err != nil {
+		return err
+	}
+
+	hierarchy := rolesUsecase.NewHierarchy(roles)
+
+	if _, ok := hierarchy[roleUID]; !ok {
+		return fmt.Errorf("role %s is not found", roleUID)
+	}
+
+	if !hierarchy.Manages(actorRoleUID, roleUID) {
+		return fmt.Errorf("%w: role %s can only manage the roles beneath it", service.ErrForbidden, actorRoleUID)
+	}
+
+	resources, err := as.repo.ResourcesRepository().ReadResources(ctx)
+	if err != nil {
+		return err
+	}
+
+	owned := make(map[string]bool, len(resources))
+	for _, v := range resources {
+		owned[v.UID] = true
+	}
+
+	for _, v := range resourceUIDs {
+		if !owned[v] {
+			return fmt.Errorf("resource %s is not found", v)
+		}
+	}
+
+	held, err := as.repo.AccessRepository().ReadAccessByRoleUIDs(ctx, &model.ReadAccessByRoleUIDsReq{
+		RoleUIDs: []string{actorRoleUID},
+	})
+	if err != nil {
+		return err
+	}
+
+	holds := make(map[string]bool, len(held))
+	for _, v := range held {
+		holds[v.ResourceUID] = true
+	}
+
+	for _, v := range resourceUIDs {
+		if !holds[v] {
+			return fmt.Errorf("%w: resource %s can't be granted by a role that does not hold it", service.ErrForbidden, v)
+		}
+	}
+
+	return nil
+}
+
+// readSnapshot returns the grants of a role as of version, version 0 being the
+// empty set before any recorded change.
+func (as *AccessServiceImpl) readSnapshot(ctx context.Context, roleUID string, version int) ([]model.AccessSnapshotItem, error) {
+
+	if version == 0 {
+		return nil, nil
+	}
+
+	history, err := as.repo.AccessHistoriesRepository().ReadAccessHistoryByVersion(ctx, &model.ReadAccessHistoryByVersionReq{
+		RoleUID: roleUID,
+		Version: version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if history == nil {
+		return nil, fmt.Errorf("access history version %d of role %s is not found", version, roleUID)
+	}
+
+	return history.Snapshot, nil
+}
+
+// filterByCondition drops every resource whose access condition does not hold
+// for the caller right now, together with its descendants.
+func filterByCondition(req service.GetAccessByRoleUIDReq, rows []*model.ReadAccessByRoleUIDResp) []*model.ReadAccessByRoleUIDResp {
+
+	var (
+		allowed  []*model.ReadAccessByRoleUIDResp
+		excluded = make(map[string]bool)
+		timeCtx  = policy.TimeContext(time.Now())
+	)
+
+	for _, v := range rows {
+		if v.ParentUID.Valid && excluded[v.ParentUID.String] {
+			excluded[v.UID] = true
+			continue
+		}
+
+		ok, err := policy.Evaluate(v.Condition.String, policy.Env{
+			"subject": map[string]interface{}{
+				"sub":      req.UserUID,
+				"role_uid": req.RoleUID,
+			},
+			"resource": map[string]interface{}{
+				"uid":    v.UID,
+				"name":   v.Name,
+				"type":   v.Type,
+				"action": v.Action,
+			},
+			"context": timeCtx,
+		})
+		if err != nil {
+			log.Println("error evaluate access condition", v.UID, err)
+		}
+
+		if !ok {
+			excluded[v.UID] = true
+			continue
+		}
+
+		allowed = append(allowed, v)
+	}
+
+	return allowed
+}
+
+func validateConditions(req service.UpsertAccessReq) error {
 
-	return resp, nil
+	granted := make(map[string]bool, len(req.ResourceUID))
+	for _, uid := range req.ResourceUID {
+		granted[uid] = true
+	}
+
+	for resourceUID, condition := range req.Conditions {
+		if !granted[resourceUID] {
+			return fmt.Errorf("condition given for resource %s which is not granted", resourceUID)
+		}
+
+		if condition == "" {
+			continue
+		}
+
+		if _, err := policy.Compile(condition); err != nil {
+			return fmt.Errorf("invalid condition for resource %s: %w", resourceUID, err)
+		}
+	}
 
+	return nil
 }
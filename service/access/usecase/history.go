@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/shared/util"
+)
+
+// ReplaceAccess makes access the complete grant list of roleUID and records
+// the change as a new version of the role's access history. It must run
+// inside DoInTransaction so that the role stays locked until the version is
+// recorded.
+// Nothing is written when the grants do not change.
+func ReplaceAccess(ctx context.Context, rr sql.RepositoryRegistry, roleUID string, access []*model.Access, note, actor string) error {
+
+	accessRepo := rr.AccessRepository()
+	historiesRepo := rr.AccessHistoriesRepository()
+
+	// The role is locked before its grants are read, so concurrent changes
+	// diff against the grants the previous one left.
+	current, err := accessRepo.ReadAccessByRoleUIDs(ctx, &model.ReadAccessByRoleUIDsReq{
+		RoleUIDs:  []string{roleUID},
+		ForUpdate: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	before := make([]model.AccessSnapshotItem, 0, len(current))
+	for _, v := range current {
+		before = append(before, model.AccessSnapshotItem{ResourceUID: v.ResourceUID, Condition: v.Condition})
+	}
+
+	after := make([]model.AccessSnapshotItem, 0, len(access))
+	for _, v := range access {
+		after = append(after, model.AccessSnapshotItem{ResourceUID: v.ResourceUID, Condition: v.Condition})
+	}
+
+	added, removed, changed := model.DiffAccess(before, after)
+	if len(added) == 0 && len(removed) == 0 && len(changed) == 0 {
+		return nil
+	}
+
+	if len(access) == 0 {
+		err = accessRepo.DeleteAccessByRoleUID(ctx, &model.DeleteAccessByRoleUIDReq{
+			RoleUID:   roleUID,
+			UpdatedBy: actor,
+		})
+	} else {
+		err = accessRepo.UpsertAccess(ctx, access)
+	}
+	if err != nil {
+		return err
+	}
+
+	version, err := historiesRepo.ReadLatestAccessVersion(ctx, &model.ReadLatestAccessVersionReq{
+		RoleUID: roleUID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return historiesRepo.CreateAccessHistory(ctx, &model.AccessHistory{
+		UID:       util.NewULIDGenerate(),
+		RoleUID:   roleUID,
+		Version:   version + 1,
+		Snapshot:  after,
+		Added:     added,
+		Removed:   removed,
+		Changed:   changed,
+		Note:      note,
+		CreatedBy: actor,
+	})
+}
+
+func rollbackNote(version int) string {
+	return fmt.Sprintf("rollback to version %d", version)
+}
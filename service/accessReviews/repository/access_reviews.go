@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+)
+
+type AccessReviewsRepository interface {
+	CreateAccessReview(ctx context.Context, req *model.AccessReview) error
+	CreateAccessReviewItems(ctx context.Context, req []*model.AccessReviewItem) error
+	ReadAccessReviews(ctx context.Context, req *model.ReadAccessReviewsReq) ([]*model.AccessReview, error)
+	ReadAccessReviewByUID(ctx context.Context, req *model.ReadAccessReviewByUIDReq) (*model.AccessReview, error)
+	ReadOverdueAccessReviews(ctx context.Context) ([]*model.AccessReview, error)
+	ReadAccessReviewItems(ctx context.Context, req *model.ReadAccessReviewItemsReq) ([]*model.AccessReviewItem, error)
+	ReadAccessReviewItemByUID(ctx context.Context, req *model.ReadAccessReviewItemByUIDReq) (*model.AccessReviewItem, error)
+	DecideAccessReviewItem(ctx context.Context, req *model.DecideAccessReviewItemReq) error
+	CloseAccessReview(ctx context.Context, req *model.CloseAccessReviewReq) error
+}
@@ -0,0 +1,416 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/cache"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/shared/util"
+	"sort"
+	"strings"
+	"time"
+)
+
+const unreviewedNote = "not reviewed before the deadline"
+
+type AccessReviewsServiceImpl struct {
+	repo  sql.RepositoryRegistry
+	cache cache.Cache
+}
+
+type AccessReviewsService interface {
+	CreateAccessReview(ctx context.Context, req service.CreateAccessReviewReq) (service.AccessReviewResp, error)
+	GetAccessReviews(ctx context.Context, req service.GetAccessReviewsReq) ([]service.AccessReviewResp, error)
+	GetAccessReviewItems(ctx context.Context, req service.GetAccessReviewItemsReq) ([]service.AccessReviewItemResp, error)
+	DecideAccessReviewItem(ctx context.Context, req service.DecideAccessReviewItemReq) (service.AccessReviewItemResp, error)
+	ExportAccessReview(ctx context.Context, req service.ExportAccessReviewReq) (service.AccessReviewReportResp, error)
+	CloseOverdueAccessReviews(ctx context.Context) (service.CloseOverdueAccessReviewsResp, error)
+}
+
+func NewAccessReviewsService(repository sql.RepositoryRegistry, cache cache.Cache) AccessReviewsService {
+	return &AccessReviewsServiceImpl{
+		repo:  repository,
+		cache: cache,
+	}
+}
+
+// CreateAccessReview opens a campaign over every current and upcoming grant of
+// the organization. Nobody reviews their own grants: those go to the default
+// reviewer instead, or are escalated to the creator of the campaign when the
+// default reviewer holds them.
+func (as *AccessReviewsServiceImpl) CreateAccessReview(ctx context.Context, req service.CreateAccessReviewReq) (resp service.AccessReviewResp, err error) {
+
+	if strings.TrimSpace(req.Name) == "" {
+		return resp, errors.New("name is required")
+	}
+
+	if !req.Deadline.After(time.Now()) {
+		return resp, errors.New("deadline must be in the future")
+	}
+
+	if req.DefaultReviewerUID == "" {
+		return resp, errors.New("default_reviewer_uid is required")
+	}
+
+	grants, err := as.repo.AuthzRepository().ReadUnexpiredAuthz(ctx, &model.ReadUnexpiredAuthzReq{})
+	if err != nil {
+		return resp, err
+	}
+
+	members := make(map[string]bool, len(grants))
+	for _, v := range grants {
+		members[v.UserUID] = true
+	}
+
+	for _, v := range append([]string{req.DefaultReviewerUID}, reviewerUIDs(req.Reviewers)...) {
+		if !members[v] {
+			return resp, fmt.Errorf("reviewer %s holds no role in the organization", v)
+		}
+	}
+
+	review := &model.AccessReview{
+		UID:       util.NewULIDGenerate(),
+		Name:      strings.TrimSpace(req.Name),
+		Deadline:  req.Deadline.UTC(),
+		Status:    constant.ReviewOpen.String(),
+		CreatedBy: req.CreatedBy,
+		CreatedAt: time.Now(),
+		UpdatedBy: req.CreatedBy,
+	}
+
+	items := make([]*model.AccessReviewItem, 0, len(grants))
+	for _, v := range grants {
+		reviewer := reviewerFor(v, req, members)
+		if reviewer == "" {
+			return resp, fmt.Errorf("no reviewer other than %s is eligible for the grants of %s", v.UserUID, v.UserUID)
+		}
+
+		items = append(items, &model.AccessReviewItem{
+			UID:         util.NewULIDGenerate(),
+			ReviewUID:   review.UID,
+			AuthzUID:    v.UID,
+			UserUID:     v.UserUID,
+			RoleUID:     v.RoleUID,
+			ReviewerUID: reviewer,
+		})
+	}
+
+	_, err = as.repo.DoInTransaction(ctx, func(rr sql.RepositoryRegistry) (interface{}, error) {
+		if err := rr.AccessReviewsRepository().CreateAccessReview(ctx, review); err != nil {
+			return nil, err
+		}
+		return nil, rr.AccessReviewsRepository().CreateAccessReviewItems(ctx, items)
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	resp = accessReviewResp(review)
+	resp.Items = len(items)
+
+	return resp, nil
+}
+
+// reviewerFor picks the reviewer of grant: the reviewer of its role, else the
+// default reviewer, else the creator of the campaign, else any other reviewer
+// of the campaign, skipping its holder. It is empty when the holder is the
+// only one eligible.
+func reviewerFor(grant *model.Authz, req service.CreateAccessReviewReq, members map[string]bool) string {
+
+	candidates := []string{req.Reviewers[grant.RoleUID], req.DefaultReviewerUID}
+	if members[req.CreatedBy] {
+		candidates = append(candidates, req.CreatedBy)
+	}
+	candidates = append(candidates, reviewerUIDs(req.Reviewers)...)
+
+	for _, v := range candidates {
+		if v != "" && v != grant.UserUID {
+			return v
+		}
+	}
+
+	return ""
+}
+
+func (as *AccessReviewsServiceImpl) GetAccessReviews(ctx context.Context, req service.GetAccessReviewsReq) (resp []service.AccessReviewResp, err error) {
+
+	reviews, err := as.repo.AccessReviewsRepository().ReadAccessReviews(ctx, &model.ReadAccessReviewsReq{
+		Status: req.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp = []service.AccessReviewResp{}
+	for _, v := range reviews {
+		resp = append(resp, accessReviewResp(v))
+	}
+
+	return resp, nil
+}
+
+func (as *AccessReviewsServiceImpl) GetAccessReviewItems(ctx context.Context, req service.GetAccessReviewItemsReq) (resp []service.AccessReviewItemResp, err error) {
+
+	if _, err = as.review(ctx, req.ReviewUID); err != nil {
+		return nil, err
+	}
+
+	items, err := as.repo.AccessReviewsRepository().ReadAccessReviewItems(ctx, &model.ReadAccessReviewItemsReq{
+		ReviewUID:   req.ReviewUID,
+		ReviewerUID: req.ReviewerUID,
+		Pending:     req.Pending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp = []service.AccessReviewItemResp{}
+	for _, v := range items {
+		resp = append(resp, accessReviewItemResp(v))
+	}
+
+	return resp, nil
+}
+
+// DecideAccessReviewItem records the reviewer's decision. Revoking removes the
+// grant right away and drops the sessions of its holder.
+func (as *AccessReviewsServiceImpl) DecideAccessReviewItem(ctx context.Context, req service.DecideAccessReviewItemReq) (resp service.AccessReviewItemResp, err error) {
+
+	if req.Decision != constant.ReviewConfirmed.String() && req.Decision != constant.ReviewRevoked.String() {
+		return resp, fmt.Errorf("decision must be %s or %s", constant.ReviewConfirmed, constant.ReviewRevoked)
+	}
+
+	review, err := as.review(ctx, req.ReviewUID)
+	if err != nil {
+		return resp, err
+	}
+
+	if review.Status != constant.ReviewOpen.String() || !review.Deadline.After(time.Now()) {
+		return resp, fmt.Errorf("%w: access review %s is closed", service.ErrConflict, review.UID)
+	}
+
+	reviewsRepo := as.repo.AccessReviewsRepository()
+
+	item, err := reviewsRepo.ReadAccessReviewItemByUID(ctx, &model.ReadAccessReviewItemByUIDReq{
+		ReviewUID: req.ReviewUID,
+		UID:       req.ItemUID,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if item == nil {
+		return resp, fmt.Errorf("access review item %s is not found", req.ItemUID)
+	}
+
+	if item.ReviewerUID != req.ActorUID {
+		return resp, fmt.Errorf("%w: item %s is assigned to another reviewer", service.ErrForbidden, item.UID)
+	}
+
+	if item.Decision != constant.ReviewPending.String() {
+		return resp, fmt.Errorf("%w: item %s is already %s", service.ErrConflict, item.UID, item.Decision)
+	}
+
+	_, err = as.repo.DoInTransaction(ctx, func(rr sql.RepositoryRegistry) (interface{}, error) {
+		err := rr.AccessReviewsRepository().DecideAccessReviewItem(ctx, &model.DecideAccessReviewItemReq{
+			UID:       item.UID,
+			Decision:  req.Decision,
+			DecidedBy: req.ActorUID,
+			Note:      strings.TrimSpace(req.Note),
+		})
+		if err != nil || req.Decision != constant.ReviewRevoked.String() {
+			return nil, err
+		}
+
+		return nil, rr.AuthzRepository().DeleteAuthzByUIDs(ctx, &model.DeleteAuthzByUIDsReq{
+			UIDs:      []string{item.AuthzUID},
+			UpdatedBy: req.ActorUID,
+		})
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if req.Decision == constant.ReviewRevoked.String() {
+		if err = as.revokeAccess(ctx, item.UserUID); err != nil {
+			return resp, err
+		}
+	}
+
+	item, err = reviewsRepo.ReadAccessReviewItemByUID(ctx, &model.ReadAccessReviewItemByUIDReq{
+		ReviewUID: req.ReviewUID,
+		UID:       req.ItemUID,
+	})
+	if err != nil || item == nil {
+		return resp, err
+	}
+
+	return accessReviewItemResp(item), nil
+}
+
+func (as *AccessReviewsServiceImpl) ExportAccessReview(ctx context.Context, req service.ExportAccessReviewReq) (resp service.AccessReviewReportResp, err error) {
+
+	review, err := as.review(ctx, req.ReviewUID)
+	if err != nil {
+		return resp, err
+	}
+
+	items, err := as.GetAccessReviewItems(ctx, service.GetAccessReviewItemsReq{
+		ReviewUID: req.ReviewUID,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	return service.AccessReviewReportResp{
+		Review: accessReviewResp(review),
+		Items:  items,
+	}, nil
+}
+
+// CloseOverdueAccessReviews closes the campaigns whose deadline has passed,
+// revoking every grant nobody reviewed in time.
+func (as *AccessReviewsServiceImpl) CloseOverdueAccessReviews(ctx context.Context) (resp service.CloseOverdueAccessReviewsResp, err error) {
+
+	reviewsRepo := as.repo.AccessReviewsRepository()
+
+	reviews, err := reviewsRepo.ReadOverdueAccessReviews(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	users := make(map[string]bool)
+
+	for _, review := range reviews {
+		pending, err := reviewsRepo.ReadAccessReviewItems(ctx, &model.ReadAccessReviewItemsReq{
+			ReviewUID: review.UID,
+			Pending:   true,
+		})
+		if err != nil {
+			return resp, err
+		}
+
+		_, err = as.repo.DoInTransaction(ctx, func(rr sql.RepositoryRegistry) (interface{}, error) {
+			authzUIDs := make([]string, 0, len(pending))
+
+			for _, v := range pending {
+				err := rr.AccessReviewsRepository().DecideAccessReviewItem(ctx, &model.DecideAccessReviewItemReq{
+					UID:       v.UID,
+					Decision:  constant.ReviewRevoked.String(),
+					DecidedBy: constant.System,
+					Note:      unreviewedNote,
+				})
+				if err != nil {
+					return nil, err
+				}
+				authzUIDs = append(authzUIDs, v.AuthzUID)
+			}
+
+			err := rr.AuthzRepository().DeleteAuthzByUIDs(ctx, &model.DeleteAuthzByUIDsReq{
+				UIDs:      authzUIDs,
+				UpdatedBy: constant.System,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, rr.AccessReviewsRepository().CloseAccessReview(ctx, &model.CloseAccessReviewReq{
+				UID:       review.UID,
+				UpdatedBy: constant.System,
+			})
+		})
+		if err != nil {
+			return resp, err
+		}
+
+		resp.Closed++
+		resp.Revoked += len(pending)
+
+		for _, v := range pending {
+			if !users[v.UserUID] {
+				users[v.UserUID] = true
+				resp.UserUIDs = append(resp.UserUIDs, v.UserUID)
+			}
+		}
+	}
+
+	if err = as.revokeAccess(ctx, resp.UserUIDs...); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// revokeAccess drops the sessions and the principals of the users who lost a
+// grant, so they log in again with the roles they still hold.
+func (as *AccessReviewsServiceImpl) revokeAccess(ctx context.Context, userUIDs ...string) error {
+
+	orgUID, err := sql.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range userUIDs {
+		if err = cache.RevokeSessions(ctx, as.cache, v); err != nil {
+			return err
+		}
+	}
+
+	return cache.RevokePrincipals(ctx, as.cache, orgUID, userUIDs...)
+}
+
+func (as *AccessReviewsServiceImpl) review(ctx context.Context, uid string) (*model.AccessReview, error) {
+
+	review, err := as.repo.AccessReviewsRepository().ReadAccessReviewByUID(ctx, &model.ReadAccessReviewByUIDReq{
+		UID: uid,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if review == nil {
+		return nil, fmt.Errorf("access review %s is not found", uid)
+	}
+
+	return review, nil
+}
+
+func reviewerUIDs(reviewers map[string]string) (resp []string) {
+	for _, v := range reviewers {
+		resp = append(resp, v)
+	}
+	sort.Strings(resp)
+	return resp
+}
+
+func accessReviewResp(v *model.AccessReview) service.AccessReviewResp {
+	return service.AccessReviewResp{
+		UID:       v.UID,
+		Name:      v.Name,
+		Deadline:  v.Deadline,
+		Status:    v.Status,
+		ClosedAt:  v.ClosedAt,
+		CreatedBy: v.CreatedBy,
+		CreatedAt: v.CreatedAt,
+	}
+}
+
+func accessReviewItemResp(v *model.AccessReviewItem) service.AccessReviewItemResp {
+	return service.AccessReviewItemResp{
+		UID:         v.UID,
+		AuthzUID:    v.AuthzUID,
+		UserUID:     v.UserUID,
+		UserEmail:   v.UserEmail,
+		RoleUID:     v.RoleUID,
+		RoleName:    v.RoleName,
+		ReviewerUID: v.ReviewerUID,
+		Decision:    v.Decision,
+		DecidedBy:   v.DecidedBy,
+		DecidedAt:   v.DecidedAt,
+		Note:        v.Note,
+	}
+}
@@ -7,4 +7,8 @@ import (
 
 type AuthzRepository interface {
 	CreateAuthz(ctx context.Context, req *model.Authz) error
+	ReadAuthzByUserUID(ctx context.Context, req *model.ReadAuthzByUserUIDReq) ([]*model.ReadAuthzByUserUIDResp, error)
+	ReadExpiredAuthz(ctx context.Context) ([]*model.Authz, error)
+	ReadUnexpiredAuthz(ctx context.Context, req *model.ReadUnexpiredAuthzReq) ([]*model.Authz, error)
+	DeleteAuthzByUIDs(ctx context.Context, req *model.DeleteAuthzByUIDsReq) error
 }
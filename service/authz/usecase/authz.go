@@ -14,6 +14,10 @@ import (
 	"github/yogabagas/join-app/domain/repository/cache"
 	"github/yogabagas/join-app/domain/repository/sql"
 	"github/yogabagas/join-app/domain/service"
+	roleConstraintsUsecase "github/yogabagas/join-app/service/roleConstraints/usecase"
+	rolesUsecase "github/yogabagas/join-app/service/roles/usecase"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/shared/policy"
 	"github/yogabagas/join-app/shared/util"
 	"log"
 	"time"
@@ -30,7 +34,13 @@ type AuthzServiceImpl struct {
 type AuthzService interface {
 	Login(ctx context.Context, req service.LoginReq) (resp service.LoginResp, err error)
 	Logout(ctx context.Context, req service.LogoutReq) error
+	RefreshToken(ctx context.Context, req service.RefreshTokenReq) (resp service.LoginResp, err error)
 	HasAuthenticated(ctx context.Context, req service.HasAuthenticatedReq) (resp service.HasAuthenticatedResp, err error)
+	Authorize(ctx context.Context, req service.AuthorizeReq) (resp service.AuthorizeResp, err error)
+	CheckPermission(ctx context.Context, req service.CheckPermissionReq) (resp service.CheckPermissionResp, err error)
+	CheckPermissionBatch(ctx context.Context, req service.CheckPermissionBatchReq) (resp service.CheckPermissionBatchResp, err error)
+	GrantRole(ctx context.Context, req service.GrantRoleReq) (resp service.GrantRoleResp, err error)
+	SweepExpiredAuthz(ctx context.Context) (resp service.SweepExpiredAuthzResp, err error)
 }
 
 func NewAuthzService(repository sql.RepositoryRegistry, cache cache.Cache) AuthzService {
@@ -43,9 +53,13 @@ func NewAuthzService(repository sql.RepositoryRegistry, cache cache.Cache) Authz
 func (as *AuthzServiceImpl) Login(ctx context.Context, req service.LoginReq) (resp service.LoginResp, err error) {
 
 	usersRepo := as.repo.UsersRepository()
-	jwkRepo := as.repo.JWKRepository()
 	credentialsRepo := as.repo.UserCredentialsRepository()
 
+	orgUID, err := sql.TenantFromContext(ctx)
+	if err != nil {
+		return resp, err
+	}
+
 	pwd, err := util.Hash(config.GlobalCfg.PasswordAlg, req.Password)
 	if err != nil {
 		return resp, err
@@ -71,9 +85,83 @@ func (as *AuthzServiceImpl) Login(ctx context.Context, req service.LoginReq) (re
 	}
 
 	if !crd.Valid {
-		return resp, errors.New("wrong password")
+		return resp, fmt.Errorf("%w: wrong password", service.ErrUnauthorized)
+	}
+
+	if !crd.IsActive {
+		return resp, fmt.Errorf("%w: account is suspended", service.ErrForbidden)
+	}
+
+	if err = as.checkActiveSeparation(ctx, user.UserUID); err != nil {
+		return resp, err
+	}
+
+	return as.issueTokens(ctx, user, orgUID, util.NewULIDGenerate())
+}
+
+// RefreshToken issues new tokens for the session of a verified refresh
+// token, as long as the session lasts and the credential of its user is
+// active.
+func (as *AuthzServiceImpl) RefreshToken(ctx context.Context, req service.RefreshTokenReq) (resp service.LoginResp, err error) {
+
+	if req.SessionUID == "" {
+		return resp, errors.New("session is unknown, please re-authenticate")
+	}
+
+	ctx = sql.WithTenant(ctx, req.OrgUID)
+
+	if !as.cache.Exist(ctx, cache.SessionKey(req.UserUID, req.SessionUID)) {
+		return resp, errors.New("session has ended, please re-authenticate")
+	}
+
+	if active, err := as.credentialActive(ctx, req.UserUID); err != nil {
+		return resp, err
+	} else if !active {
+		return resp, fmt.Errorf("%w: account is suspended", service.ErrForbidden)
+	}
+
+	usersRepo := as.repo.UsersRepository()
+
+	account, err := usersRepo.ReadUserByUID(ctx, &model.ReadUserByUIDReq{UID: req.UserUID})
+	if err != nil {
+		return resp, err
+	} else if account == nil {
+		return resp, errors.New("user not found, please re-authenticate")
+	}
+
+	user, err := usersRepo.ReadUserByEmail(ctx, &model.ReadUserByEmailReq{Email: account.Email})
+	if err != nil {
+		return resp, err
+	}
+
+	if err = as.checkActiveSeparation(ctx, user.UserUID); err != nil {
+		return resp, err
+	}
+
+	return as.issueTokens(ctx, user, req.OrgUID, req.SessionUID)
+}
+
+// credentialActive tells whether the user holds an active credential, the
+// users deleted or without any credential holding none.
+func (as *AuthzServiceImpl) credentialActive(ctx context.Context, userUID string) (bool, error) {
+
+	credential, err := as.repo.UserCredentialsRepository().ReadCredentialByUserUID(ctx, &model.ReadCredentialByUserUIDReq{
+		UserUID: userUID,
+	})
+	if err != nil {
+		return false, err
 	}
 
+	return credential != nil && credential.IsActive, nil
+}
+
+// issueTokens signs the access and refresh tokens of the session sessionUID
+// of user, and starts the session or extends it to the lifetime of the new
+// refresh token.
+func (as *AuthzServiceImpl) issueTokens(ctx context.Context, user *model.ReadUserByEmailResp, orgUID, sessionUID string) (resp service.LoginResp, err error) {
+
+	jwkRepo := as.repo.JWKRepository()
+
 	key, err := jwkRepo.ReadUnexpiredKeyByID(ctx, &model.ReadUnexpiredKeyByIDReq{
 		KeyID: user.RoleName,
 	})
@@ -106,7 +194,9 @@ func (as *AuthzServiceImpl) Login(ctx context.Context, req service.LoginReq) (re
 	accessToken, err := as.generateAndSignAccessToken(ctx, &model.GenerateAccessTokenReq{
 		KeyID:      user.RoleName,
 		UserUID:    user.UserUID,
+		SessionUID: sessionUID,
 		RoleUID:    user.RoleUID,
+		OrgUID:     orgUID,
 		LastActive: user.LastActive.UTC().Unix(),
 		ExpiredAt:  config.GlobalCfg.TokenExpiration,
 		Signer:     signer,
@@ -116,19 +206,22 @@ func (as *AuthzServiceImpl) Login(ctx context.Context, req service.LoginReq) (re
 	}
 
 	refreshToken, err := as.generateAndSignRefreshToken(ctx, &model.GenerateRefreshTokenReq{
-		KeyID:     user.RoleName,
-		UserUID:   user.UserUID,
-		ExpiredAt: (config.GlobalCfg.TokenExpiration + config.GlobalCfg.RefreshTokenExpiration),
-		Signer:    signer,
+		KeyID:      user.RoleName,
+		UserUID:    user.UserUID,
+		SessionUID: sessionUID,
+		OrgUID:     orgUID,
+		ExpiredAt:  (config.GlobalCfg.TokenExpiration + config.GlobalCfg.RefreshTokenExpiration),
+		Signer:     signer,
 	})
 	if err != nil {
 		return resp, err
 	}
 
-	cacheKeyLogin := fmt.Sprintf("auth::user-uid:%s", user.UserUID)
-	tokenExp := time.Now().UTC().Add(time.Duration(config.GlobalCfg.TokenExpiration) * time.Second).Unix()
+	// The session lasts as long as its refresh token.
+	cacheKeyLogin := cache.SessionKey(user.UserUID, sessionUID)
+	sessionExp := config.GlobalCfg.TokenExpiration + config.GlobalCfg.RefreshTokenExpiration
 
-	err = as.cache.Set(ctx, cacheKeyLogin, true, int(tokenExp))
+	err = as.cache.Set(ctx, cacheKeyLogin, true, sessionExp)
 	if err != nil {
 		log.Fatalln("error set cache auth", err)
 	}
@@ -141,15 +234,27 @@ func (as *AuthzServiceImpl) Login(ctx context.Context, req service.LoginReq) (re
 
 func (as *AuthzServiceImpl) Logout(ctx context.Context, req service.LogoutReq) error {
 
-	key := fmt.Sprintf("auth::user-uid:%s", req.UserUID)
-
-	return as.cache.Delete(ctx, key)
+	return as.cache.Delete(ctx, cache.SessionKey(req.UserUID, req.SessionUID))
 }
 
 func (as *AuthzServiceImpl) HasAuthenticated(ctx context.Context, req service.HasAuthenticatedReq) (resp service.HasAuthenticatedResp, err error) {
 
-	cacheKeyLogin := fmt.Sprintf("auth::user-uid:%s", req.Sub)
-	if !as.cache.Exist(ctx, cacheKeyLogin) {
+	if !as.cache.Exist(ctx, cache.SessionKey(req.Sub, req.SessionUID)) {
+		return resp, nil
+	}
+
+	p, err := as.principalOf(ctx, req.Sub)
+	if err != nil || !p.Active {
+		return resp, err
+	}
+
+	// A grant reaching its valid_from during the session may break a
+	// separation of duties, which login alone wouldn't catch.
+	if err = p.separationError(); err != nil {
+		return resp, err
+	}
+
+	if req.RoleUID != "" && !p.holds(req.RoleUID) {
 		return resp, nil
 	}
 
@@ -158,11 +263,524 @@ func (as *AuthzServiceImpl) HasAuthenticated(ctx context.Context, req service.Ha
 	}, nil
 }
 
+func (as *AuthzServiceImpl) GrantRole(ctx context.Context, req service.GrantRoleReq) (resp service.GrantRoleResp, err error) {
+
+	if req.UserUID == "" || req.RoleUID == "" {
+		return resp, errors.New("user_uid and role_uid are required")
+	}
+
+	roles, err := as.repo.RolesRepository().ReadRoles(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	if !rolesUsecase.NewHierarchy(roles).Manages(req.ActorRoleUID, req.RoleUID) {
+		return resp, fmt.Errorf("%w: role %s can only assign the roles beneath it", service.ErrForbidden, req.ActorRoleUID)
+	}
+
+	authz := &model.Authz{
+		UID:       util.NewULIDGenerate(),
+		UserUID:   req.UserUID,
+		RoleUID:   req.RoleUID,
+		CreatedBy: req.CreatedBy,
+		UpdatedBy: req.CreatedBy,
+	}
+
+	if req.ValidFrom != nil {
+		validFrom := req.ValidFrom.UTC()
+		authz.ValidFrom = &validFrom
+	}
+
+	if req.ValidUntil != nil {
+		if !req.ValidUntil.After(time.Now()) {
+			return resp, errors.New("valid_until must be in the future")
+		}
+
+		if req.ValidFrom != nil && !req.ValidUntil.After(*req.ValidFrom) {
+			return resp, errors.New("valid_until must be after valid_from")
+		}
+
+		validUntil := req.ValidUntil.UTC()
+		authz.ValidUntil = &validUntil
+	}
+
+	// The grants of the user are locked while checked, so two grants made at
+	// once can't both pass the separation of duties.
+	var InTransaction = func(rr sql.RepositoryRegistry) (out interface{}, err error) {
+
+		constraints, err := rr.RoleConstraintsRepository().ReadRoleConstraints(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(constraints) > 0 {
+			held, err := rr.AuthzRepository().ReadUnexpiredAuthz(ctx, &model.ReadUnexpiredAuthzReq{
+				UserUID:   req.UserUID,
+				ForUpdate: true,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			if c := roleConstraintsUsecase.Separation(constraints).Conflict(held, authz); c != nil {
+				return nil, fmt.Errorf("%w: role %s cannot be held together with the other roles of constraint %s",
+					service.ErrConflict, req.RoleUID, c.Name)
+			}
+		}
+
+		return nil, rr.AuthzRepository().CreateAuthz(ctx, authz)
+	}
+
+	if _, err = as.repo.DoInTransaction(ctx, InTransaction); err != nil {
+		return resp, err
+	}
+
+	if err = as.revokePrincipals(ctx, req.UserUID); err != nil {
+		log.Println("error revoke principal of granted user", req.UserUID, err)
+	}
+
+	return service.GrantRoleResp{UID: authz.UID}, nil
+}
+
+// SweepExpiredAuthz soft-deletes every grant whose validity window has closed
+// and revokes the sessions of the users holding them, so they have to log in
+// again with the roles they still have.
+func (as *AuthzServiceImpl) SweepExpiredAuthz(ctx context.Context) (resp service.SweepExpiredAuthzResp, err error) {
+
+	authzRepo := as.repo.AuthzRepository()
+
+	expired, err := authzRepo.ReadExpiredAuthz(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(expired) == 0 {
+		return resp, nil
+	}
+
+	uids := make([]string, 0, len(expired))
+	users := make(map[string]bool)
+	for _, v := range expired {
+		uids = append(uids, v.UID)
+		if !users[v.UserUID] {
+			users[v.UserUID] = true
+			resp.UserUIDs = append(resp.UserUIDs, v.UserUID)
+		}
+	}
+
+	err = authzRepo.DeleteAuthzByUIDs(ctx, &model.DeleteAuthzByUIDsReq{
+		UIDs:      uids,
+		UpdatedBy: constant.System,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Revoked = len(uids)
+
+	for _, v := range resp.UserUIDs {
+		if err = cache.RevokeSessions(ctx, as.cache, v); err != nil {
+			return resp, err
+		}
+	}
+
+	if err = as.revokePrincipals(ctx, resp.UserUIDs...); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// revokePrincipals drops the cached principals of userUIDs in the tenant of
+// ctx.
+func (as *AuthzServiceImpl) revokePrincipals(ctx context.Context, userUIDs ...string) error {
+
+	orgUID, err := sql.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cache.RevokePrincipals(ctx, as.cache, orgUID, userUIDs...)
+}
+
+// principalTTL bounds in seconds how long a principal is cached, the
+// changes of grants, constraints and credentials revoking it before.
+const principalTTL = 60
+
+// principal is what the requests of a user are checked against, cached so
+// the requests don't read the credential and grants of the user each time.
+type principal struct {
+	Active    bool     `json:"active"`
+	RoleUIDs  []string `json:"role_uids"`
+	Violation string   `json:"violation,omitempty"`
+}
+
+func (p principal) holds(roleUID string) bool {
+	for _, v := range p.RoleUIDs {
+		if v == roleUID {
+			return true
+		}
+	}
+	return false
+}
+
+func (p principal) separationError() error {
+	if p.Violation == "" {
+		return nil
+	}
+	return fmt.Errorf("%w: active roles break constraint %s, ask an administrator to resolve it",
+		service.ErrForbidden, p.Violation)
+}
+
+// principalOf returns the cached principal of userUID, reading it on a miss.
+func (as *AuthzServiceImpl) principalOf(ctx context.Context, userUID string) (p principal, err error) {
+
+	orgUID, err := sql.TenantFromContext(ctx)
+	if err != nil {
+		return p, err
+	}
+
+	key := cache.PrincipalKey(orgUID, userUID)
+	if err = as.cache.GetObject(ctx, key, &p); err == nil {
+		return p, nil
+	}
+
+	p, ttl, err := as.readPrincipal(ctx, userUID)
+	if err != nil {
+		return p, err
+	}
+
+	if err = as.cache.Set(ctx, key, p, ttl); err != nil {
+		log.Println("error cache principal", userUID, err)
+	}
+
+	return p, nil
+}
+
+// readPrincipal reads the principal of userUID, and how long it holds: until
+// the next grant enters or leaves its window, within principalTTL.
+func (as *AuthzServiceImpl) readPrincipal(ctx context.Context, userUID string) (p principal, ttl int, err error) {
+
+	ttl = principalTTL
+
+	if p.Active, err = as.credentialActive(ctx, userUID); err != nil || !p.Active {
+		return p, ttl, err
+	}
+
+	roles, err := as.repo.AuthzRepository().ReadAuthzByUserUID(ctx, &model.ReadAuthzByUserUIDReq{
+		UserUID: userUID,
+	})
+	if err != nil {
+		return p, ttl, err
+	}
+
+	p.RoleUIDs = make([]string, 0, len(roles))
+	for _, v := range roles {
+		p.RoleUIDs = append(p.RoleUIDs, v.RoleUID)
+	}
+
+	grants, err := as.repo.AuthzRepository().ReadUnexpiredAuthz(ctx, &model.ReadUnexpiredAuthzReq{
+		UserUID: userUID,
+	})
+	if err != nil {
+		return p, ttl, err
+	}
+
+	now := time.Now()
+	active := make([]*model.Authz, 0, len(grants))
+	for _, v := range grants {
+		for _, edge := range []*time.Time{v.ValidFrom, v.ValidUntil} {
+			if edge != nil && edge.After(now) {
+				if left := int(edge.Sub(now)/time.Second) + 1; left < ttl {
+					ttl = left
+				}
+			}
+		}
+
+		if v.ValidFrom == nil || !v.ValidFrom.After(now) {
+			active = append(active, v)
+		}
+	}
+
+	// A constraint declared after the grants, or an upcoming grant reaching
+	// its valid_from, may make the active grants break a separation of
+	// duties.
+	constraints, err := as.repo.RoleConstraintsRepository().ReadRoleConstraints(ctx)
+	if err != nil {
+		return p, ttl, err
+	}
+
+	if violations := roleConstraintsUsecase.Separation(constraints).Violations(active); len(violations) > 0 {
+		p.Violation = violations[0].Constraint.Name
+	}
+
+	return p, ttl, nil
+}
+
+// checkActiveSeparation refuses users whose active grants break a separation
+// of duties constraint.
+func (as *AuthzServiceImpl) checkActiveSeparation(ctx context.Context, userUID string) error {
+
+	p, err := as.principalOf(ctx, userUID)
+	if err != nil {
+		return err
+	}
+
+	return p.separationError()
+}
+
+// Authorize allows the request when a rule of any role the caller holds in
+// its validity window grants the resource and its condition holds, the token
+// naming a single role while the approved elevations and the time-bound
+// grants add others. subject.role_uid is the role of the rule evaluated and
+// resource.owner_uid the user owning the resource, see resourceAttrs.
+func (as *AuthzServiceImpl) Authorize(ctx context.Context, req service.AuthorizeReq) (resp service.AuthorizeResp, err error) {
+
+	roleUIDs, err := as.activeRoleUIDs(ctx, req.Claims.Sub)
+	if err != nil || len(roleUIDs) == 0 {
+		return resp, err
+	}
+
+	rules, err := as.repo.AccessRepository().ReadAccessByResource(ctx, &model.ReadAccessByResourceReq{
+		RoleUIDs: roleUIDs,
+		Name:     req.Resource,
+		Action:   req.Action,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	// The owner of the resource is only read for the rules with a condition.
+	attrs, loaded := req.Attrs, false
+
+	for _, rule := range rules {
+		if rule.Condition.String != "" && !loaded {
+			if attrs, err = as.resourceAttrs(ctx, req.Resource, req.Claims.Sub, req.Attrs); err != nil {
+				return resp, err
+			}
+			loaded = true
+		}
+
+		claims := req.Claims
+		claims.RoleUID = rule.RoleUID
+
+		env := policyEnv(claims, req.Resource, req.Action, attrs, req.Context)
+
+		ok, err := policy.Evaluate(rule.Condition.String, env)
+		if err != nil {
+			log.Println("error evaluate access condition", rule.UID, err)
+			continue
+		}
+
+		if ok {
+			return service.AuthorizeResp{Allowed: true}, nil
+		}
+	}
+
+	return resp, nil
+}
+
+// activeRoleUIDs lists the roles userUID holds in their validity window.
+func (as *AuthzServiceImpl) activeRoleUIDs(ctx context.Context, userUID string) ([]string, error) {
+
+	p, err := as.principalOf(ctx, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.RoleUIDs, nil
+}
+
+// CheckPermission decides as Authorize does, over every role the user holds
+// in its validity window, explaining the decision when asked.
+func (as *AuthzServiceImpl) CheckPermission(ctx context.Context, req service.CheckPermissionReq) (resp service.CheckPermissionResp, err error) {
+
+	authzRepo := as.repo.AuthzRepository()
+	resourcesRepo := as.repo.ResourcesRepository()
+	accessRepo := as.repo.AccessRepository()
+
+	resp.Decision = constant.Deny.String()
+
+	explain := func(format string, args ...interface{}) {
+		if req.Explain {
+			resp.Explanation = append(resp.Explanation, fmt.Sprintf(format, args...))
+		}
+	}
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	orgUID, err := sql.TenantFromContext(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	roles, err := authzRepo.ReadAuthzByUserUID(ctx, &model.ReadAuthzByUserUIDReq{
+		UserUID: req.UserUID,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if len(roles) == 0 {
+		explain("user %s holds no active role", req.UserUID)
+		return resp, nil
+	}
+
+	roleNames := make(map[string]string, len(roles))
+	roleUIDs := make([]string, 0, len(roles))
+	for _, v := range roles {
+		roleNames[v.RoleUID] = v.RoleName
+		roleUIDs = append(roleUIDs, v.RoleUID)
+		explain("user %s holds role %s (%s)", req.UserUID, v.RoleName, v.RoleUID)
+	}
+
+	resources, err := resourcesRepo.ReadResourcesByKey(ctx, &model.ReadResourcesByKeyReq{
+		Key:    req.Resource,
+		Action: req.Action,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if len(resources) == 0 {
+		explain("no resource matches %q with action %q", req.Resource, req.Action)
+		return resp, nil
+	}
+
+	resourceByUID := make(map[string]*model.Resource, len(resources))
+	resourceUIDs := make([]string, 0, len(resources))
+	for _, v := range resources {
+		resourceByUID[v.UID] = v
+		resourceUIDs = append(resourceUIDs, v.UID)
+	}
+
+	rules, err := accessRepo.ReadAccessByRolesAndResources(ctx, &model.ReadAccessByRolesAndResourcesReq{
+		RoleUIDs:     roleUIDs,
+		ResourceUIDs: resourceUIDs,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if len(rules) == 0 {
+		explain("none of the user's roles is granted %q with action %q", req.Resource, req.Action)
+		return resp, nil
+	}
+
+	for _, rule := range rules {
+		resource := resourceByUID[rule.ResourceUID]
+
+		attrs := req.Attributes
+		if rule.Condition.String != "" {
+			if attrs, err = as.resourceAttrs(ctx, resource.Name, req.UserUID, req.Attributes); err != nil {
+				return resp, err
+			}
+		}
+
+		env := policyEnv(service.JWTClaims{Sub: req.UserUID, RoleUID: rule.RoleUID, OrgUID: orgUID}, resource.Name, resource.Action,
+			attrs, req.Context)
+
+		ok, err := policy.Evaluate(rule.Condition.String, env)
+		if err != nil {
+			explain("rule %s of role %s: condition %q could not be evaluated: %v", rule.UID, roleNames[rule.RoleUID], rule.Condition.String, err)
+			continue
+		}
+
+		if !ok {
+			explain("rule %s of role %s: condition %q evaluated to false", rule.UID, roleNames[rule.RoleUID], rule.Condition.String)
+			continue
+		}
+
+		if rule.Condition.String == "" {
+			explain("rule %s grants role %s unconditional access to %s (%s)", rule.UID, roleNames[rule.RoleUID], resource.Name, resource.UID)
+		} else {
+			explain("rule %s grants role %s access to %s (%s) because %q holds", rule.UID, roleNames[rule.RoleUID], resource.Name, resource.UID, rule.Condition.String)
+		}
+
+		resp.Allowed = true
+		resp.Decision = constant.Allow.String()
+		resp.MatchedRule = &service.MatchedRule{
+			AccessUID:    rule.UID,
+			RoleUID:      rule.RoleUID,
+			RoleName:     roleNames[rule.RoleUID],
+			ResourceUID:  resource.UID,
+			ResourceName: resource.Name,
+			Action:       resource.Action,
+			Condition:    rule.Condition.String,
+		}
+		return resp, nil
+	}
+
+	explain("no access rule allows the request")
+
+	return resp, nil
+}
+
+func (as *AuthzServiceImpl) CheckPermissionBatch(ctx context.Context, req service.CheckPermissionBatchReq) (resp service.CheckPermissionBatchResp, err error) {
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	resp.Results = make([]service.CheckPermissionResp, 0, len(req.Checks))
+
+	for i, check := range req.Checks {
+		check.Explain = check.Explain || req.Explain
+
+		if err = check.Validate(); err != nil {
+			resp.Results = append(resp.Results, service.CheckPermissionResp{
+				Decision: constant.Deny.String(),
+				Error:    err.Error(),
+			})
+			continue
+		}
+
+		res, err := as.CheckPermission(ctx, check)
+		if err != nil {
+			return resp, fmt.Errorf("check %d: %w", i, err)
+		}
+
+		resp.Results = append(resp.Results, res)
+	}
+
+	return resp, nil
+}
+
+func policyEnv(claims service.JWTClaims, resource, action string, attrs, reqCtx map[string]interface{}) policy.Env {
+
+	ctxAttrs := policy.TimeContext(time.Now())
+	for k, v := range reqCtx {
+		ctxAttrs[k] = v
+	}
+
+	resourceAttrs := map[string]interface{}{
+		"name":   resource,
+		"action": action,
+	}
+	for k, v := range attrs {
+		resourceAttrs[k] = v
+	}
+
+	return policy.Env{
+		"subject": map[string]interface{}{
+			"sub":      claims.Sub,
+			"role_uid": claims.RoleUID,
+			"org_uid":  claims.OrgUID,
+		},
+		"resource": resourceAttrs,
+		"context":  ctxAttrs,
+	}
+}
+
 func (as *AuthzServiceImpl) generateAndSignAccessToken(ctx context.Context, req *model.GenerateAccessTokenReq) (resp *model.GenerateAccessTokenResp, err error) {
 
 	claims := make(jwt.MapClaims)
 	claims["sub"] = req.UserUID
 	claims["role_uid"] = req.RoleUID
+	claims["org_uid"] = req.OrgUID
+	claims["sid"] = req.SessionUID
 	claims["iat"] = time.Now().UTC().Unix()
 	claims["exp"] = time.Now().UTC().Add(time.Duration(req.ExpiredAt) * time.Second).Unix()
 	claims["last_active"] = req.LastActive
@@ -191,6 +809,8 @@ func (as *AuthzServiceImpl) generateAndSignRefreshToken(ctx context.Context, req
 
 	claims := make(jwt.MapClaims)
 	claims["sub"] = req.UserUID
+	claims["org_uid"] = req.OrgUID
+	claims["sid"] = req.SessionUID
 	claims["iat"] = time.Now().UTC().Unix()
 	claims["exp"] = time.Now().UTC().Add(time.Duration(req.ExpiredAt) * time.Second).Unix()
 
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestResourceAttrs(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		attrs    map[string]interface{}
+		want     map[string]interface{}
+	}{
+		{name: "me", resource: "/v1/me", want: map[string]interface{}{"owner_uid": "u1"}},
+		{
+			name:     "beneath me",
+			resource: "/v1/me/educations/{section_uid}",
+			attrs:    map[string]interface{}{"section_uid": "s1"},
+			want:     map[string]interface{}{"section_uid": "s1", "owner_uid": "u1"},
+		},
+		{
+			name:     "user in the path",
+			resource: "/v1/users/{uid}",
+			attrs:    map[string]interface{}{"uid": "u2"},
+			want:     map[string]interface{}{"uid": "u2", "owner_uid": "u2"},
+		},
+		{
+			name:     "beneath a user",
+			resource: "/v1/users/{uid}/expertises",
+			attrs:    map[string]interface{}{"uid": "u2"},
+			want:     map[string]interface{}{"uid": "u2", "owner_uid": "u2"},
+		},
+		{
+			name:     "owner given",
+			resource: "/v1/users/{uid}/photo",
+			attrs:    map[string]interface{}{"uid": "u2", "owner_uid": "u3"},
+			want:     map[string]interface{}{"uid": "u2", "owner_uid": "u3"},
+		},
+		{name: "prefix of me", resource: "/v1/menus"},
+		{name: "users listing", resource: "/v1/users"},
+		{
+			name:     "resource without owner",
+			resource: "/v1/expertises/{uid}",
+			attrs:    map[string]interface{}{"uid": "e1"},
+			want:     map[string]interface{}{"uid": "e1"},
+		},
+		{name: "record not named", resource: "/v1/elevations/{uid}/approve"},
+	}
+
+	as := &AuthzServiceImpl{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := as.resourceAttrs(context.Background(), tt.resource, "u1", tt.attrs)
+			if err != nil {
+				t.Fatalf("resourceAttrs() error = %v", err)
+			}
+			if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Fatalf("resourceAttrs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/domain/repository/blob"
+	"github/yogabagas/join-app/domain/service"
+)
+
+type BlobsServiceImpl struct {
+	store blob.BlobStore
+}
+
+type BlobsService interface {
+	GetBlob(ctx context.Context, req service.GetBlobReq) (service.BlobResp, error)
+}
+
+func NewBlobsService(store blob.BlobStore) BlobsService {
+	return &BlobsServiceImpl{store: store}
+}
+
+// GetBlob opens the blob of a URL signed by the store, only the stores whose
+// URLs point at the app being served this way.
+func (bs *BlobsServiceImpl) GetBlob(ctx context.Context, req service.GetBlobReq) (resp service.BlobResp, err error) {
+
+	verifier, ok := bs.store.(blob.URLVerifier)
+	if !ok {
+		return resp, fmt.Errorf("%w: blob %s", service.ErrNotFound, req.Key)
+	}
+
+	if err = verifier.VerifyURL(req.Key, req.Expires, req.Signature); err != nil {
+		return resp, fmt.Errorf("%w: %v", service.ErrForbidden, err)
+	}
+
+	obj, err := bs.store.Get(ctx, req.Key)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return resp, fmt.Errorf("%w: blob %s", service.ErrNotFound, req.Key)
+		}
+		return resp, err
+	}
+
+	return service.BlobResp{Body: obj.Body, ContentType: obj.ContentType, Size: obj.Size}, nil
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+)
+
+type ElevationsRepository interface {
+	CreateElevationRequest(ctx context.Context, req *model.ElevationRequest) error
+	ReadElevationRequests(ctx context.Context, req *model.ReadElevationRequestsReq) ([]*model.ElevationRequest, error)
+	ReadElevationRequestByUID(ctx context.Context, req *model.ReadElevationRequestByUIDReq) (*model.ElevationRequest, error)
+	DecideElevationRequest(ctx context.Context, req *model.DecideElevationRequestReq) error
+}
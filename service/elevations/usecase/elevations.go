@@ -0,0 +1,370 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/config"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/cache"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	roleConstraintsUsecase "github/yogabagas/join-app/service/roleConstraints/usecase"
+	rolesUsecase "github/yogabagas/join-app/service/roles/usecase"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/shared/util"
+	"log"
+	"strings"
+	"time"
+)
+
+type ElevationsServiceImpl struct {
+	repo  sql.RepositoryRegistry
+	cache cache.Cache
+}
+
+type ElevationsService interface {
+	CreateElevation(ctx context.Context, req service.CreateElevationReq) (service.ElevationResp, error)
+	GetElevations(ctx context.Context, req service.GetElevationsReq) ([]service.ElevationResp, error)
+	ApproveElevation(ctx context.Context, req service.DecideElevationReq) (service.ElevationResp, error)
+	DenyElevation(ctx context.Context, req service.DecideElevationReq) (service.ElevationResp, error)
+}
+
+func NewElevationsService(repository sql.RepositoryRegistry, cache cache.Cache) ElevationsService {
+	return &ElevationsServiceImpl{
+		repo:  repository,
+		cache: cache,
+	}
+}
+
+func (es *ElevationsServiceImpl) CreateElevation(ctx context.Context, req service.CreateElevationReq) (resp service.ElevationResp, err error) {
+
+	elevationsRepo := es.repo.ElevationsRepository()
+
+	if req.RoleUID == "" {
+		return resp, errors.New("role_uid is required")
+	}
+
+	if strings.TrimSpace(req.Justification) == "" {
+		return resp, errors.New("justification is required")
+	}
+
+	if req.DurationMinutes <= 0 {
+		return resp, errors.New("duration_minutes must be positive")
+	}
+
+	if max := config.GlobalCfg.Authorization.MaxElevationMinutes; max > 0 && req.DurationMinutes > max {
+		return resp, fmt.Errorf("duration_minutes can't exceed %d", max)
+	}
+
+	roles, err := es.repo.RolesRepository().ReadRoles(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	var found bool
+	for _, v := range roles {
+		if v.UID == req.RoleUID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return resp, fmt.Errorf("role %s is not found", req.RoleUID)
+	}
+
+	held, err := es.repo.AuthzRepository().ReadAuthzByUserUID(ctx, &model.ReadAuthzByUserUIDReq{
+		UserUID: req.UserUID,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	for _, v := range held {
+		if v.RoleUID == req.RoleUID {
+			return resp, fmt.Errorf("%w: role %s is already held", service.ErrConflict, req.RoleUID)
+		}
+	}
+
+	pending, err := elevationsRepo.ReadElevationRequests(ctx, &model.ReadElevationRequestsReq{
+		UserUID: req.UserUID,
+		Status:  constant.ElevationPending.String(),
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	for _, v := range pending {
+		if v.RoleUID == req.RoleUID {
+			return resp, fmt.Errorf("%w: request %s for this role is still pending", service.ErrConflict, v.UID)
+		}
+	}
+
+	elevation := &model.ElevationRequest{
+		UID:           util.NewULIDGenerate(),
+		UserUID:       req.UserUID,
+		RoleUID:       req.RoleUID,
+		Justification: strings.TrimSpace(req.Justification),
+		Duration:      req.DurationMinutes,
+		Status:        constant.ElevationPending.String(),
+		CreatedBy:     req.UserUID,
+		UpdatedBy:     req.UserUID,
+		CreatedAt:     time.Now(),
+	}
+
+	if err = elevationsRepo.CreateElevationRequest(ctx, elevation); err != nil {
+		return resp, err
+	}
+
+	return elevationResp(elevation), nil
+}
+
+func (es *ElevationsServiceImpl) GetElevations(ctx context.Context, req service.GetElevationsReq) (resp []service.ElevationResp, err error) {
+
+	elevations, err := es.repo.ElevationsRepository().ReadElevationRequests(ctx, &model.ReadElevationRequestsReq{
+		UserUID: req.UserUID,
+		Status:  req.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp = []service.ElevationResp{}
+	for _, v := range elevations {
+		resp = append(resp, elevationResp(v))
+	}
+
+	return resp, nil
+}
+
+// ApproveElevation grants the requested role until the requested duration has
+// elapsed, after which the expiry sweeper revokes it like any time-bound grant.
+func (es *ElevationsServiceImpl) ApproveElevation(ctx context.Context, req service.DecideElevationReq) (resp service.ElevationResp, err error) {
+
+	orgUID, err := sql.TenantFromContext(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	elevation, err := es.decidable(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	validUntil := time.Now().UTC().Add(time.Duration(elevation.Duration) * time.Minute)
+
+	authz := &model.Authz{
+		UID:        util.NewULIDGenerate(),
+		UserUID:    elevation.UserUID,
+		RoleUID:    elevation.RoleUID,
+		ValidUntil: &validUntil,
+		CreatedBy:  req.ActorUID,
+		UpdatedBy:  req.ActorUID,
+	}
+
+	_, err = es.repo.DoInTransaction(ctx, func(rr sql.RepositoryRegistry) (interface{}, error) {
+
+		constraints, err := rr.RoleConstraintsRepository().ReadRoleConstraints(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		held, err := rr.AuthzRepository().ReadUnexpiredAuthz(ctx, &model.ReadUnexpiredAuthzReq{
+			UserUID:   elevation.UserUID,
+			ForUpdate: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		for _, v := range held {
+			if v.RoleUID == elevation.RoleUID && (v.ValidFrom == nil || !v.ValidFrom.After(now)) {
+				return nil, fmt.Errorf("%w: role %s is already held by the requester", service.ErrConflict, elevation.RoleUID)
+			}
+		}
+
+		if c := roleConstraintsUsecase.Separation(constraints).Conflict(held, authz); c != nil {
+			return nil, fmt.Errorf("%w: role %s cannot be held together with the other roles of constraint %s",
+				service.ErrConflict, elevation.RoleUID, c.Name)
+		}
+
+		if err = rr.AuthzRepository().CreateAuthz(ctx, authz); err != nil {
+			return nil, err
+		}
+
+		err = rr.ElevationsRepository().DecideElevationRequest(ctx, &model.DecideElevationRequestReq{
+			UID:          elevation.UID,
+			Status:       constant.ElevationApproved.String(),
+			DecidedBy:    req.ActorUID,
+			DecisionNote: strings.TrimSpace(req.Note),
+			AuthzUID:     authz.UID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, notify(ctx, rr, elevation, constant.ElevationApproved, req, &validUntil)
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	// The requester holds the role from their next request, their principal
+	// being read again.
+	if err = cache.RevokePrincipals(ctx, es.cache, orgUID, elevation.UserUID); err != nil {
+		log.Println("error revoke principal of elevated user", elevation.UserUID, err)
+	}
+
+	return es.reload(ctx, elevation.UID)
+}
+
+func (es *ElevationsServiceImpl) DenyElevation(ctx context.Context, req service.DecideElevationReq) (resp service.ElevationResp, err error) {
+
+	if strings.TrimSpace(req.Note) == "" {
+		return resp, errors.New("note is required when denying a request")
+	}
+
+	elevation, err := es.decidable(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	_, err = es.repo.DoInTransaction(ctx, func(rr sql.RepositoryRegistry) (interface{}, error) {
+
+		err := rr.ElevationsRepository().DecideElevationRequest(ctx, &model.DecideElevationRequestReq{
+			UID:          elevation.UID,
+			Status:       constant.ElevationDenied.String(),
+			DecidedBy:    req.ActorUID,
+			DecisionNote: strings.TrimSpace(req.Note),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, notify(ctx, rr, elevation, constant.ElevationDenied, req, nil)
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	return es.reload(ctx, elevation.UID)
+}
+
+// decidable loads a pending request the actor may decide on: the actor's role
+// must be a configured approver sitting above the requested role, and nobody
+// decides on their own request.
+func (es *ElevationsServiceImpl) decidable(ctx context.Context, req service.DecideElevationReq) (*model.ElevationRequest, error) {
+
+	roles, err := es.repo.RolesRepository().ReadRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var approver bool
+	for _, v := range roles {
+		if v.UID == req.ActorRoleUID && isApproverRole(v.Name) {
+			approver = true
+			break
+		}
+	}
+
+	if !approver {
+		return nil, fmt.Errorf("%w: role %s can't decide on elevation requests", service.ErrForbidden, req.ActorRoleUID)
+	}
+
+	elevation, err := es.repo.ElevationsRepository().ReadElevationRequestByUID(ctx, &model.ReadElevationRequestByUIDReq{
+		UID: req.UID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if elevation == nil {
+		return nil, fmt.Errorf("elevation request %s is not found", req.UID)
+	}
+
+	if !rolesUsecase.NewHierarchy(roles).Manages(req.ActorRoleUID, elevation.RoleUID) {
+		return nil, fmt.Errorf("%w: role %s can only decide on the roles beneath it", service.ErrForbidden, req.ActorRoleUID)
+	}
+
+	if elevation.UserUID == req.ActorUID {
+		return nil, fmt.Errorf("%w: requesters can't decide on their own elevation", service.ErrForbidden)
+	}
+
+	if elevation.Status != constant.ElevationPending.String() {
+		return nil, fmt.Errorf("%w: elevation request %s is already %s", service.ErrConflict, elevation.UID, elevation.Status)
+	}
+
+	return elevation, nil
+}
+
+func (es *ElevationsServiceImpl) reload(ctx context.Context, uid string) (resp service.ElevationResp, err error) {
+
+	elevation, err := es.repo.ElevationsRepository().ReadElevationRequestByUID(ctx, &model.ReadElevationRequestByUIDReq{
+		UID: uid,
+	})
+	if err != nil || elevation == nil {
+		return resp, err
+	}
+
+	return elevationResp(elevation), nil
+}
+
+// notify stores the decision as a notification of the requester, within the
+// transaction recording the decision.
+func notify(ctx context.Context, rr sql.RepositoryRegistry, elevation *model.ElevationRequest, status constant.ElevationStatus,
+	req service.DecideElevationReq, validUntil *time.Time) error {
+
+	orgUID, _ := sql.TenantFromContext(ctx)
+
+	b, err := json.Marshal(service.ElevationDecisionEvent{
+		RequestUID: elevation.UID,
+		OrgUID:     orgUID,
+		UserUID:    elevation.UserUID,
+		RoleUID:    elevation.RoleUID,
+		Status:     status.String(),
+		DecidedBy:  req.ActorUID,
+		Note:       strings.TrimSpace(req.Note),
+		ValidUntil: validUntil,
+	})
+	if err != nil {
+		return err
+	}
+
+	return rr.NotificationsRepository().CreateNotification(ctx, &model.Notification{
+		UID:        util.NewULIDGenerate(),
+		UserUID:    elevation.UserUID,
+		Kind:       constant.NotifyElevationDecision.String(),
+		SubjectUID: elevation.UID,
+		Payload:    string(b),
+		CreatedBy:  req.ActorUID,
+	})
+}
+
+func isApproverRole(name string) bool {
+	for _, v := range config.GlobalCfg.Authorization.ElevationApprovers {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+func elevationResp(v *model.ElevationRequest) service.ElevationResp {
+	return service.ElevationResp{
+		UID:             v.UID,
+		UserUID:         v.UserUID,
+		RoleUID:         v.RoleUID,
+		Justification:   v.Justification,
+		DurationMinutes: v.Duration,
+		Status:          v.Status,
+		DecidedBy:       v.DecidedBy,
+		DecidedAt:       v.DecidedAt,
+		DecisionNote:    v.DecisionNote,
+		AuthzUID:        v.AuthzUID,
+		CreatedAt:       v.CreatedAt,
+	}
+}
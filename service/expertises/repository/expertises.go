@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+)
+
+type ExpertisesRepository interface {
+	CreateExpertise(ctx context.Context, req *model.Expertise) error
+	ReadExpertises(ctx context.Context, req *model.ReadExpertisesReq) ([]*model.Expertise, error)
+	ReadExpertisesByUIDs(ctx context.Context, req *model.ReadExpertisesByUIDsReq) ([]*model.Expertise, error)
+	UpdateExpertise(ctx context.Context, req *model.UpdateExpertiseReq) error
+	DeleteExpertise(ctx context.Context, req *model.DeleteExpertiseReq) error
+	ReadUserExpertises(ctx context.Context, req *model.ReadUserExpertisesReq) ([]*model.UserExpertise, error)
+	ReplaceUserExpertises(ctx context.Context, req *model.ReplaceUserExpertisesReq) error
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/util"
+	"strings"
+	"unicode/utf8"
+)
+
+// The autocomplete answers defaultAutocompleteLimit expertises unless asked
+// for more, and never more than maxAutocompleteLimit.
+const (
+	defaultAutocompleteLimit = 10
+	maxAutocompleteLimit     = 50
+	maxExpertiseNameLength   = 255
+)
+
+type ExpertisesServiceImpl struct {
+	repo sql.RepositoryRegistry
+}
+
+type ExpertisesService interface {
+	CreateExpertise(ctx context.Context, req service.CreateExpertiseReq) (service.ExpertiseResp, error)
+	GetExpertises(ctx context.Context) ([]service.ExpertiseResp, error)
+	AutocompleteExpertises(ctx context.Context, req service.AutocompleteExpertisesReq) ([]service.ExpertiseTagResp, error)
+	UpdateExpertise(ctx context.Context, req service.UpdateExpertiseReq) (service.ExpertiseResp, error)
+	DeleteExpertise(ctx context.Context, req service.DeleteExpertiseReq) error
+}
+
+func NewExpertisesService(repository sql.RepositoryRegistry) ExpertisesService {
+	return &ExpertisesServiceImpl{repo: repository}
+}
+
+func (es *ExpertisesServiceImpl) CreateExpertise(ctx context.Context, req service.CreateExpertiseReq) (resp service.ExpertiseResp, err error) {
+
+	name, err := es.uniqueName(ctx, req.Name, "")
+	if err != nil {
+		return resp, err
+	}
+
+	expertise := &model.Expertise{
+		UID:       util.NewULIDGenerate(),
+		Name:      name,
+		CreatedBy: req.CreatedBy,
+		UpdatedBy: req.CreatedBy,
+	}
+
+	if err = es.repo.ExpertisesRepository().CreateExpertise(ctx, expertise); err != nil {
+		return resp, err
+	}
+
+	return es.expertise(ctx, expertise.UID)
+}
+
+func (es *ExpertisesServiceImpl) GetExpertises(ctx context.Context) (resp []service.ExpertiseResp, err error) {
+
+	expertises, err := es.repo.ExpertisesRepository().ReadExpertises(ctx, &model.ReadExpertisesReq{})
+	if err != nil {
+		return nil, err
+	}
+
+	resp = []service.ExpertiseResp{}
+	for _, v := range expertises {
+		resp = append(resp, expertiseResp(v))
+	}
+
+	return resp, nil
+}
+
+func (es *ExpertisesServiceImpl) AutocompleteExpertises(ctx context.Context, req service.AutocompleteExpertisesReq) (resp []service.ExpertiseTagResp, err error) {
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultAutocompleteLimit
+	} else if limit > maxAutocompleteLimit {
+		limit = maxAutocompleteLimit
+	}
+
+	expertises, err := es.repo.ExpertisesRepository().ReadExpertises(ctx, &model.ReadExpertisesReq{
+		Prefix: strings.TrimSpace(req.Query),
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp = []service.ExpertiseTagResp{}
+	for _, v := range expertises {
+		resp = append(resp, service.ExpertiseTagResp{
+			UID:  v.UID,
+			Name: v.Name,
+		})
+	}
+
+	return resp, nil
+}
+
+func (es *ExpertisesServiceImpl) UpdateExpertise(ctx context.Context, req service.UpdateExpertiseReq) (resp service.ExpertiseResp, err error) {
+
+	if _, err = es.expertise(ctx, req.UID); err != nil {
+		return resp, err
+	}
+
+	name, err := es.uniqueName(ctx, req.Name, req.UID)
+	if err != nil {
+		return resp, err
+	}
+
+	err = es.repo.ExpertisesRepository().UpdateExpertise(ctx, &model.UpdateExpertiseReq{
+		UID:       req.UID,
+		Name:      name,
+		UpdatedBy: req.UpdatedBy,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	return es.expertise(ctx, req.UID)
+}
+
+// DeleteExpertise takes the expertise out of the catalog, the users tagged
+// with it simply stop showing it.
+func (es *ExpertisesServiceImpl) DeleteExpertise(ctx context.Context, req service.DeleteExpertiseReq) error {
+	return es.repo.ExpertisesRepository().DeleteExpertise(ctx, &model.DeleteExpertiseReq{
+		UID:       req.UID,
+		UpdatedBy: req.UpdatedBy,
+	})
+}
+
+func (es *ExpertisesServiceImpl) expertise(ctx context.Context, uid string) (resp service.ExpertiseResp, err error) {
+
+	expertises, err := es.repo.ExpertisesRepository().ReadExpertisesByUIDs(ctx, &model.ReadExpertisesByUIDsReq{
+		UIDs: []string{uid},
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if len(expertises) == 0 {
+		return resp, fmt.Errorf("expertise %s is not found", uid)
+	}
+
+	return expertiseResp(expertises[0]), nil
+}
+
+// uniqueName trims the name and checks no other expertise than exceptUID is
+// already called that way, regardless of case.
+func (es *ExpertisesServiceImpl) uniqueName(ctx context.Context, name, exceptUID string) (string, error) {
+
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return "", errors.New("name is required")
+	}
+
+	if utf8.RuneCountInString(name) > maxExpertiseNameLength {
+		return "", fmt.Errorf("name can't be longer than %d characters", maxExpertiseNameLength)
+	}
+
+	same, err := es.repo.ExpertisesRepository().ReadExpertises(ctx, &model.ReadExpertisesReq{
+		Prefix: name,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, v := range same {
+		if v.UID != exceptUID && strings.EqualFold(v.Name, name) {
+			return "", fmt.Errorf("%w: expertise %s already exists", service.ErrConflict, v.Name)
+		}
+	}
+
+	return name, nil
+}
+
+func expertiseResp(expertise *model.Expertise) service.ExpertiseResp {
+	return service.ExpertiseResp{
+		UID:       expertise.UID,
+		Name:      expertise.Name,
+		CreatedAt: expertise.CreatedAt,
+		UpdatedAt: expertise.UpdatedAt,
+	}
+}
@@ -11,6 +11,7 @@ type JWKPresenterImpl struct{}
 
 type JWKPresenter interface {
 	VerifyJWT(ctx context.Context, payload map[string]interface{}) (service.VerifyTokenResp, error)
+	VerifyRefreshJWT(ctx context.Context, payload map[string]interface{}) (service.RefreshTokenReq, error)
 }
 
 func NewJWKPresenter() JWKPresenter {
@@ -34,6 +35,11 @@ func (jp *JWKPresenterImpl) VerifyJWT(ctx context.Context, payload map[string]in
 		return resp, errors.New("role uid is undefined")
 	}
 
+	orgUID, ok := payload["org_uid"].(string)
+	if !ok {
+		return resp, errors.New("organization uid is undefined")
+	}
+
 	lat, ok := payload["last_active"].(float64)
 	if !ok {
 		return resp, errors.New("invalid token")
@@ -43,12 +49,53 @@ func (jp *JWKPresenterImpl) VerifyJWT(ctx context.Context, payload map[string]in
 		return resp, errors.New("token expired")
 	}
 
+	// Tokens issued before sessions had an id carry no sid.
+	sid, _ := payload["sid"].(string)
+
 	return service.VerifyTokenResp{
 		Valid:      true,
 		UserUID:    sub,
 		RoleUID:    roleUID,
+		OrgUID:     orgUID,
+		SessionUID: sid,
 		LastActive: time.Unix(int64(lat), 0).UTC(),
 		ExpiredAt:  time.Unix(int64(exp), 0).UTC(),
 	}, nil
 
 }
+
+// VerifyRefreshJWT reads the claims of a refresh token, the access tokens
+// being told apart by their role uid.
+func (jp *JWKPresenterImpl) VerifyRefreshJWT(ctx context.Context, payload map[string]interface{}) (resp service.RefreshTokenReq, err error) {
+
+	if _, ok := payload["role_uid"]; ok {
+		return resp, errors.New("not a refresh token")
+	}
+
+	sub, ok := payload["sub"].(string)
+	if !ok {
+		return resp, errors.New("subject is nil")
+	}
+
+	exp, ok := payload["exp"].(float64)
+	if !ok {
+		return resp, errors.New("expired unset")
+	}
+
+	orgUID, ok := payload["org_uid"].(string)
+	if !ok {
+		return resp, errors.New("organization uid is undefined")
+	}
+
+	if time.Now().After(time.Unix(int64(exp), 0)) {
+		return resp, errors.New("token expired")
+	}
+
+	sid, _ := payload["sid"].(string)
+
+	return service.RefreshTokenReq{
+		UserUID:    sub,
+		OrgUID:     orgUID,
+		SessionUID: sid,
+	}, nil
+}
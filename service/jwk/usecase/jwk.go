@@ -25,6 +25,7 @@ type JWKServiceImpl struct {
 
 type JWKService interface {
 	VerifyJWT(ctx context.Context, req service.VerifyTokenReq) (resp service.VerifyTokenResp, err error)
+	VerifyRefreshJWT(ctx context.Context, req service.VerifyTokenReq) (resp service.RefreshTokenReq, err error)
 }
 
 func NewJWKService(repository sql.RepositoryRegistry, cache cache.Cache, presenter presenter.JWKPresenter) JWKService {
@@ -37,31 +38,53 @@ func NewJWKService(repository sql.RepositoryRegistry, cache cache.Cache, present
 
 func (js *JWKServiceImpl) VerifyJWT(ctx context.Context, req service.VerifyTokenReq) (resp service.VerifyTokenResp, err error) {
 
-	jwkRepo := js.repo.JWKRepository()
+	payload, err := js.verifiedPayload(ctx, req.Token)
+	if err != nil {
+		return resp, err
+	}
+
+	return js.presenter.VerifyJWT(ctx, payload)
+}
+
+func (js *JWKServiceImpl) VerifyRefreshJWT(ctx context.Context, req service.VerifyTokenReq) (resp service.RefreshTokenReq, err error) {
 
-	token, err := util.SplitBearer(req.Token)
+	payload, err := js.verifiedPayload(ctx, req.Token)
 	if err != nil {
 		return resp, err
 	}
 
+	return js.presenter.VerifyRefreshJWT(ctx, payload)
+}
+
+// verifiedPayload checks the signature of the bearer token against the
+// unexpired keys and returns its claims.
+func (js *JWKServiceImpl) verifiedPayload(ctx context.Context, bearer string) (payload map[string]interface{}, err error) {
+
+	jwkRepo := js.repo.JWKRepository()
+
+	token, err := util.SplitBearer(bearer)
+	if err != nil {
+		return nil, err
+	}
+
 	b, err := base64.RawStdEncoding.DecodeString(strings.Split(token, ".")[0])
 	if err != nil {
-		return resp, err
+		return nil, err
 	}
 
 	headerToken := make(map[string]string)
 	if err := json.Unmarshal(b, &headerToken); err != nil {
-		return resp, err
+		return nil, err
 	}
 
 	kid, ok := headerToken["kid"]
 	if !ok {
-		return resp, fmt.Errorf("token key ID is missing %s", kid)
+		return nil, fmt.Errorf("token key ID is missing %s", kid)
 	}
 
 	object, err := jose.ParseSigned(token)
 	if err != nil {
-		return resp, err
+		return nil, err
 	}
 
 	keys, err := jwkRepo.ReadUnexpiredKeys(ctx)
@@ -70,7 +93,7 @@ func (js *JWKServiceImpl) VerifyJWT(ctx context.Context, req service.VerifyToken
 	}
 
 	if len(keys) <= 0 {
-		return resp, errors.New("key has expired")
+		return nil, errors.New("key has expired")
 	}
 
 	var key interface{}
@@ -89,20 +112,19 @@ func (js *JWKServiceImpl) VerifyJWT(ctx context.Context, req service.VerifyToken
 	}
 
 	if key == nil {
-		return resp, errors.New("key not found")
+		return nil, errors.New("key not found")
 	}
 
 	pb, err := object.Verify(key)
 	if err != nil {
 		log.Println("error verify object", err)
-		return resp, err
+		return nil, err
 	}
 
-	payload := make(map[string]interface{})
+	payload = make(map[string]interface{})
 	if err = json.Unmarshal(pb, &payload); err != nil {
-		return resp, err
+		return nil, err
 	}
 
-	return js.presenter.VerifyJWT(ctx, payload)
-
+	return payload, nil
 }
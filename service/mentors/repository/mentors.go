@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+)
+
+type MentorsRepository interface {
+	SearchMentors(ctx context.Context, req *model.SearchMentorsReq) (*model.SearchMentorsResp, error)
+	ReadMentorFacets(ctx context.Context, req *model.SearchMentorsReq) ([]*model.MentorFacet, error)
+}
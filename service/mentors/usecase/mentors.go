@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/blob"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	usersUsecase "github/yogabagas/join-app/service/users/usecase"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/shared/util"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 100
+)
+
+type MentorsServiceImpl struct {
+	repo sql.RepositoryRegistry
+	blob blob.BlobStore
+}
+
+type MentorsService interface {
+	SearchMentors(ctx context.Context, req service.SearchMentorsReq) (service.SearchMentorsResp, error)
+}
+
+func NewMentorsService(repository sql.RepositoryRegistry, blob blob.BlobStore) MentorsService {
+	return &MentorsServiceImpl{repo: repository, blob: blob}
+}
+
+func (ms *MentorsServiceImpl) SearchMentors(ctx context.Context, req service.SearchMentorsReq) (resp service.SearchMentorsResp, err error) {
+
+	search, err := searchOf(req)
+	if err != nil {
+		return resp, err
+	}
+
+	mentorsRepo := ms.repo.MentorsRepository()
+
+	mentors, err := mentorsRepo.SearchMentors(ctx, search)
+	if err != nil {
+		return resp, err
+	}
+
+	facets, err := mentorsRepo.ReadMentorFacets(ctx, search)
+	if err != nil {
+		return resp, err
+	}
+
+	userUIDs := []string{}
+	for _, v := range mentors.Mentors {
+		userUIDs = append(userUIDs, v.UID)
+	}
+
+	expertises, err := ms.repo.ExpertisesRepository().ReadUserExpertises(ctx, &model.ReadUserExpertisesReq{
+		UserUIDs: userUIDs,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	names := make(map[string][]string)
+	for _, v := range expertises {
+		names[v.UserUID] = append(names[v.UserUID], v.Name)
+	}
+
+	resp.Mentors = []service.MentorResp{}
+	for _, v := range mentors.Mentors {
+		photo, err := usersUsecase.PhotoOf(ctx, ms.blob, v.Photo)
+		if err != nil {
+			return resp, err
+		}
+
+		resp.Mentors = append(resp.Mentors, service.MentorResp{
+			UID:               v.UID,
+			Fullname:          fmt.Sprintf("%s %s", v.FirstName, v.LastName),
+			Bio:               v.Description,
+			Gender:            v.Gender,
+			Country:           v.Country,
+			Photo:             photo.URL,
+			PhotoThumbnails:   photo.Thumbnails,
+			Expertises:        append([]string{}, names[v.UID]...),
+			Languages:         append([]string{}, v.Languages...),
+			YearsOfExperience: v.YearsOfExperience,
+			Rating:            v.Rating,
+			RatingCount:       v.RatingCount,
+		})
+	}
+
+	resp.Facets = map[string][]service.FacetResp{
+		constant.FacetExpertise.String(): {},
+		constant.FacetCountry.String():   {},
+		constant.FacetLanguage.String():  {},
+		constant.FacetGender.String():    {},
+	}
+
+	for _, v := range facets {
+		resp.Facets[v.Field] = append(resp.Facets[v.Field], service.FacetResp{
+			Value: v.Value,
+			Label: facetLabel(v),
+			Count: v.Count,
+		})
+	}
+
+	resp.Pagination = service.Pagination{
+		Page:      req.Page,
+		PerPage:   search.Limit,
+		TotalPage: util.GetTotalPage(mentors.Total, search.Limit),
+		TotalData: mentors.Total,
+	}
+
+	return resp, nil
+}
+
+func searchOf(req service.SearchMentorsReq) (*model.SearchMentorsReq, error) {
+
+	search := &model.SearchMentorsReq{
+		RoleName:      constant.Mentor.String(),
+		Query:         util.FulltextQuery(req.Query),
+		ExpertiseUIDs: compact(req.ExpertiseUIDs, strings.TrimSpace),
+		Countries:     compact(req.Countries, strings.TrimSpace),
+		Languages:     compact(req.Languages, func(s string) string { return strings.ToLower(strings.TrimSpace(s)) }),
+		Gender:        req.Gender,
+		MinYears:      req.MinYears,
+		MaxYears:      req.MaxYears,
+		MinRating:     req.MinRating,
+		Sort:          req.Sort,
+		Limit:         req.Limit,
+	}
+
+	if search.Limit <= 0 {
+		search.Limit = defaultSearchLimit
+	} else if search.Limit > maxSearchLimit {
+		search.Limit = maxSearchLimit
+	}
+	search.Offset = util.PageToOffset(search.Limit, req.Page)
+
+	switch search.Sort {
+	case "":
+		search.Sort = constant.SortRating.String()
+		if search.Query != "" {
+			search.Sort = constant.SortRelevance.String()
+		}
+	case constant.SortRelevance.String():
+		if search.Query == "" {
+			return nil, errors.New("sorting by relevance needs a query")
+		}
+	case constant.SortRating.String(), constant.SortNewest.String():
+	default:
+		return nil, errors.New("sort must be one of relevance, rating or newest")
+	}
+
+	if g := search.Gender; g != nil && *g != constant.Female.Int() && *g != constant.Male.Int() {
+		return nil, fmt.Errorf("gender %d is unknown", *g)
+	}
+
+	if (search.MinYears != nil && *search.MinYears < 0) || (search.MaxYears != nil && *search.MaxYears < 0) {
+		return nil, errors.New("years of experience can't be negative")
+	}
+
+	if search.MinYears != nil && search.MaxYears != nil && *search.MinYears > *search.MaxYears {
+		return nil, errors.New("min_years can't be above max_years")
+	}
+
+	if search.MinRating != nil && *search.MinRating < 0 {
+		return nil, errors.New("min_rating can't be negative")
+	}
+
+	return search, nil
+}
+
+// compact normalizes the values and drops the empty ones and the repeats.
+func compact(values []string, normalize func(string) string) []string {
+
+	resp := []string{}
+	seen := make(map[string]bool)
+
+	for _, v := range values {
+		v = normalize(v)
+		if v != "" && !seen[v] {
+			seen[v] = true
+			resp = append(resp, v)
+		}
+	}
+
+	return resp
+}
+
+func facetLabel(facet *model.MentorFacet) string {
+
+	switch facet.Field {
+	case constant.FacetLanguage.String():
+		if name, ok := util.ISO639[facet.Value]; ok {
+			return name
+		}
+	case constant.FacetGender.String():
+		if g, err := strconv.Atoi(facet.Value); err == nil {
+			return constant.Gender(g).String()
+		}
+	}
+
+	return facet.Label
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+)
+
+type NotificationsRepository interface {
+	CreateNotification(ctx context.Context, req *model.Notification) error
+	ReadNotifications(ctx context.Context, req *model.ReadNotificationsReq) ([]*model.Notification, error)
+	MarkNotificationRead(ctx context.Context, req *model.MarkNotificationReadReq) (bool, error)
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/util"
+)
+
+type NotificationsServiceImpl struct {
+	repo sql.RepositoryRegistry
+}
+
+type NotificationsService interface {
+	GetNotifications(ctx context.Context, req service.GetNotificationsReq) ([]service.NotificationResp, error)
+	ReadNotification(ctx context.Context, req service.ReadNotificationReq) error
+}
+
+func NewNotificationsService(repository sql.RepositoryRegistry) NotificationsService {
+	return &NotificationsServiceImpl{
+		repo: repository,
+	}
+}
+
+func (ns *NotificationsServiceImpl) GetNotifications(ctx context.Context, req service.GetNotificationsReq) ([]service.NotificationResp, error) {
+
+	if req.Limit <= 0 {
+		req.Limit = util.DefaultListLimit
+	} else if req.Limit > util.MaxListLimit {
+		req.Limit = util.MaxListLimit
+	}
+
+	notifications, err := ns.repo.NotificationsRepository().ReadNotifications(ctx, &model.ReadNotificationsReq{
+		UserUID: req.UserUID,
+		Unread:  req.Unread,
+		Limit:   req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]service.NotificationResp, 0, len(notifications))
+	for _, v := range notifications {
+		resp = append(resp, service.NotificationResp{
+			UID:        v.UID,
+			Kind:       v.Kind,
+			SubjectUID: v.SubjectUID,
+			Payload:    json.RawMessage(v.Payload),
+			ReadAt:     v.ReadAt,
+			CreatedBy:  v.CreatedBy,
+			CreatedAt:  v.CreatedAt,
+		})
+	}
+
+	return resp, nil
+}
+
+func (ns *NotificationsServiceImpl) ReadNotification(ctx context.Context, req service.ReadNotificationReq) error {
+
+	ok, err := ns.repo.NotificationsRepository().MarkNotificationRead(ctx, &model.MarkNotificationReadReq{
+		UID:     req.UID,
+		UserUID: req.UserUID,
+	})
+	if err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf("%w: notification %s", service.ErrNotFound, req.UID)
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+)
+
+type OrganizationsRepository interface {
+	CreateOrganization(ctx context.Context, req *model.Organization) error
+	ReadOrganizations(ctx context.Context) ([]*model.Organization, error)
+	ReadOrganizationByKey(ctx context.Context, req *model.ReadOrganizationByKeyReq) (*model.Organization, error)
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/shared/util"
+	"regexp"
+	"strings"
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,98}[a-z0-9]$`)
+
+type OrganizationsServiceImpl struct {
+	repo sql.RepositoryRegistry
+}
+
+type OrganizationsService interface {
+	CreateOrganization(ctx context.Context, req service.CreateOrganizationReq) (service.OrganizationResp, error)
+	GetOrganizations(ctx context.Context) ([]service.OrganizationResp, error)
+	ResolveOrganization(ctx context.Context, req service.ResolveOrganizationReq) (service.OrganizationResp, error)
+}
+
+func NewOrganizationsService(repository sql.RepositoryRegistry) OrganizationsService {
+	return &OrganizationsServiceImpl{repo: repository}
+}
+
+func (os *OrganizationsServiceImpl) CreateOrganization(ctx context.Context, req service.CreateOrganizationReq) (resp service.OrganizationResp, err error) {
+
+	organizationsRepo := os.repo.OrganizationsRepository()
+
+	req.Slug = strings.ToLower(strings.TrimSpace(req.Slug))
+
+	if strings.TrimSpace(req.Name) == "" {
+		return resp, errors.New("name is required")
+	}
+
+	if !slugPattern.MatchString(req.Slug) {
+		return resp, errors.New("slug must be 3 to 100 lowercase letters, digits or dashes")
+	}
+
+	existing, err := organizationsRepo.ReadOrganizationByKey(ctx, &model.ReadOrganizationByKeyReq{
+		Key: req.Slug,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if existing != nil {
+		return resp, fmt.Errorf("%w: organization %s already exists", service.ErrConflict, req.Slug)
+	}
+
+	org := &model.Organization{
+		UID:       util.NewULIDGenerate(),
+		Name:      strings.TrimSpace(req.Name),
+		Slug:      req.Slug,
+		CreatedBy: req.CreatedBy,
+		UpdatedBy: req.CreatedBy,
+	}
+
+	if err = organizationsRepo.CreateOrganization(ctx, org); err != nil {
+		return resp, err
+	}
+
+	return service.OrganizationResp{
+		UID:  org.UID,
+		Name: org.Name,
+		Slug: org.Slug,
+	}, nil
+}
+
+// GetOrganizations lists every organization, across the tenants, for the jobs
+// and the CLI only.
+func (os *OrganizationsServiceImpl) GetOrganizations(ctx context.Context) (resp []service.OrganizationResp, err error) {
+
+	orgs, err := os.repo.OrganizationsRepository().ReadOrganizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = []service.OrganizationResp{}
+	for _, v := range orgs {
+		resp = append(resp, service.OrganizationResp{
+			UID:  v.UID,
+			Name: v.Name,
+			Slug: v.Slug,
+		})
+	}
+
+	return resp, nil
+}
+
+// ResolveOrganization finds an organization by uid or slug, falling back to
+// the default organization when key is empty.
+func (os *OrganizationsServiceImpl) ResolveOrganization(ctx context.Context, req service.ResolveOrganizationReq) (resp service.OrganizationResp, err error) {
+
+	key := strings.TrimSpace(req.Key)
+	if key == "" {
+		key = constant.DefaultOrganization
+	}
+
+	org, err := os.repo.OrganizationsRepository().ReadOrganizationByKey(ctx, &model.ReadOrganizationByKeyReq{
+		Key: key,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if org == nil {
+		return resp, fmt.Errorf("organization %s is not found", key)
+	}
+
+	return service.OrganizationResp{
+		UID:  org.UID,
+		Name: org.Name,
+		Slug: org.Slug,
+	}, nil
+}
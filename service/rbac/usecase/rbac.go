@@ -0,0 +1,418 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/cache"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	accessUsecase "github/yogabagas/join-app/service/access/usecase"
+	rolesUsecase "github/yogabagas/join-app/service/roles/usecase"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/shared/policy"
+	"github/yogabagas/join-app/shared/util"
+	"sort"
+	"strings"
+)
+
+const (
+	opAdd    = "add"
+	opRemove = "remove"
+	opUpdate = "update"
+)
+
+type RBACServiceImpl struct {
+	repo  sql.RepositoryRegistry
+	cache cache.Cache
+	local *cache.LocalCache
+}
+
+type RBACService interface {
+	ApplyRBAC(ctx context.Context, req service.ApplyRBACReq) (service.ApplyRBACResp, error)
+}
+
+func NewRBACService(repository sql.RepositoryRegistry, cache cache.Cache, local *cache.LocalCache) RBACService {
+	return &RBACServiceImpl{
+		repo:  repository,
+		cache: cache,
+		local: local,
+	}
+}
+
+// plan is the set of writes needed to bring the database in line with a
+// manifest, with the UIDs of to-be-created rows already allocated.
+type plan struct {
+	roles     []*model.Role
+	parents   []*model.UpdateRoleParentReq
+	resources []*model.Resource
+	grants    map[string][]*model.Access
+	resp      service.ApplyRBACResp
+}
+
+type resourceRef struct {
+	uid    string
+	name   string
+	typ    int
+	action string
+}
+
+func resourceKey(typ int, name, action string) string {
+	return fmt.Sprintf("%d|%s|%s", typ, name, action)
+}
+
+// ApplyRBAC diffs the manifest against the database and, unless running dry,
+// applies the difference in a single transaction. Roles and resources are
+// only ever created; grants of every role listed in the manifest are
+// reconciled so that they match it exactly.
+func (rs *RBACServiceImpl) ApplyRBAC(ctx context.Context, req service.ApplyRBACReq) (resp service.ApplyRBACResp, err error) {
+
+	p, err := rs.plan(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	resp = p.resp
+
+	if req.DryRun || (len(resp.Roles) == 0 && len(resp.Resources) == 0 && len(resp.Grants) == 0) {
+		return resp, nil
+	}
+
+	var InTransaction = func(rr sql.RepositoryRegistry) (out interface{}, err error) {
+
+		rolesRepo := rr.RolesRepository()
+		resourcesRepo := rr.ResourcesRepository()
+
+		for _, role := range p.roles {
+			if err = rolesRepo.CreateRoles(ctx, role); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, parent := range p.parents {
+			if err = rolesRepo.UpdateRoleParent(ctx, parent); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, resource := range p.resources {
+			if err = resourcesRepo.CreateResources(ctx, resource); err != nil {
+				return nil, err
+			}
+		}
+
+		for roleUID, access := range p.grants {
+			if err = accessUsecase.ReplaceAccess(ctx, rr, roleUID, access, "rbac apply", req.CreatedBy); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	}
+
+	if _, err = rs.repo.DoInTransaction(ctx, InTransaction); err != nil {
+		return resp, err
+	}
+
+	resp.Applied = true
+
+	orgUID, err := sql.TenantFromContext(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, cache.Invalidate(ctx, rs.cache, rs.local,
+		fmt.Sprintf(constant.MenuResourcePattern.String(), orgUID),
+		constant.AllRoleMenuPattern.String())
+}
+
+func (rs *RBACServiceImpl) plan(ctx context.Context, req service.ApplyRBACReq) (*plan, error) {
+
+	rolesRepo := rs.repo.RolesRepository()
+	resourcesRepo := rs.repo.ResourcesRepository()
+	accessRepo := rs.repo.AccessRepository()
+
+	p := &plan{grants: make(map[string][]*model.Access)}
+
+	existingRoles, err := rolesRepo.ReadRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roleUIDs := make(map[string]string, len(existingRoles))
+	hierarchy := rolesUsecase.NewHierarchy(existingRoles)
+	for _, v := range existingRoles {
+		roleUIDs[strings.ToLower(v.Name)] = v.UID
+	}
+
+	created := make(map[string]bool)
+
+	for _, v := range req.Manifest.Roles {
+		name := strings.ToLower(strings.TrimSpace(v.Name))
+		if name == "" {
+			return nil, fmt.Errorf("role name is required")
+		}
+
+		if _, ok := roleUIDs[name]; ok {
+			continue
+		}
+
+		role := &model.Role{
+			UID:       util.NewULIDGenerate(),
+			Name:      name,
+			CreatedBy: req.CreatedBy,
+			UpdatedBy: req.CreatedBy,
+		}
+		roleUIDs[name] = role.UID
+		hierarchy[role.UID] = &model.Role{UID: role.UID, Name: name}
+		created[name] = true
+
+		p.roles = append(p.roles, role)
+	}
+
+	// New roles are created as roots and attached to their parent afterwards,
+	// so a parent declared after its children is never referenced too early.
+	for _, v := range req.Manifest.Roles {
+		name := strings.ToLower(strings.TrimSpace(v.Name))
+		parent := strings.ToLower(strings.TrimSpace(v.Parent))
+		role := hierarchy[roleUIDs[name]]
+
+		var parentUID string
+		if parent != "" {
+			uid, ok := roleUIDs[parent]
+			if !ok {
+				return nil, fmt.Errorf("role %q has undeclared parent %q", v.Name, v.Parent)
+			}
+			parentUID = uid
+		}
+
+		if created[name] {
+			p.resp.Roles = append(p.resp.Roles, service.RBACRoleChange{Op: opAdd, Name: name, Parent: parent})
+		} else if role.ParentUID != parentUID {
+			p.resp.Roles = append(p.resp.Roles, service.RBACRoleChange{Op: opUpdate, Name: name, Parent: parent})
+		} else {
+			continue
+		}
+
+		if parentUID != "" || !created[name] {
+			p.parents = append(p.parents, &model.UpdateRoleParentReq{
+				UID:       role.UID,
+				ParentUID: parentUID,
+				UpdatedBy: req.CreatedBy,
+			})
+		}
+
+		hierarchy[role.UID] = &model.Role{UID: role.UID, Name: role.Name, ParentUID: parentUID}
+	}
+
+	if err = hierarchy.Validate(); err != nil {
+		return nil, err
+	}
+
+	existingResources, err := resourcesRepo.ReadResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make(map[string]resourceRef, len(existingResources))
+	resourcesByUID := make(map[string]resourceRef, len(existingResources))
+	for _, v := range existingResources {
+		ref := resourceRef{uid: v.UID, name: v.Name, typ: v.Type, action: v.Action}
+		resources[resourceKey(v.Type, v.Name, v.Action)] = ref
+		resourcesByUID[v.UID] = ref
+	}
+
+	var walk func(nodes []service.RBACResource, parent *resourceRef) error
+	walk = func(nodes []service.RBACResource, parent *resourceRef) error {
+		for _, v := range nodes {
+			typ := constant.ResourceTypeAtoi(v.Type)
+			if typ == 0 {
+				return fmt.Errorf("resource %q has unknown type %q, expected menu or api", v.Name, v.Type)
+			}
+
+			if v.Name == "" || v.Action == "" {
+				return fmt.Errorf("resource name and action are required")
+			}
+
+			key := resourceKey(typ.Int(), v.Name, v.Action)
+
+			ref, ok := resources[key]
+			if !ok {
+				ref = resourceRef{uid: util.NewULIDGenerate(), name: v.Name, typ: typ.Int(), action: v.Action}
+
+				resource := &model.Resource{
+					UID:       ref.uid,
+					Name:      v.Name,
+					Type:      typ.Int(),
+					Action:    v.Action,
+					CreatedBy: req.CreatedBy,
+					UpdatedBy: req.CreatedBy,
+				}
+
+				change := service.RBACResourceChange{Name: v.Name, Type: v.Type, Action: v.Action}
+				if parent != nil {
+					resource.ParentUID = parent.uid
+					change.Parent = parent.name
+				}
+
+				resources[key] = ref
+				resourcesByUID[ref.uid] = ref
+
+				p.resources = append(p.resources, resource)
+				p.resp.Resources = append(p.resp.Resources, change)
+			}
+
+			if err := walk(v.Children, &ref); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err = walk(req.Manifest.Resources, nil); err != nil {
+		return nil, err
+	}
+
+	lookup := func(v service.RBACGrantResource) (resourceRef, error) {
+		if v.Type != "" {
+			typ := constant.ResourceTypeAtoi(v.Type)
+			ref, ok := resources[resourceKey(typ.Int(), v.Name, v.Action)]
+			if !ok {
+				return ref, fmt.Errorf("resource %s %q (%s) is not declared", v.Type, v.Name, v.Action)
+			}
+			return ref, nil
+		}
+
+		var found []resourceRef
+		for _, ref := range resources {
+			if ref.name == v.Name && ref.action == v.Action {
+				found = append(found, ref)
+			}
+		}
+
+		switch len(found) {
+		case 0:
+			return resourceRef{}, fmt.Errorf("resource %q (%s) is not declared", v.Name, v.Action)
+		case 1:
+			return found[0], nil
+		default:
+			return resourceRef{}, fmt.Errorf("resource %q (%s) is ambiguous, set its type", v.Name, v.Action)
+		}
+	}
+
+	desired := make(map[string]map[string]string)
+	roleNames := make(map[string]string)
+
+	for _, grant := range req.Manifest.Grants {
+		name := strings.ToLower(strings.TrimSpace(grant.Role))
+
+		roleUID, ok := roleUIDs[name]
+		if !ok {
+			return nil, fmt.Errorf("grant refers to undeclared role %q", grant.Role)
+		}
+
+		if _, ok := desired[roleUID]; ok {
+			return nil, fmt.Errorf("role %q is granted more than once", grant.Role)
+		}
+
+		roleNames[roleUID] = name
+		desired[roleUID] = make(map[string]string)
+
+		for _, v := range grant.Resources {
+			ref, err := lookup(v)
+			if err != nil {
+				return nil, fmt.Errorf("role %q: %w", grant.Role, err)
+			}
+
+			if v.Condition != "" {
+				if _, err := policy.Compile(v.Condition); err != nil {
+					return nil, fmt.Errorf("role %q, resource %q: invalid condition: %w", grant.Role, v.Name, err)
+				}
+			}
+
+			desired[roleUID][ref.uid] = v.Condition
+		}
+	}
+
+	roleList := make([]string, 0, len(desired))
+	for roleUID := range desired {
+		roleList = append(roleList, roleUID)
+	}
+	sort.Slice(roleList, func(i, j int) bool {
+		return roleNames[roleList[i]] < roleNames[roleList[j]]
+	})
+
+	current, err := accessRepo.ReadAccessByRoleUIDs(ctx, &model.ReadAccessByRoleUIDsReq{
+		RoleUIDs: roleList,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	granted := make(map[string]map[string]string)
+	for _, v := range current {
+		if granted[v.RoleUID] == nil {
+			granted[v.RoleUID] = make(map[string]string)
+		}
+		granted[v.RoleUID][v.ResourceUID] = v.Condition
+	}
+
+	change := func(op, roleUID, resourceUID, condition string) service.RBACGrantChange {
+		ref := resourcesByUID[resourceUID]
+		return service.RBACGrantChange{
+			Op:        op,
+			Role:      roleNames[roleUID],
+			Resource:  ref.name,
+			Type:      constant.ResourcesType(ref.typ).String(),
+			Action:    ref.action,
+			Condition: condition,
+		}
+	}
+
+	for _, roleUID := range roleList {
+		var changes []service.RBACGrantChange
+
+		for resourceUID, condition := range desired[roleUID] {
+			currentCondition, ok := granted[roleUID][resourceUID]
+			switch {
+			case !ok:
+				changes = append(changes, change(opAdd, roleUID, resourceUID, condition))
+			case currentCondition != condition:
+				changes = append(changes, change(opUpdate, roleUID, resourceUID, condition))
+			}
+		}
+
+		for resourceUID, condition := range granted[roleUID] {
+			if _, ok := desired[roleUID][resourceUID]; !ok {
+				changes = append(changes, change(opRemove, roleUID, resourceUID, condition))
+			}
+		}
+
+		if len(changes) == 0 {
+			continue
+		}
+
+		sort.Slice(changes, func(i, j int) bool {
+			if changes[i].Resource != changes[j].Resource {
+				return changes[i].Resource < changes[j].Resource
+			}
+			return changes[i].Action < changes[j].Action
+		})
+		p.resp.Grants = append(p.resp.Grants, changes...)
+
+		access := make([]*model.Access, 0, len(desired[roleUID]))
+		for resourceUID, condition := range desired[roleUID] {
+			access = append(access, &model.Access{
+				UID:         util.NewULIDGenerate(),
+				RoleUID:     roleUID,
+				ResourceUID: resourceUID,
+				Condition:   condition,
+				CreatedBy:   req.CreatedBy,
+				UpdatedBy:   req.CreatedBy,
+			})
+		}
+		p.grants[roleUID] = access
+	}
+
+	return p, nil
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"github/yogabagas/join-app/domain/model"
 	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/util"
 )
 
 type ResourcesPresenterImpl struct{}
 
 type ResourcesPresenter interface {
 	GetResourcesByType(ctx context.Context, req []*model.ReadResourcesByTypeResp) ([]service.GetResourcesByTypeResp, error)
+	GetResources(ctx context.Context, req *model.ListReq, resources *model.ReadResourcesWithPaginationResp) (service.GetResourcesResp, error)
 }
 
 func NewResourcesPresenter() ResourcesPresenter {
@@ -57,3 +59,22 @@ func (rp *ResourcesPresenterImpl) GetResourcesByType(ctx context.Context, req []
 	}
 	return
 }
+
+func (rp *ResourcesPresenterImpl) GetResources(ctx context.Context, req *model.ListReq,
+	resources *model.ReadResourcesWithPaginationResp) (resp service.GetResourcesResp, err error) {
+
+	resp.Resources = []service.ResourceResp{}
+	for _, v := range resources.Resources {
+		resp.Resources = append(resp.Resources, service.ResourceResp{
+			UID:       v.UID,
+			Name:      v.Name,
+			Type:      v.Type,
+			Action:    v.Action,
+			ParentUID: v.ParentUID,
+			CreatedAt: v.CreatedAt,
+		})
+	}
+	resp.Pagination = util.PaginationOf(req, resources.Page)
+
+	return resp, nil
+}
@@ -8,4 +8,8 @@ import (
 type ResourcesRepository interface {
 	CreateResources(ctx context.Context, req *model.Resource) error
 	ReadResourcesByType(ctx context.Context, req *model.ReadResourcesByTypeReq) ([]*model.ReadResourcesByTypeResp, error)
+	ReadResources(ctx context.Context) ([]*model.Resource, error)
+	ReadResourcesFlatByType(ctx context.Context, req *model.ReadResourcesByTypeReq) ([]*model.Resource, error)
+	ReadResourcesByKey(ctx context.Context, req *model.ReadResourcesByKeyReq) ([]*model.Resource, error)
+	ReadResourcesWithPagination(ctx context.Context, req *model.ListReq) (*model.ReadResourcesWithPaginationResp, error)
 }
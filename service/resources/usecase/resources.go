@@ -3,16 +3,20 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"github/yogabagas/join-app/config"
 	"github/yogabagas/join-app/domain/model"
 	"github/yogabagas/join-app/domain/repository/cache"
 	"github/yogabagas/join-app/domain/repository/sql"
 	"github/yogabagas/join-app/domain/service"
 	"github/yogabagas/join-app/service/resources/presenter"
+	"github/yogabagas/join-app/shared/constant"
 	"github/yogabagas/join-app/shared/util"
+	"log"
 )
 
 type ResourcesServiceImpl struct {
 	cache     cache.Cache
+	local     *cache.LocalCache
 	repo      sql.RepositoryRegistry
 	presenter presenter.ResourcesPresenter
 }
@@ -20,11 +24,14 @@ type ResourcesServiceImpl struct {
 type ResourcesService interface {
 	CreateResources(ctx context.Context, req service.CreateResourcesReq) error
 	GetResourcesByType(ctx context.Context, req service.GetResourcesByTypeReq) ([]service.GetResourcesByTypeResp, error)
+	SyncAPIResources(ctx context.Context, req service.SyncAPIResourcesReq) (service.SyncAPIResourcesResp, error)
+	GetResources(ctx context.Context, req service.ListReq) (service.GetResourcesResp, error)
 }
 
-func NewResourcesService(cache cache.Cache, repository sql.RepositoryRegistry, presenter presenter.ResourcesPresenter) ResourcesService {
+func NewResourcesService(cache cache.Cache, local *cache.LocalCache, repository sql.RepositoryRegistry, presenter presenter.ResourcesPresenter) ResourcesService {
 	return &ResourcesServiceImpl{
 		cache:     cache,
+		local:     local,
 		repo:      repository,
 		presenter: presenter}
 }
@@ -35,7 +42,7 @@ func (rs *ResourcesServiceImpl) CreateResources(ctx context.Context, req service
 
 	uID := util.NewULIDGenerate()
 
-	return resourcesRepo.CreateResources(ctx, &model.Resource{
+	err := resourcesRepo.CreateResources(ctx, &model.Resource{
 		UID:       uID,
 		Name:      req.Name,
 		Type:      req.Type,
@@ -44,15 +51,45 @@ func (rs *ResourcesServiceImpl) CreateResources(ctx context.Context, req service
 		CreatedBy: req.CreatedBy,
 		UpdatedBy: req.CreatedBy,
 	})
+	if err != nil {
+		return err
+	}
+
+	return rs.invalidateTrees(ctx)
+}
+
+// invalidateTrees drops every cached resource tree of the organization, the
+// per role menus included, since a new node can appear under any of them.
+func (rs *ResourcesServiceImpl) invalidateTrees(ctx context.Context) error {
+
+	orgUID, err := sql.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cache.Invalidate(ctx, rs.cache, rs.local,
+		fmt.Sprintf(constant.MenuResourcePattern.String(), orgUID),
+		constant.AllRoleMenuPattern.String())
 }
 
 func (rs *ResourcesServiceImpl) GetResourcesByType(ctx context.Context, req service.GetResourcesByTypeReq) (resp []service.GetResourcesByTypeResp, err error) {
 
 	resourcesRepo := rs.repo.ResourcesRepository()
 
-	keyCache := fmt.Sprintf("resources::type:%d", req.Type)
+	orgUID, err := sql.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keyCache := fmt.Sprintf(constant.MenuResource.String(), orgUID, req.Type)
+
+	if v, ok := rs.local.Get(keyCache); ok {
+		return v.([]service.GetResourcesByTypeResp), nil
+	}
+
 	err = rs.cache.GetObject(ctx, keyCache, &resp)
 	if err == nil {
+		rs.local.Set(keyCache, resp)
 		return
 	}
 
@@ -68,7 +105,105 @@ func (rs *ResourcesServiceImpl) GetResourcesByType(ctx context.Context, req serv
 		return resp, err
 	}
 
-	go rs.cache.Set(ctx, keyCache, resp, 0)
+	if err = rs.cache.Set(ctx, keyCache, resp, config.GlobalCfg.Cache.TTL); err != nil {
+		log.Println("error set cache resources", err)
+	}
+
+	rs.local.Set(keyCache, resp)
 
 	return resp, nil
 }
+
+// SyncAPIResources registers an API resource for every route that has none yet
+// and reports API resources whose route no longer exists.
+func (rs *ResourcesServiceImpl) SyncAPIResources(ctx context.Context, req service.SyncAPIResourcesReq) (resp service.SyncAPIResourcesResp, err error) {
+
+	resourcesRepo := rs.repo.ResourcesRepository()
+
+	existing, err := resourcesRepo.ReadResourcesFlatByType(ctx, &model.ReadResourcesByTypeReq{
+		Type: constant.API.Int(),
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	routeKey := func(path, method string) string {
+		return method + " " + path
+	}
+
+	registered := make(map[string]bool, len(existing))
+	for _, v := range existing {
+		registered[routeKey(v.Name, v.Action)] = true
+	}
+
+	routes := make(map[string]bool, len(req.Routes))
+	for _, route := range req.Routes {
+		key := routeKey(route.Path, route.Method)
+		if routes[key] {
+			continue
+		}
+		routes[key] = true
+
+		if registered[key] {
+			resp.Unchanged++
+			continue
+		}
+		resp.Created = append(resp.Created, route)
+	}
+
+	for _, v := range existing {
+		if !routes[routeKey(v.Name, v.Action)] {
+			resp.Orphaned = append(resp.Orphaned, service.OrphanedResource{
+				UID:    v.UID,
+				Path:   v.Name,
+				Method: v.Action,
+			})
+		}
+	}
+
+	if req.DryRun || len(resp.Created) == 0 {
+		return resp, nil
+	}
+
+	var InTransaction = func(rr sql.RepositoryRegistry) (out interface{}, err error) {
+
+		resourcesRepo := rr.ResourcesRepository()
+
+		for _, route := range resp.Created {
+			err = resourcesRepo.CreateResources(ctx, &model.Resource{
+				UID:       util.NewULIDGenerate(),
+				Name:      route.Path,
+				Type:      constant.API.Int(),
+				Action:    route.Method,
+				CreatedBy: req.CreatedBy,
+				UpdatedBy: req.CreatedBy,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	}
+
+	if _, err = rs.repo.DoInTransaction(ctx, InTransaction); err != nil {
+		return resp, err
+	}
+
+	return resp, rs.invalidateTrees(ctx)
+}
+
+func (rs *ResourcesServiceImpl) GetResources(ctx context.Context, req service.ListReq) (resp service.GetResourcesResp, err error) {
+
+	list, err := util.ListReqOf(req)
+	if err != nil {
+		return resp, err
+	}
+
+	resources, err := rs.repo.ResourcesRepository().ReadResourcesWithPagination(ctx, list)
+	if err != nil {
+		return resp, err
+	}
+
+	return rs.presenter.GetResources(ctx, list, resources)
+}
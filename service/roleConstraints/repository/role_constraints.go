@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+)
+
+type RoleConstraintsRepository interface {
+	CreateRoleConstraint(ctx context.Context, req *model.RoleConstraint) error
+	ReadRoleConstraints(ctx context.Context) ([]*model.RoleConstraint, error)
+	DeleteRoleConstraint(ctx context.Context, req *model.DeleteRoleConstraintReq) error
+}
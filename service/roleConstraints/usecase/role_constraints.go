@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/cache"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/util"
+	"log"
+	"strings"
+)
+
+type RoleConstraintsServiceImpl struct {
+	repo  sql.RepositoryRegistry
+	cache cache.Cache
+}
+
+type RoleConstraintsService interface {
+	CreateRoleConstraint(ctx context.Context, req service.CreateRoleConstraintReq) (service.RoleConstraintResp, error)
+	GetRoleConstraints(ctx context.Context) ([]service.RoleConstraintResp, error)
+	DeleteRoleConstraint(ctx context.Context, req service.DeleteRoleConstraintReq) error
+	GetRoleConstraintViolations(ctx context.Context) ([]service.RoleConstraintViolationResp, error)
+}
+
+func NewRoleConstraintsService(repository sql.RepositoryRegistry, cache cache.Cache) RoleConstraintsService {
+	return &RoleConstraintsServiceImpl{repo: repository, cache: cache}
+}
+
+// CreateRoleConstraint declares a set of mutually exclusive roles. Users who
+// already hold several of them are not touched and show up in the violations
+// report instead.
+func (rs *RoleConstraintsServiceImpl) CreateRoleConstraint(ctx context.Context, req service.CreateRoleConstraintReq) (resp service.RoleConstraintResp, err error) {
+
+	if strings.TrimSpace(req.Name) == "" {
+		return resp, errors.New("name is required")
+	}
+
+	roleUIDs := []string{}
+	seen := make(map[string]bool)
+	for _, v := range req.RoleUIDs {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			roleUIDs = append(roleUIDs, v)
+		}
+	}
+
+	if len(roleUIDs) < 2 {
+		return resp, errors.New("a constraint needs at least two distinct roles")
+	}
+
+	roles, err := rs.repo.RolesRepository().ReadRoles(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	known := make(map[string]bool, len(roles))
+	for _, v := range roles {
+		known[v.UID] = true
+	}
+
+	for _, v := range roleUIDs {
+		if !known[v] {
+			return resp, fmt.Errorf("role %s is not found", v)
+		}
+	}
+
+	constraint := &model.RoleConstraint{
+		UID:       util.NewULIDGenerate(),
+		Name:      strings.TrimSpace(req.Name),
+		RoleUIDs:  roleUIDs,
+		CreatedBy: req.CreatedBy,
+		UpdatedBy: req.CreatedBy,
+	}
+
+	_, err = rs.repo.DoInTransaction(ctx, func(rr sql.RepositoryRegistry) (interface{}, error) {
+		return nil, rr.RoleConstraintsRepository().CreateRoleConstraint(ctx, constraint)
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	rs.revokePrincipals(ctx)
+
+	return service.RoleConstraintResp{
+		UID:      constraint.UID,
+		Name:     constraint.Name,
+		RoleUIDs: constraint.RoleUIDs,
+	}, nil
+}
+
+func (rs *RoleConstraintsServiceImpl) GetRoleConstraints(ctx context.Context) (resp []service.RoleConstraintResp, err error) {
+
+	constraints, err := rs.repo.RoleConstraintsRepository().ReadRoleConstraints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = []service.RoleConstraintResp{}
+	for _, v := range constraints {
+		resp = append(resp, service.RoleConstraintResp{
+			UID:      v.UID,
+			Name:     v.Name,
+			RoleUIDs: v.RoleUIDs,
+		})
+	}
+
+	return resp, nil
+}
+
+func (rs *RoleConstraintsServiceImpl) DeleteRoleConstraint(ctx context.Context, req service.DeleteRoleConstraintReq) error {
+
+	err := rs.repo.RoleConstraintsRepository().DeleteRoleConstraint(ctx, &model.DeleteRoleConstraintReq{
+		UID:       req.UID,
+		UpdatedBy: req.UpdatedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	rs.revokePrincipals(ctx)
+
+	return nil
+}
+
+// revokePrincipals drops the principals of every user of the organization,
+// whose separation of duties verdict the constraints changed. The principals
+// expire on their own shortly, so a failure is only logged.
+func (rs *RoleConstraintsServiceImpl) revokePrincipals(ctx context.Context) {
+
+	orgUID, err := sql.TenantFromContext(ctx)
+	if err == nil {
+		err = cache.RevokeOrganizationPrincipals(ctx, rs.cache, orgUID)
+	}
+
+	if err != nil {
+		log.Println("error revoke principals of organization", orgUID, err)
+	}
+}
+
+// GetRoleConstraintViolations reports the users whose current or upcoming
+// grants break a constraint, typically because they predate it.
+func (rs *RoleConstraintsServiceImpl) GetRoleConstraintViolations(ctx context.Context) (resp []service.RoleConstraintViolationResp, err error) {
+
+	constraints, err := rs.repo.RoleConstraintsRepository().ReadRoleConstraints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = []service.RoleConstraintViolationResp{}
+
+	if len(constraints) == 0 {
+		return resp, nil
+	}
+
+	grants, err := rs.repo.AuthzRepository().ReadUnexpiredAuthz(ctx, &model.ReadUnexpiredAuthzReq{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range Separation(constraints).Violations(grants) {
+		violation := service.RoleConstraintViolationResp{
+			ConstraintUID:  v.Constraint.UID,
+			ConstraintName: v.Constraint.Name,
+			UserUID:        v.UserUID,
+		}
+
+		seen := make(map[string]bool)
+		for _, g := range v.Grants {
+			violation.AuthzUIDs = append(violation.AuthzUIDs, g.UID)
+			if !seen[g.RoleUID] {
+				seen[g.RoleUID] = true
+				violation.RoleUIDs = append(violation.RoleUIDs, g.RoleUID)
+			}
+		}
+
+		resp = append(resp, violation)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"github/yogabagas/join-app/domain/model"
+)
+
+// Separation holds the separation of duties constraints of an organization.
+type Separation []*model.RoleConstraint
+
+// Conflict returns the constraint grant would break if it were added to held,
+// or nil. Only grants whose validity windows overlap the new one count, so a
+// role may follow an exclusive one once it has expired.
+func (s Separation) Conflict(held []*model.Authz, grant *model.Authz) *model.RoleConstraint {
+	for _, c := range s {
+		if !c.Contains(grant.RoleUID) {
+			continue
+		}
+
+		for _, v := range held {
+			if v.RoleUID != grant.RoleUID && c.Contains(v.RoleUID) && overlaps(v, grant) {
+				return c
+			}
+		}
+	}
+
+	return nil
+}
+
+// Violations lists, per constraint and user, the grants that overlap with a
+// grant of another role of the same constraint.
+func (s Separation) Violations(grants []*model.Authz) (resp []*model.RoleConstraintViolation) {
+
+	byUser := make(map[string][]*model.Authz)
+	users := []string{}
+	for _, v := range grants {
+		if _, ok := byUser[v.UserUID]; !ok {
+			users = append(users, v.UserUID)
+		}
+		byUser[v.UserUID] = append(byUser[v.UserUID], v)
+	}
+
+	for _, c := range s {
+		for _, user := range users {
+			held := byUser[user]
+			clashing := make(map[int]bool)
+
+			for i := range held {
+				for j := i + 1; j < len(held); j++ {
+					a, b := held[i], held[j]
+					if a.RoleUID != b.RoleUID && c.Contains(a.RoleUID) && c.Contains(b.RoleUID) && overlaps(a, b) {
+						clashing[i], clashing[j] = true, true
+					}
+				}
+			}
+
+			if len(clashing) == 0 {
+				continue
+			}
+
+			violation := &model.RoleConstraintViolation{Constraint: c, UserUID: user}
+			for i, v := range held {
+				if clashing[i] {
+					violation.Grants = append(violation.Grants, v)
+				}
+			}
+			resp = append(resp, violation)
+		}
+	}
+
+	return resp
+}
+
+// overlaps reports whether the validity windows of a and b intersect, an
+// unset bound being open ended.
+func overlaps(a, b *model.Authz) bool {
+	return (a.ValidUntil == nil || b.ValidFrom == nil || b.ValidFrom.Before(*a.ValidUntil)) &&
+		(b.ValidUntil == nil || a.ValidFrom == nil || a.ValidFrom.Before(*b.ValidUntil))
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"github/yogabagas/join-app/domain/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testEpoch = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// at is testEpoch shifted by hours.
+func at(hours int) *time.Time {
+	t := testEpoch.Add(time.Duration(hours) * time.Hour)
+	return &t
+}
+
+// grant is a grant of roleUID to userUID valid in [from, until), a nil bound
+// being open ended.
+func grant(uid, userUID, roleUID string, from, until *time.Time) *model.Authz {
+	return &model.Authz{UID: uid, UserUID: userUID, RoleUID: roleUID, ValidFrom: from, ValidUntil: until}
+}
+
+func TestOverlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *model.Authz
+		want bool
+	}{
+		{name: "both open ended", a: grant("a", "u1", "r1", nil, nil), b: grant("b", "u1", "r2", nil, nil), want: true},
+		{name: "inside an open window", a: grant("a", "u1", "r1", nil, nil), b: grant("b", "u1", "r2", at(1), at(2)), want: true},
+		{name: "intersecting", a: grant("a", "u1", "r1", at(0), at(2)), b: grant("b", "u1", "r2", at(1), at(3)), want: true},
+		{name: "contained", a: grant("a", "u1", "r1", at(0), at(4)), b: grant("b", "u1", "r2", at(1), at(2)), want: true},
+		{name: "ending as the other starts", a: grant("a", "u1", "r1", at(0), at(1)), b: grant("b", "u1", "r2", at(1), at(2))},
+		{name: "starting as the other ends", a: grant("a", "u1", "r1", at(1), at(2)), b: grant("b", "u1", "r2", at(0), at(1))},
+		{name: "before", a: grant("a", "u1", "r1", at(0), at(1)), b: grant("b", "u1", "r2", at(2), at(3))},
+		{name: "after", a: grant("a", "u1", "r1", at(2), at(3)), b: grant("b", "u1", "r2", at(0), at(1))},
+		{name: "open start before an open end", a: grant("a", "u1", "r1", nil, at(2)), b: grant("b", "u1", "r2", at(1), nil), want: true},
+		{name: "open start ending before an open end", a: grant("a", "u1", "r1", nil, at(1)), b: grant("b", "u1", "r2", at(1), nil)},
+		{name: "open end starting after an open start", a: grant("a", "u1", "r1", at(2), nil), b: grant("b", "u1", "r2", nil, at(2))},
+		{name: "upcoming against open ended", a: grant("a", "u1", "r1", nil, nil), b: grant("b", "u1", "r2", at(100), nil), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overlaps(tt.a, tt.b); got != tt.want {
+				t.Fatalf("overlaps() = %v, want %v", got, tt.want)
+			}
+			if got := overlaps(tt.b, tt.a); got != tt.want {
+				t.Fatalf("overlaps() swapped = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeparationConflict(t *testing.T) {
+	duties := &model.RoleConstraint{UID: "c1", Name: "duties", RoleUIDs: []string{"admin", "auditor"}}
+	payments := &model.RoleConstraint{UID: "c2", Name: "payments", RoleUIDs: []string{"payer", "approver"}}
+	separation := Separation{duties, payments}
+
+	tests := []struct {
+		name  string
+		held  []*model.Authz
+		grant *model.Authz
+		want  *model.RoleConstraint
+	}{
+		{name: "nothing held", grant: grant("g", "u1", "admin", nil, nil)},
+		{
+			name:  "role outside the constraints",
+			held:  []*model.Authz{grant("h", "u1", "admin", nil, nil)},
+			grant: grant("g", "u1", "mentor", nil, nil),
+		},
+		{
+			name:  "same role again",
+			held:  []*model.Authz{grant("h", "u1", "admin", nil, nil)},
+			grant: grant("g", "u1", "admin", at(1), at(2)),
+		},
+		{
+			name:  "held role outside the constraint",
+			held:  []*model.Authz{grant("h", "u1", "mentor", nil, nil)},
+			grant: grant("g", "u1", "admin", nil, nil),
+		},
+		{
+			name:  "exclusive role held open ended",
+			held:  []*model.Authz{grant("h", "u1", "auditor", nil, nil)},
+			grant: grant("g", "u1", "admin", at(1), at(2)),
+			want:  duties,
+		},
+		{
+			name:  "exclusive role held in an overlapping window",
+			held:  []*model.Authz{grant("h", "u1", "auditor", at(0), at(2))},
+			grant: grant("g", "u1", "admin", at(1), nil),
+			want:  duties,
+		},
+		{
+			name:  "exclusive role expiring as the grant starts",
+			held:  []*model.Authz{grant("h", "u1", "auditor", nil, at(1))},
+			grant: grant("g", "u1", "admin", at(1), nil),
+		},
+		{
+			name:  "exclusive role starting as the grant ends",
+			held:  []*model.Authz{grant("h", "u1", "auditor", at(2), nil)},
+			grant: grant("g", "u1", "admin", nil, at(2)),
+		},
+		{
+			name:  "upcoming exclusive role against an open grant",
+			held:  []*model.Authz{grant("h", "u1", "auditor", at(100), at(101))},
+			grant: grant("g", "u1", "admin", nil, nil),
+			want:  duties,
+		},
+		{
+			name:  "second constraint",
+			held:  []*model.Authz{grant("h1", "u1", "auditor", nil, at(1)), grant("h2", "u1", "approver", nil, nil)},
+			grant: grant("g", "u1", "payer", at(1), nil),
+			want:  payments,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := separation.Conflict(tt.held, tt.grant); got != tt.want {
+				t.Fatalf("Conflict() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeparationViolations(t *testing.T) {
+	duties := &model.RoleConstraint{UID: "c1", Name: "duties", RoleUIDs: []string{"admin", "auditor"}}
+	payments := &model.RoleConstraint{UID: "c2", Name: "payments", RoleUIDs: []string{"payer", "approver"}}
+	separation := Separation{duties, payments}
+
+	// violation is the constraint, the user and the uids of the grants.
+	type violation struct {
+		constraint string
+		user       string
+		grants     []string
+	}
+
+	tests := []struct {
+		name   string
+		grants []*model.Authz
+		want   []violation
+	}{
+		{name: "no grant"},
+		{
+			name:   "same role twice",
+			grants: []*model.Authz{grant("a", "u1", "admin", nil, nil), grant("b", "u1", "admin", nil, nil)},
+		},
+		{
+			name:   "exclusive roles held by different users",
+			grants: []*model.Authz{grant("a", "u1", "admin", nil, nil), grant("b", "u2", "auditor", nil, nil)},
+		},
+		{
+			name:   "exclusive roles one after the other",
+			grants: []*model.Authz{grant("a", "u1", "admin", nil, at(1)), grant("b", "u1", "auditor", at(1), nil)},
+		},
+		{
+			name: "exclusive roles open ended",
+			grants: []*model.Authz{
+				grant("a", "u1", "admin", nil, nil),
+				grant("b", "u1", "mentor", nil, nil),
+				grant("c", "u1", "auditor", nil, nil),
+			},
+			want: []violation{{constraint: "duties", user: "u1", grants: []string{"a", "c"}}},
+		},
+		{
+			name: "only the overlapping grants",
+			grants: []*model.Authz{
+				grant("a", "u1", "admin", at(0), at(2)),
+				grant("b", "u1", "auditor", at(1), at(3)),
+				grant("c", "u1", "auditor", at(5), nil),
+			},
+			want: []violation{{constraint: "duties", user: "u1", grants: []string{"a", "b"}}},
+		},
+		{
+			name: "per user and constraint",
+			grants: []*model.Authz{
+				grant("a", "u1", "payer", nil, nil),
+				grant("b", "u2", "admin", nil, at(4)),
+				grant("c", "u1", "approver", at(3), nil),
+				grant("d", "u2", "auditor", at(3), nil),
+				grant("e", "u1", "admin", nil, nil),
+			},
+			want: []violation{
+				{constraint: "duties", user: "u2", grants: []string{"b", "d"}},
+				{constraint: "payments", user: "u1", grants: []string{"a", "c"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []violation{}
+			for _, v := range separation.Violations(tt.grants) {
+				uids := []string{}
+				for _, g := range v.Grants {
+					uids = append(uids, g.UID)
+				}
+				got = append(got, violation{constraint: v.Constraint.Name, user: v.UserUID, grants: uids})
+			}
+
+			want := tt.want
+			if want == nil {
+				want = []violation{}
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("Violations() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
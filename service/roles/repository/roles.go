@@ -8,4 +8,7 @@ import (
 type RolesRepository interface {
 	CreateRoles(ctx context.Context, req *model.Role) error
 	ReadRolesByID(ctx context.Context, req *model.ReadRolesByIDReq) (*model.Role, error)
+	ReadRoles(ctx context.Context) ([]*model.Role, error)
+	UpdateRoleParent(ctx context.Context, req *model.UpdateRoleParentReq) error
+	ReadRolesWithPagination(ctx context.Context, req *model.ListReq) (*model.ReadRolesWithPaginationResp, error)
 }
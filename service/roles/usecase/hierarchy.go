@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+)
+
+// Hierarchy indexes the roles of an organization by uid to tell which role
+// may administer which.
+type Hierarchy map[string]*model.Role
+
+func NewHierarchy(roles []*model.Role) Hierarchy {
+	h := make(Hierarchy, len(roles))
+	for _, v := range roles {
+		h[v.UID] = v
+	}
+	return h
+}
+
+// Manages reports whether actorUID sits strictly above roleUID. A role never
+// manages itself, so nobody can widen their own grants.
+func (h Hierarchy) Manages(actorUID, roleUID string) bool {
+	role, ok := h[roleUID]
+	if !ok || actorUID == "" {
+		return false
+	}
+
+	for i := 0; i < len(h) && role.ParentUID != ""; i++ {
+		if role.ParentUID == actorUID {
+			return true
+		}
+
+		if role, ok = h[role.ParentUID]; !ok {
+			return false
+		}
+	}
+
+	return false
+}
+
+// Validate makes sure every parent exists and that following parents always
+// ends at a root.
+func (h Hierarchy) Validate() error {
+	for uid, role := range h {
+		seen := map[string]bool{uid: true}
+
+		for role.ParentUID != "" {
+			parent, ok := h[role.ParentUID]
+			if !ok {
+				return fmt.Errorf("role %s has unknown parent %s", role.Name, role.ParentUID)
+			}
+
+			if seen[parent.UID] {
+				return fmt.Errorf("role %s is its own ancestor", h[uid].Name)
+			}
+
+			seen[parent.UID] = true
+			role = parent
+		}
+	}
+
+	return nil
+}
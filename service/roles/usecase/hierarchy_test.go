@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"strings"
+	"testing"
+)
+
+// role is a role named as its uid, beneath parentUID.
+func role(uid, parentUID string) *model.Role {
+	return &model.Role{UID: uid, Name: uid, ParentUID: parentUID}
+}
+
+// chain is n roles r0 to r(n-1), each beneath the previous one.
+func chain(n int) []*model.Role {
+	roles := make([]*model.Role, 0, n)
+	for i := 0; i < n; i++ {
+		parentUID := ""
+		if i > 0 {
+			parentUID = fmt.Sprintf("r%d", i-1)
+		}
+		roles = append(roles, role(fmt.Sprintf("r%d", i), parentUID))
+	}
+	return roles
+}
+
+func TestHierarchyManages(t *testing.T) {
+	h := NewHierarchy([]*model.Role{
+		role("admin", ""),
+		role("mentor", "admin"),
+		role("auditor", "admin"),
+		role("mentee", "mentor"),
+		role("guest", "mentee"),
+		role("orphan", "gone"),
+		role("a", "b"),
+		role("b", "a"),
+	})
+
+	deep := NewHierarchy(chain(64))
+
+	tests := []struct {
+		name    string
+		h       Hierarchy
+		actor   string
+		roleUID string
+		want    bool
+	}{
+		{name: "parent", h: h, actor: "admin", roleUID: "mentor", want: true},
+		{name: "grandparent", h: h, actor: "mentor", roleUID: "guest", want: true},
+		{name: "root over a leaf", h: h, actor: "admin", roleUID: "guest", want: true},
+		{name: "itself", h: h, actor: "mentor", roleUID: "mentor"},
+		{name: "root itself", h: h, actor: "admin", roleUID: "admin"},
+		{name: "child over its parent", h: h, actor: "mentee", roleUID: "mentor"},
+		{name: "sibling", h: h, actor: "auditor", roleUID: "mentee"},
+		{name: "no actor", h: h, actor: "", roleUID: "admin"},
+		{name: "no actor over a child", h: h, actor: "", roleUID: "mentor"},
+		{name: "unknown role", h: h, actor: "admin", roleUID: "nope"},
+		{name: "unknown actor", h: h, actor: "nope", roleUID: "mentor"},
+		{name: "unknown parent", h: h, actor: "admin", roleUID: "orphan"},
+		{name: "outside a cycle", h: h, actor: "admin", roleUID: "a"},
+		{name: "inside a cycle", h: h, actor: "a", roleUID: "b", want: true},
+		{name: "deep chain down", h: deep, actor: "r0", roleUID: "r63", want: true},
+		{name: "deep chain up", h: deep, actor: "r63", roleUID: "r0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.h.Manages(tt.actor, tt.roleUID); got != tt.want {
+				t.Fatalf("Manages(%q, %q) = %v, want %v", tt.actor, tt.roleUID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHierarchyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		roles   []*model.Role
+		wantErr string
+	}{
+		{name: "no role"},
+		{name: "single root", roles: []*model.Role{role("admin", "")}},
+		{name: "several roots", roles: []*model.Role{role("admin", ""), role("system", ""), role("mentor", "admin")}},
+		{name: "deep chain", roles: chain(1000)},
+		{
+			name:    "unknown parent",
+			roles:   []*model.Role{role("admin", ""), role("mentor", "gone")},
+			wantErr: "role mentor has unknown parent gone",
+		},
+		{
+			name:    "unknown parent atop a chain",
+			roles:   append([]*model.Role{role("r0", "gone")}, chain(10)[1:]...),
+			wantErr: "role r0 has unknown parent gone",
+		},
+		{
+			name:    "own parent",
+			roles:   []*model.Role{role("admin", "admin")},
+			wantErr: "role admin is its own ancestor",
+		},
+		{
+			name:    "two roles cycle",
+			roles:   []*model.Role{role("a", "b"), role("b", "a")},
+			wantErr: "is its own ancestor",
+		},
+		{
+			name:    "cycle beside a tree",
+			roles:   []*model.Role{role("admin", ""), role("mentor", "admin"), role("a", "c"), role("b", "a"), role("c", "b")},
+			wantErr: "is its own ancestor",
+		},
+		{
+			name:    "branch into a cycle",
+			roles:   []*model.Role{role("a", "b"), role("b", "a"), role("leaf", "a")},
+			wantErr: "is its own ancestor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewHierarchy(tt.roles).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
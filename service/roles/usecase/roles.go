@@ -2,18 +2,24 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"github/yogabagas/join-app/domain/model"
 	"github/yogabagas/join-app/domain/repository/sql"
 	"github/yogabagas/join-app/domain/service"
 	"github/yogabagas/join-app/shared/util"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
+var errParentUnknown = validation.NewError("validation_parent_unknown", "is not a role of the organization")
+
 type RolesServiceImpl struct {
 	repo sql.RepositoryRegistry
 }
 
 type RolesService interface {
 	CreateRoles(ctx context.Context, req service.CreateRolesReq) error
+	GetRoles(ctx context.Context, req service.ListReq) (service.GetRolesResp, error)
 }
 
 func NewRolesService(repository sql.RepositoryRegistry) RolesService {
@@ -24,12 +30,54 @@ func (rs *RolesServiceImpl) CreateRoles(ctx context.Context, req service.CreateR
 
 	rolesRepo := rs.repo.RolesRepository()
 
+	roles, err := rolesRepo.ReadRoles(ctx)
+	if err != nil {
+		return err
+	}
+
+	hierarchy := NewHierarchy(roles)
+
+	if _, ok := hierarchy[req.ParentUID]; !ok {
+		return validation.Errors{"parent_uid": errParentUnknown}
+	}
+
+	if req.ParentUID != req.ActorRoleUID && !hierarchy.Manages(req.ActorRoleUID, req.ParentUID) {
+		return fmt.Errorf("%w: role %s can only create roles beneath it", service.ErrForbidden, req.ActorRoleUID)
+	}
+
 	uID := util.NewULIDGenerate()
 
 	return rolesRepo.CreateRoles(ctx, &model.Role{
 		UID:       uID,
 		Name:      req.Name,
+		ParentUID: req.ParentUID,
 		CreatedBy: req.CreatedBy,
 		UpdatedBy: req.CreatedBy,
 	})
 }
+
+func (rs *RolesServiceImpl) GetRoles(ctx context.Context, req service.ListReq) (resp service.GetRolesResp, err error) {
+
+	list, err := util.ListReqOf(req)
+	if err != nil {
+		return resp, err
+	}
+
+	roles, err := rs.repo.RolesRepository().ReadRolesWithPagination(ctx, list)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Roles = []service.RoleResp{}
+	for _, v := range roles.Roles {
+		resp.Roles = append(resp.Roles, service.RoleResp{
+			UID:       v.UID,
+			Name:      v.Name,
+			ParentUID: v.ParentUID,
+			CreatedAt: v.CreatedAt,
+		})
+	}
+	resp.Pagination = util.PaginationOf(list, roles.Page)
+
+	return resp, nil
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+)
+
+type PasswordHistoriesRepository interface {
+	InsertPasswordHistory(ctx context.Context, req *model.PasswordHistory) error
+	ReadPasswordHistories(ctx context.Context, req *model.ReadPasswordHistoriesReq) ([]*model.PasswordHistory, error)
+	DeletePasswordHistories(ctx context.Context, req *model.DeletePasswordHistoriesReq) error
+}
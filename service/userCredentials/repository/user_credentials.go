@@ -8,4 +8,9 @@ import (
 type UserCredentialsRepository interface {
 	InsertCredential(ctx context.Context, req *model.UserCredential) error
 	ReadCredentialsByUserUIDAndPassword(ctx context.Context, req *model.ReadCredentialsByUserUIDAndPasswordReq) (resp *model.ReadCredentialsByUserUIDAndPasswordResp, err error)
+	ReadCredentialByUserUID(ctx context.Context, req *model.ReadCredentialByUserUIDReq) (*model.UserCredential, error)
+	UpdatePassword(ctx context.Context, req *model.UpdatePasswordReq) error
+	UpdateCredentialActive(ctx context.Context, req *model.UpdateCredentialActiveReq) error
+	InsertStatusHistory(ctx context.Context, req *model.UserStatusHistory) error
+	ReadUsernames(ctx context.Context, req *model.ReadUsernamesReq) ([]string, error)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github/yogabagas/join-app/domain/model"
 	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
 	"github/yogabagas/join-app/shared/util"
 	"time"
 )
@@ -12,36 +13,73 @@ import (
 type UsersPresenterImpl struct{}
 
 type UsersPresenter interface {
-	GetUsersWithPagination(ctx context.Context, req service.GetUsersWithPaginationReq, userResp *model.ReadUsersWithPaginationResp,
-		count *model.CountUsersResp) (service.GetUsersWithPaginationResp, error)
+	GetUsersWithPagination(ctx context.Context, req *model.ListReq, userResp *model.ReadUsersWithPaginationResp,
+		expertises map[string][]string) (service.GetUsersWithPaginationResp, error)
+	GetUser(ctx context.Context, user *model.User, credential *model.UserCredential, roles []*model.ReadAuthzByUserUIDResp,
+		expertises []string, photo service.PhotoResp) (service.UserDetailResp, error)
 }
 
 func NewUsersPresenter() UsersPresenter {
 	return &UsersPresenterImpl{}
 }
 
-func (up *UsersPresenterImpl) GetUsersWithPagination(ctx context.Context, req service.GetUsersWithPaginationReq,
-	userResp *model.ReadUsersWithPaginationResp, count *model.CountUsersResp) (resp service.GetUsersWithPaginationResp, err error) {
+func (up *UsersPresenterImpl) GetUsersWithPagination(ctx context.Context, req *model.ListReq,
+	userResp *model.ReadUsersWithPaginationResp, expertises map[string][]string) (resp service.GetUsersWithPaginationResp, err error) {
 
 	if userResp != nil {
 		for _, v := range userResp.Users {
 
 			user := service.UserResp{
-				Fullname:  fmt.Sprintf("%s %s", v.FirstName, v.LastName),
-				Username:  v.Username,
-				Birthdate: v.Birthdate.Format(time.DateOnly),
-				Email:     v.Email,
-				Role:      v.RoleName,
+				UID:        v.UID,
+				Fullname:   fmt.Sprintf("%s %s", v.FirstName, v.LastName),
+				Username:   v.Username,
+				Birthdate:  v.Birthdate.Format(time.DateOnly),
+				Email:      v.Email,
+				Role:       v.RoleName,
+				Status:     constant.StatusOf(v.IsActive).String(),
+				Expertises: append([]string{}, expertises[v.UID]...),
 			}
 			resp.Users = append(resp.Users, user)
 		}
 
-		resp.Pagination.Page = req.Page
-		resp.Pagination.PerPage = userResp.PerPage
-		resp.Pagination.TotalData = count.Total
-		resp.Pagination.TotalPage = util.GetTotalPage(count.Total, userResp.PerPage)
+		resp.Pagination = util.PaginationOf(req, userResp.Page)
 	}
 
 	return
 
 }
+
+// GetUser presents the user as active when they have no credential.
+func (up *UsersPresenterImpl) GetUser(ctx context.Context, user *model.User, credential *model.UserCredential,
+	roles []*model.ReadAuthzByUserUIDResp, expertises []string, photo service.PhotoResp) (resp service.UserDetailResp, err error) {
+
+	resp = service.UserDetailResp{
+		UID:             user.UID,
+		FirstName:       user.FirstName,
+		LastName:        user.LastName,
+		Fullname:        fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+		Email:           user.Email,
+		Birthdate:       user.Birthdate.Format(time.DateOnly),
+		Bio:             user.Description,
+		Gender:          user.Gender,
+		Country:         user.Country,
+		Photo:           photo.URL,
+		PhotoThumbnails: photo.Thumbnails,
+		Roles:           []string{},
+		Expertises:      append([]string{}, expertises...),
+		Status:          constant.UserActive.String(),
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+
+	if credential != nil {
+		resp.Status = constant.StatusOf(credential.IsActive).String()
+		resp.StatusReason = credential.StatusReason
+	}
+
+	for _, v := range roles {
+		resp.Roles = append(resp.Roles, v.RoleName)
+	}
+
+	return resp, nil
+}
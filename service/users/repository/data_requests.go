@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+)
+
+type DataRequestsRepository interface {
+	CreateDataRequest(ctx context.Context, req *model.DataRequest) error
+	ReadDataRequests(ctx context.Context, req *model.ReadDataRequestsReq) ([]*model.DataRequest, error)
+	UpdateDataRequestStatus(ctx context.Context, req *model.UpdateDataRequestStatusReq) (bool, error)
+}
+
+// PersonalDataRepository reads and erases the personal data of a user held
+// across the tables.
+type PersonalDataRepository interface {
+	ReadFeedbacks(ctx context.Context, req *model.ReadPersonalDataReq) ([]*model.Feedback, error)
+	ReadAuditEntries(ctx context.Context, req *model.ReadPersonalDataReq) ([]*model.AuditEntry, error)
+	AnonymizeUser(ctx context.Context, req *model.AnonymizeUserReq) (*model.AnonymizeUserResp, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/model"
+)
+
+// ProfileSectionsRepository stores the entries E of one section of a profile,
+// such as model.UserEducation.
+type ProfileSectionsRepository[E any] interface {
+	CreateSection(ctx context.Context, req *E) error
+	ReadSection(ctx context.Context, req *model.ReadProfileSectionReq) ([]*E, error)
+	UpdateSection(ctx context.Context, req *E) error
+	DeleteSection(ctx context.Context, req *model.DeleteProfileSectionReq) error
+	ReorderSection(ctx context.Context, req *model.ReorderProfileSectionReq) error
+}
@@ -8,6 +8,10 @@ import (
 type UsersRepository interface {
 	CreateUsers(ctx context.Context, req *model.User) error
 	ReadUserByEmail(ctx context.Context, req *model.ReadUserByEmailReq) (*model.ReadUserByEmailResp, error)
-	ReadUsersWithPagination(ctx context.Context, req *model.ReadUsersWithPaginationReq) (*model.ReadUsersWithPaginationResp, error)
+	ReadUsersByEmails(ctx context.Context, req *model.ReadUsersByEmailsReq) ([]*model.User, error)
+	ReadUserByUID(ctx context.Context, req *model.ReadUserByUIDReq) (*model.User, error)
+	UpdateUserProfile(ctx context.Context, req *model.UpdateUserProfileReq) error
+	UpdateUserDeleted(ctx context.Context, req *model.UpdateUserDeletedReq) error
+	ReadUsersWithPagination(ctx context.Context, req *model.ListReq) (*model.ReadUsersWithPaginationResp, error)
 	CountUsers(ctx context.Context, req *model.CountUsersReq) (*model.CountUsersResp, error)
 }
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+)
+
+// maxUserExpertises caps the expertises a user can be tagged with.
+const maxUserExpertises = 20
+
+func (us *UsersServiceImpl) GetUserExpertises(ctx context.Context, req service.GetProfileSectionReq) ([]service.ExpertiseTagResp, error) {
+
+	if err := us.checkOwnerOrAdmin(ctx, req.ActorUID, req.ActorRoleUID, req.UserUID); err != nil {
+		return nil, err
+	}
+
+	return us.userExpertises(ctx, req.UserUID)
+}
+
+// SetUserExpertises replaces the expertises of the user, which all have to
+// be in the catalog.
+func (us *UsersServiceImpl) SetUserExpertises(ctx context.Context, req service.SetUserExpertisesReq) ([]service.ExpertiseTagResp, error) {
+
+	if err := us.checkOwnerOrAdmin(ctx, req.ActorUID, req.ActorRoleUID, req.UserUID); err != nil {
+		return nil, err
+	}
+
+	uids := []string{}
+	seen := make(map[string]bool)
+	for _, v := range req.ExpertiseUIDs {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			uids = append(uids, v)
+		}
+	}
+
+	if len(uids) > maxUserExpertises {
+		return nil, fmt.Errorf("a user can't have more than %d expertises", maxUserExpertises)
+	}
+
+	known, err := us.repo.ExpertisesRepository().ReadExpertisesByUIDs(ctx, &model.ReadExpertisesByUIDsReq{
+		UIDs: uids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range known {
+		delete(seen, v.UID)
+	}
+
+	for _, v := range uids {
+		if seen[v] {
+			return nil, fmt.Errorf("expertise %s is not found", v)
+		}
+	}
+
+	_, err = us.repo.DoInTransaction(ctx, func(rr sql.RepositoryRegistry) (out interface{}, err error) {
+		return nil, rr.ExpertisesRepository().ReplaceUserExpertises(ctx, &model.ReplaceUserExpertisesReq{
+			UserUID:       req.UserUID,
+			ExpertiseUIDs: uids,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return us.userExpertises(ctx, req.UserUID)
+}
+
+func (us *UsersServiceImpl) userExpertises(ctx context.Context, userUID string) ([]service.ExpertiseTagResp, error) {
+
+	expertises, err := us.repo.ExpertisesRepository().ReadUserExpertises(ctx, &model.ReadUserExpertisesReq{
+		UserUIDs: []string{userUID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := []service.ExpertiseTagResp{}
+	for _, v := range expertises {
+		resp = append(resp, service.ExpertiseTagResp{
+			UID:  v.ExpertiseUID,
+			Name: v.Name,
+		})
+	}
+
+	return resp, nil
+}
+
+// expertiseNames groups the names of the users' expertises by user.
+func (us *UsersServiceImpl) expertiseNames(ctx context.Context, userUIDs []string) (map[string][]string, error) {
+
+	expertises, err := us.repo.ExpertisesRepository().ReadUserExpertises(ctx, &model.ReadUserExpertisesReq{
+		UserUIDs: userUIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string][]string)
+	for _, v := range expertises {
+		names[v.UserUID] = append(names[v.UserUID], v.Name)
+	}
+
+	return names, nil
+}
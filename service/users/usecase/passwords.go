@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/config"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/cache"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/util"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var (
+	errPasswordMismatch = validation.NewError("validation_password_mismatch", "is not the current password")
+
+	bannedPasswordsMu sync.Mutex
+	bannedPasswords   map[string]bool
+)
+
+// ChangePassword sets a new password once the current one is given, then
+// drops every other session of the user. The new password has to pass the
+// policy and differ from the current and the previous ones kept.
+func (us *UsersServiceImpl) ChangePassword(ctx context.Context, req service.ChangePasswordReq) error {
+
+	credential, err := us.repo.UserCredentialsRepository().ReadCredentialByUserUID(ctx, &model.ReadCredentialByUserUIDReq{
+		UserUID: req.UserUID,
+	})
+	if err != nil {
+		return err
+	} else if credential == nil {
+		return fmt.Errorf("%w: user has no credential", service.ErrNotFound)
+	}
+
+	alg := config.GlobalCfg.PasswordAlg
+
+	if ok, err := util.CompareHash(alg, req.CurrentPassword, credential.Password); err != nil {
+		return err
+	} else if !ok {
+		return validation.Errors{"current_password": errPasswordMismatch}
+	}
+
+	user, err := us.repo.UsersRepository().ReadUserByUID(ctx, &model.ReadUserByUIDReq{UID: req.UserUID})
+	if err != nil {
+		return err
+	} else if user == nil {
+		return fmt.Errorf("%w: user is not found", service.ErrNotFound)
+	}
+
+	if err = checkPassword(req.NewPassword, user.FirstName, user.LastName, user.Email, credential.Username); err != nil {
+		return passwordFieldError("new_password", err)
+	}
+
+	history := config.GlobalCfg.PasswordPolicy.History
+
+	previous, err := us.repo.PasswordHistoriesRepository().ReadPasswordHistories(ctx, &model.ReadPasswordHistoriesReq{
+		UserUID: req.UserUID,
+		Limit:   history,
+	})
+	if err != nil {
+		return err
+	}
+
+	hashes := []string{credential.Password}
+	for _, v := range previous {
+		hashes = append(hashes, v.Password)
+	}
+
+	for _, hashed := range hashes {
+		// A hash made with another algorithm than the current one can't
+		// match, so it isn't an error.
+		if ok, _ := util.CompareHash(alg, req.NewPassword, hashed); ok {
+			return validation.Errors{"new_password": validation.NewError("validation_password_reused",
+				fmt.Sprintf("can't be one of your last %d passwords", history+1))}
+		}
+	}
+
+	pwd, err := util.Hash(alg, req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	var InTransaction = func(rr sql.RepositoryRegistry) (out interface{}, err error) {
+
+		err = rr.UserCredentialsRepository().UpdatePassword(ctx, &model.UpdatePasswordReq{
+			UserUID:  req.UserUID,
+			Password: util.Base64(pwd),
+		})
+		if err != nil {
+			log.Println("error update password", err)
+			return nil, err
+		}
+
+		historiesRepo := rr.PasswordHistoriesRepository()
+
+		if history > 0 {
+			err = historiesRepo.InsertPasswordHistory(ctx, &model.PasswordHistory{
+				UserUID:  req.UserUID,
+				Password: credential.Password,
+			})
+			if err != nil {
+				log.Println("error insert password history", err)
+				return nil, err
+			}
+		}
+
+		err = historiesRepo.DeletePasswordHistories(ctx, &model.DeletePasswordHistoriesReq{
+			UserUID: req.UserUID,
+			Keep:    history,
+		})
+		if err != nil {
+			log.Println("error delete password histories", err)
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	if _, err = us.repo.DoInTransaction(ctx, InTransaction); err != nil {
+		log.Println("error do in transaction", err)
+		return err
+	}
+
+	return cache.RevokeOtherSessions(ctx, us.cache, req.UserUID, req.SessionUID)
+}
+
+// checkPassword checks pwd against the password policy, refusing as well the
+// passwords holding one of the personal values such as the name or email of
+// their user. A password out of the policy fails with a validation.Error.
+func checkPassword(pwd string, personal ...string) error {
+
+	policy := config.GlobalCfg.PasswordPolicy
+
+	length := utf8.RuneCountInString(pwd)
+	if length < policy.MinLength || (policy.MaxLength > 0 && length > policy.MaxLength) {
+		msg := fmt.Sprintf("must be at least %d characters long", policy.MinLength)
+		if policy.MaxLength > 0 {
+			msg = fmt.Sprintf("must be %d to %d characters long", policy.MinLength, policy.MaxLength)
+		}
+		return validation.NewError("validation_password_length", msg)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range pwd {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	missing := []string{}
+	for _, class := range []struct {
+		required, has bool
+		name          string
+	}{
+		{policy.RequireUpper, upper, "an uppercase letter"},
+		{policy.RequireLower, lower, "a lowercase letter"},
+		{policy.RequireDigit, digit, "a digit"},
+		{policy.RequireSymbol, symbol, "a symbol"},
+	} {
+		if class.required && !class.has {
+			missing = append(missing, class.name)
+		}
+	}
+	if len(missing) > 0 {
+		return validation.NewError("validation_password_classes", "must have "+strings.Join(missing, ", "))
+	}
+
+	banned, err := loadBannedPasswords()
+	if err != nil {
+		return err
+	}
+
+	lowered := strings.ToLower(pwd)
+	if banned[lowered] {
+		return validation.NewError("validation_password_banned", "is too common")
+	}
+
+	for _, v := range personalTokens(personal) {
+		if strings.Contains(lowered, v) {
+			return validation.NewError("validation_password_personal", "can't contain your name, username or email")
+		}
+	}
+
+	return nil
+}
+
+// personalTokens lowers the personal values and splits them on what isn't a
+// letter or a digit, such as the dots of an email, dropping the tokens too
+// short to be told apart from chance.
+func personalTokens(values []string) (tokens []string) {
+	for _, v := range values {
+		for _, token := range strings.FieldsFunc(strings.ToLower(v), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if utf8.RuneCountInString(token) >= 3 {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}
+
+// loadBannedPasswords reads the banned list once, lowered, skipping the blank
+// lines and the comments starting with #. A list that couldn't be read is
+// read again on the next call.
+func loadBannedPasswords() (map[string]bool, error) {
+
+	bannedPasswordsMu.Lock()
+	defer bannedPasswordsMu.Unlock()
+
+	if bannedPasswords != nil {
+		return bannedPasswords, nil
+	}
+
+	banned := map[string]bool{}
+
+	if path := config.GlobalCfg.PasswordPolicy.BannedList; path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("can't read banned passwords: %w", err)
+		}
+
+		for _, line := range strings.Split(string(b), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			banned[strings.ToLower(line)] = true
+		}
+	}
+
+	bannedPasswords = banned
+
+	return bannedPasswords, nil
+}
+
+// passwordFieldError keys the policy failure of a password by field, any
+// other error being returned as is.
+func passwordFieldError(field string, err error) error {
+	var e validation.Error
+	if errors.As(err, &e) {
+		return validation.Errors{field: e}
+	}
+	return err
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github/yogabagas/join-app/config"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/cache"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	userCredentialsRepo "github/yogabagas/join-app/service/userCredentials/repository"
+	usersRepo "github/yogabagas/join-app/service/users/repository"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/shared/util"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// passwordStore keeps the credential of a single user and its password
+// histories, the latest first as the repository reads them.
+type passwordStore struct {
+	sql.RepositoryRegistry
+	credential model.UserCredential
+	histories  []*model.PasswordHistory
+}
+
+func (s *passwordStore) UserCredentialsRepository() userCredentialsRepo.UserCredentialsRepository {
+	return &passwordCredentials{store: s}
+}
+
+func (s *passwordStore) PasswordHistoriesRepository() userCredentialsRepo.PasswordHistoriesRepository {
+	return &passwordHistories{store: s}
+}
+
+func (s *passwordStore) UsersRepository() usersRepo.UsersRepository {
+	return &passwordUsers{}
+}
+
+func (s *passwordStore) DoInTransaction(ctx context.Context, txFunc sql.InTransaction) (interface{}, error) {
+	return txFunc(s)
+}
+
+type passwordCredentials struct {
+	userCredentialsRepo.UserCredentialsRepository
+	store *passwordStore
+}
+
+func (c *passwordCredentials) ReadCredentialByUserUID(ctx context.Context, req *model.ReadCredentialByUserUIDReq) (*model.UserCredential, error) {
+	credential := c.store.credential
+	return &credential, nil
+}
+
+func (c *passwordCredentials) UpdatePassword(ctx context.Context, req *model.UpdatePasswordReq) error {
+	c.store.credential.Password = req.Password
+	return nil
+}
+
+type passwordHistories struct {
+	userCredentialsRepo.PasswordHistoriesRepository
+	store *passwordStore
+}
+
+func (h *passwordHistories) InsertPasswordHistory(ctx context.Context, req *model.PasswordHistory) error {
+	h.store.histories = append([]*model.PasswordHistory{req}, h.store.histories...)
+	return nil
+}
+
+func (h *passwordHistories) ReadPasswordHistories(ctx context.Context, req *model.ReadPasswordHistoriesReq) ([]*model.PasswordHistory, error) {
+	if req.Limit < len(h.store.histories) {
+		return h.store.histories[:req.Limit], nil
+	}
+	return h.store.histories, nil
+}
+
+func (h *passwordHistories) DeletePasswordHistories(ctx context.Context, req *model.DeletePasswordHistoriesReq) error {
+	if req.Keep < len(h.store.histories) {
+		h.store.histories = h.store.histories[:req.Keep]
+	}
+	return nil
+}
+
+type passwordUsers struct {
+	usersRepo.UsersRepository
+}
+
+func (u *passwordUsers) ReadUserByUID(ctx context.Context, req *model.ReadUserByUIDReq) (*model.User, error) {
+	return &model.User{UID: req.UID, FirstName: "Ann", LastName: "Lee", Email: "ann.lee@example.com"}, nil
+}
+
+// sessionsCache holds no session, so revoking them always succeeds.
+type sessionsCache struct {
+	cache.Cache
+}
+
+func (sessionsCache) Exist(ctx context.Context, key string) bool {
+	return false
+}
+
+func (sessionsCache) RemainingTime(ctx context.Context, key string) int {
+	return 0
+}
+
+func (sessionsCache) Delete(ctx context.Context, key string, opts ...cache.DeleteOptions) error {
+	return nil
+}
+
+func TestChangePasswordHistory(t *testing.T) {
+	defer func(cfg *config.Config) { config.GlobalCfg = cfg }(config.GlobalCfg)
+
+	tests := []struct {
+		name    string
+		history int
+		// changes are the passwords set in turn over "Initial-pass-0".
+		changes     []string
+		password    string
+		wantReused  bool
+		wantHistory int
+	}{
+		{name: "current without history", history: 0, password: "Initial-pass-0", wantReused: true},
+		{name: "previous without history", history: 0, changes: []string{"Second-pass-1"}, password: "Initial-pass-0", wantHistory: 0},
+		{name: "current", history: 2, changes: []string{"Second-pass-1"}, password: "Second-pass-1", wantReused: true},
+		{name: "inside the window", history: 2, changes: []string{"Second-pass-1", "Third-pass-2"}, password: "Initial-pass-0", wantReused: true},
+		{name: "last of the window", history: 2, changes: []string{"Second-pass-1", "Third-pass-2", "Fourth-pass-3"}, password: "Second-pass-1", wantReused: true},
+		{name: "past the window", history: 2, changes: []string{"Second-pass-1", "Third-pass-2", "Fourth-pass-3"}, password: "Initial-pass-0", wantHistory: 2},
+		{name: "new password", history: 2, changes: []string{"Second-pass-1"}, password: "Brand-new-pass", wantHistory: 2},
+		{name: "window not filled", history: 5, changes: []string{"Second-pass-1"}, password: "Third-pass-2", wantHistory: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.GlobalCfg = &config.Config{
+				PasswordAlg:    constant.SHA.String(),
+				PasswordPolicy: config.PasswordPolicy{MinLength: 8, History: tt.history},
+			}
+
+			pwd, err := util.Hash(constant.SHA.String(), "Initial-pass-0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			store := &passwordStore{credential: model.UserCredential{UserUID: "u1", Username: "annlee", Password: util.Base64(pwd)}}
+			us := &UsersServiceImpl{repo: store, cache: sessionsCache{}}
+
+			current := "Initial-pass-0"
+			for _, v := range tt.changes {
+				if err = us.ChangePassword(context.Background(), service.ChangePasswordReq{
+					CurrentPassword: current, NewPassword: v, UserUID: "u1",
+				}); err != nil {
+					t.Fatalf("ChangePassword(%q) error = %v", v, err)
+				}
+				current = v
+			}
+
+			err = us.ChangePassword(context.Background(), service.ChangePasswordReq{
+				CurrentPassword: current, NewPassword: tt.password, UserUID: "u1",
+			})
+
+			var errs validation.Errors
+			reused := errors.As(err, &errs) && errs["new_password"] != nil &&
+				errs["new_password"].(validation.Error).Code() == "validation_password_reused"
+
+			if reused != tt.wantReused {
+				t.Fatalf("ChangePassword(%q) error = %v, want reused %v", tt.password, err, tt.wantReused)
+			}
+			if tt.wantReused {
+				return
+			}
+			if err != nil {
+				t.Fatalf("ChangePassword(%q) error = %v", tt.password, err)
+			}
+			if len(store.histories) != tt.wantHistory {
+				t.Fatalf("kept %d password histories, want %d", len(store.histories), tt.wantHistory)
+			}
+		})
+	}
+}
+
+func TestImportRowPassword(t *testing.T) {
+	defer func(cfg *config.Config) { config.GlobalCfg = cfg }(config.GlobalCfg)
+
+	config.GlobalCfg = &config.Config{
+		PasswordPolicy: config.PasswordPolicy{MinLength: 8},
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{name: "valid", password: "Brand-new-pass"},
+		{name: "too short", password: "short", wantErr: true},
+		{name: "personal", password: "Annlee-2024", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]string{
+				"first_name": "Ann", "last_name": "Lee", "email": "ann.lee@example.com", "birthdate": "1990-01-02",
+				"gender": "female", "username": "annlee", "password": tt.password, "role": "r1",
+			}
+
+			row := importRowOf(2, func(name string) string { return values[name] })
+
+			var failed bool
+			for _, v := range row.resp.Errors {
+				failed = failed || v.Field == "password"
+			}
+
+			if failed != tt.wantErr {
+				t.Fatalf("importRowOf() errors = %+v, want a password error %v", row.resp.Errors, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,569 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/config"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/cache"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/shared/util"
+	"log"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	defaultExportRetention = 7 * 24 * time.Hour
+	maxDataRequestError    = 255
+)
+
+// RequestDataExport queues the compilation of the personal data of the user
+// into an archive, one export being pending or running at a time.
+func (us *UsersServiceImpl) RequestDataExport(ctx context.Context, req service.DataRequestReq) (resp service.DataRequestResp, err error) {
+
+	if us.blob == nil {
+		return resp, errors.New("export storage is not configured")
+	}
+
+	for _, status := range []constant.DataRequestStatus{constant.DataPending, constant.DataRunning} {
+		if err = us.checkNoDataRequest(ctx, req.UserUID, constant.DataExport, status); err != nil {
+			return resp, err
+		}
+	}
+
+	return us.createDataRequest(ctx, req, constant.DataExport)
+}
+
+// RequestErasure records the wish of the user to have their personal data
+// erased, left to an administrator to carry out.
+func (us *UsersServiceImpl) RequestErasure(ctx context.Context, req service.DataRequestReq) (resp service.DataRequestResp, err error) {
+
+	if err = us.checkNoDataRequest(ctx, req.UserUID, constant.DataErasure, constant.DataPending); err != nil {
+		return resp, err
+	}
+
+	return us.createDataRequest(ctx, req, constant.DataErasure)
+}
+
+func (us *UsersServiceImpl) GetDataRequests(ctx context.Context, req service.GetDataRequestsReq) ([]service.DataRequestResp, error) {
+
+	err := validation.Errors{
+		"kind": validation.Validate(req.Kind, validation.In(constant.DataExport.String(), constant.DataErasure.String())),
+		"status": validation.Validate(req.Status, validation.In(constant.DataPending.String(), constant.DataRunning.String(),
+			constant.DataCompleted.String(), constant.DataFailed.String(), constant.DataExpired.String())),
+	}.Filter()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Limit <= 0 {
+		req.Limit = util.DefaultListLimit
+	} else if req.Limit > util.MaxListLimit {
+		req.Limit = util.MaxListLimit
+	}
+
+	requests, err := us.repo.DataRequestsRepository().ReadDataRequests(ctx, &model.ReadDataRequestsReq{
+		UserUID: req.UserUID,
+		Kind:    req.Kind,
+		Status:  req.Status,
+		Limit:   req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]service.DataRequestResp, 0, len(requests))
+	for _, v := range requests {
+		res, err := us.dataRequestResp(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, res)
+	}
+
+	return resp, nil
+}
+
+// EraseUser anonymizes the personal columns of the user and drops the rows
+// only they own, keeping their uid so the created_by and updated_by columns
+// referring to them stay valid. The pending erasure request of the user is
+// completed, or a completed one recorded.
+func (us *UsersServiceImpl) EraseUser(ctx context.Context, req service.EraseUserReq) (resp service.DataRequestResp, err error) {
+
+	if req.UID == req.ActorUID {
+		return resp, fmt.Errorf("%w: you can't erase your own account", service.ErrForbidden)
+	}
+
+	if err = us.checkOwnerOrAdmin(ctx, req.ActorUID, req.ActorRoleUID, req.UID); err != nil {
+		return resp, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err = rand.Read(secret); err != nil {
+		return resp, err
+	}
+
+	pwd, err := util.Hash(config.GlobalCfg.PasswordAlg, util.Base64(secret))
+	if err != nil {
+		return resp, err
+	}
+
+	pending, err := us.repo.DataRequestsRepository().ReadDataRequests(ctx, &model.ReadDataRequestsReq{
+		UserUID: req.UID,
+		Kind:    constant.DataErasure.String(),
+		Status:  constant.DataPending.String(),
+		Limit:   1,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	now := time.Now()
+
+	request := &model.DataRequest{
+		UID:       util.NewULIDGenerate(),
+		UserUID:   req.UID,
+		Kind:      constant.DataErasure.String(),
+		Note:      strings.TrimSpace(req.Reason),
+		CreatedBy: req.ActorUID,
+		CreatedAt: now,
+	}
+	if len(pending) > 0 {
+		request = pending[0]
+	}
+
+	var InTransaction = func(rr sql.RepositoryRegistry) (out interface{}, err error) {
+
+		erased, err := rr.PersonalDataRepository().AnonymizeUser(ctx, &model.AnonymizeUserReq{
+			UserUID:   req.UID,
+			FirstName: "Erased",
+			LastName:  "User",
+			Email:     fmt.Sprintf("erased-%s@erased.invalid", strings.ToLower(req.UID)),
+			Username:  "erased-" + req.UID,
+			Password:  util.Base64(pwd),
+			Reason:    strings.TrimSpace(req.Reason),
+			UpdatedBy: req.ActorUID,
+		})
+		if err != nil {
+			return nil, err
+		} else if erased == nil {
+			return nil, fmt.Errorf("%w: user %s", service.ErrNotFound, req.UID)
+		}
+
+		dataRequestsRepo := rr.DataRequestsRepository()
+
+		if len(pending) > 0 {
+			_, err = dataRequestsRepo.UpdateDataRequestStatus(ctx, &model.UpdateDataRequestStatusReq{
+				UID:         request.UID,
+				From:        constant.DataPending.String(),
+				Status:      constant.DataCompleted.String(),
+				CompletedBy: req.ActorUID,
+			})
+			return erased, err
+		}
+
+		return erased, dataRequestsRepo.CreateDataRequest(ctx, &model.DataRequest{
+			UID:         request.UID,
+			UserUID:     request.UserUID,
+			Kind:        request.Kind,
+			Status:      constant.DataCompleted.String(),
+			Note:        request.Note,
+			CreatedBy:   request.CreatedBy,
+			CompletedBy: req.ActorUID,
+			CompletedAt: &now,
+		})
+	}
+
+	out, err := us.repo.DoInTransaction(ctx, InTransaction)
+	if err != nil {
+		return resp, err
+	}
+
+	// The erasure is committed, so the cleanup below is only logged when it
+	// fails: the sessions expire on their own and ProcessDataRequests drops
+	// the archives left behind past their retention.
+	if err = cache.RevokeSessions(ctx, us.cache, req.UID); err != nil {
+		log.Println("error revoke sessions of erased user", req.UID, err)
+	}
+
+	us.deletePhotoBlobs(ctx, out.(*model.AnonymizeUserResp).Photo)
+
+	if err = us.expireExports(ctx, req.UID); err != nil {
+		log.Println("error expire data exports of erased user", req.UID, err)
+	}
+
+	request.Status, request.CompletedBy, request.CompletedAt = constant.DataCompleted.String(), req.ActorUID, &now
+
+	return dataRequestOf(request), nil
+}
+
+// ProcessDataRequests compiles the archives of the pending exports and drops
+// the archives past their retention.
+func (us *UsersServiceImpl) ProcessDataRequests(ctx context.Context) (resp service.ProcessDataRequestsResp, err error) {
+
+	if us.blob == nil {
+		return resp, errors.New("export storage is not configured")
+	}
+
+	dataRequestsRepo := us.repo.DataRequestsRepository()
+
+	pending, err := dataRequestsRepo.ReadDataRequests(ctx, &model.ReadDataRequestsReq{
+		Kind:   constant.DataExport.String(),
+		Status: constant.DataPending.String(),
+		Limit:  util.MaxListLimit,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	for _, v := range pending {
+		claimed, err := dataRequestsRepo.UpdateDataRequestStatus(ctx, &model.UpdateDataRequestStatusReq{
+			UID:    v.UID,
+			From:   constant.DataPending.String(),
+			Status: constant.DataRunning.String(),
+		})
+		if err != nil {
+			return resp, err
+		} else if !claimed {
+			continue
+		}
+
+		update := &model.UpdateDataRequestStatusReq{
+			UID:         v.UID,
+			From:        constant.DataRunning.String(),
+			Status:      constant.DataCompleted.String(),
+			CompletedBy: constant.System,
+		}
+
+		if update.ArchiveKey, err = us.compileExport(ctx, v); err != nil {
+			log.Println("error compile data export", v.UID, err)
+
+			update.Status, update.Error = constant.DataFailed.String(), err.Error()
+			if len(update.Error) > maxDataRequestError {
+				update.Error = update.Error[:maxDataRequestError]
+			}
+			resp.Failed++
+		} else {
+			expiresAt := time.Now().Add(exportRetention())
+			update.ExpiresAt = &expiresAt
+			resp.Completed++
+		}
+
+		if _, err = dataRequestsRepo.UpdateDataRequestStatus(ctx, update); err != nil {
+			return resp, err
+		}
+	}
+
+	now := time.Now()
+
+	expired, err := dataRequestsRepo.ReadDataRequests(ctx, &model.ReadDataRequestsReq{
+		Kind:          constant.DataExport.String(),
+		Status:        constant.DataCompleted.String(),
+		ExpiredBefore: &now,
+		Limit:         util.MaxListLimit,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	for _, v := range expired {
+		ok, err := us.expireExport(ctx, v)
+		if err != nil {
+			return resp, err
+		} else if ok {
+			resp.Expired++
+		}
+	}
+
+	return resp, nil
+}
+
+// checkNoDataRequest refuses a request of kind while the user has one in
+// status.
+func (us *UsersServiceImpl) checkNoDataRequest(ctx context.Context, userUID string, kind constant.DataRequestKind,
+	status constant.DataRequestStatus) error {
+
+	requests, err := us.repo.DataRequestsRepository().ReadDataRequests(ctx, &model.ReadDataRequestsReq{
+		UserUID: userUID,
+		Kind:    kind.String(),
+		Status:  status.String(),
+		Limit:   1,
+	})
+	if err != nil {
+		return err
+	} else if len(requests) > 0 {
+		return fmt.Errorf("%w: %s request %s is already %s", service.ErrConflict, kind, requests[0].UID, status)
+	}
+
+	return nil
+}
+
+func (us *UsersServiceImpl) createDataRequest(ctx context.Context, req service.DataRequestReq,
+	kind constant.DataRequestKind) (resp service.DataRequestResp, err error) {
+
+	request := &model.DataRequest{
+		UID:       util.NewULIDGenerate(),
+		UserUID:   req.UserUID,
+		Kind:      kind.String(),
+		Status:    constant.DataPending.String(),
+		Note:      strings.TrimSpace(req.Note),
+		CreatedBy: req.ActorUID,
+		CreatedAt: time.Now(),
+	}
+
+	if err = us.repo.DataRequestsRepository().CreateDataRequest(ctx, request); err != nil {
+		return resp, err
+	}
+
+	return us.dataRequestResp(ctx, request)
+}
+
+// compileExport writes the archive of the personal data of the user of the
+// request to the blob store, returning its key.
+func (us *UsersServiceImpl) compileExport(ctx context.Context, request *model.DataRequest) (string, error) {
+
+	archive, err := us.exportArchive(ctx, request.UserUID)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	orgUID, err := sql.TenantFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	key := fmt.Sprintf("exports/%s/%s/%s.json", orgUID, request.UserUID, request.UID)
+
+	if err = us.blob.Put(ctx, key, data, "application/json"); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (us *UsersServiceImpl) exportArchive(ctx context.Context, userUID string) (archive service.DataExportArchive, err error) {
+
+	archive.GeneratedAt = time.Now().UTC()
+
+	if archive.Profile, err = us.userDetail(ctx, userUID); err != nil {
+		return archive, err
+	}
+
+	if archive.Educations, err = educationsSection.list(ctx, us, userUID); err != nil {
+		return archive, err
+	}
+
+	if archive.WorkExperiences, err = workExperiencesSection.list(ctx, us, userUID); err != nil {
+		return archive, err
+	}
+
+	if archive.Languages, err = languagesSection.list(ctx, us, userUID); err != nil {
+		return archive, err
+	}
+
+	if archive.Socials, err = socialsSection.list(ctx, us, userUID); err != nil {
+		return archive, err
+	}
+
+	if archive.Expertises, err = us.userExpertises(ctx, userUID); err != nil {
+		return archive, err
+	}
+
+	if archive.Credential, err = us.credentialExport(ctx, userUID); err != nil {
+		return archive, err
+	}
+
+	archive.Sessions = []service.SessionExport{}
+	for _, v := range cache.Sessions(ctx, us.cache, userUID) {
+		archive.Sessions = append(archive.Sessions, service.SessionExport{
+			SessionUID: v.SessionUID,
+			ExpiresIn:  v.ExpiresIn,
+		})
+	}
+
+	personalDataRepo := us.repo.PersonalDataRepository()
+
+	feedbacks, err := personalDataRepo.ReadFeedbacks(ctx, &model.ReadPersonalDataReq{UserUID: userUID})
+	if err != nil {
+		return archive, err
+	}
+
+	archive.FeedbacksGiven, archive.FeedbacksReceived = []service.FeedbackExport{}, []service.FeedbackExport{}
+	for _, v := range feedbacks {
+		feedback := service.FeedbackExport{
+			UID:       v.UID,
+			MentorUID: v.MentorUID,
+			Rating:    v.Rating,
+			Comment:   v.Comment,
+			CreatedBy: v.CreatedBy,
+			CreatedAt: v.CreatedAt,
+		}
+
+		if v.CreatedBy == userUID {
+			archive.FeedbacksGiven = append(archive.FeedbacksGiven, feedback)
+		}
+		if v.MentorUID == userUID {
+			archive.FeedbacksReceived = append(archive.FeedbacksReceived, feedback)
+		}
+	}
+
+	entries, err := personalDataRepo.ReadAuditEntries(ctx, &model.ReadPersonalDataReq{UserUID: userUID})
+	if err != nil {
+		return archive, err
+	}
+
+	archive.AuditEntries = make([]service.AuditEntryExport, 0, len(entries))
+	for _, v := range entries {
+		archive.AuditEntries = append(archive.AuditEntries, service.AuditEntryExport{
+			Kind:     v.Kind,
+			UID:      v.UID,
+			RoleUID:  v.RoleUID,
+			Status:   v.Status,
+			ActorUID: v.ActorUID,
+			Note:     v.Note,
+			At:       v.At,
+		})
+	}
+
+	requests, err := us.repo.DataRequestsRepository().ReadDataRequests(ctx, &model.ReadDataRequestsReq{
+		UserUID: userUID,
+		Limit:   util.MaxListLimit,
+	})
+	if err != nil {
+		return archive, err
+	}
+
+	archive.DataRequests = make([]service.DataRequestResp, 0, len(requests))
+	for _, v := range requests {
+		archive.DataRequests = append(archive.DataRequests, dataRequestOf(v))
+	}
+
+	return archive, nil
+}
+
+// credentialExport gives the metadata of the credential of the user and when
+// their password changed, leaving out the hashes.
+func (us *UsersServiceImpl) credentialExport(ctx context.Context, userUID string) (resp service.CredentialExport, err error) {
+
+	credential, err := us.repo.UserCredentialsRepository().ReadCredentialByUserUID(ctx, &model.ReadCredentialByUserUIDReq{
+		UserUID: userUID,
+	})
+	if err != nil {
+		return resp, err
+	} else if credential == nil {
+		return resp, fmt.Errorf("%w: user %s has no credential", service.ErrNotFound, userUID)
+	}
+
+	histories, err := us.repo.PasswordHistoriesRepository().ReadPasswordHistories(ctx, &model.ReadPasswordHistoriesReq{
+		UserUID: userUID,
+		Limit:   util.MaxListLimit,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	resp = service.CredentialExport{
+		Username:          credential.Username,
+		Status:            constant.StatusOf(credential.IsActive).String(),
+		StatusReason:      credential.StatusReason,
+		CreatedAt:         credential.CreatedAt,
+		PasswordChangedAt: make([]time.Time, 0, len(histories)),
+	}
+
+	for _, v := range histories {
+		resp.PasswordChangedAt = append(resp.PasswordChangedAt, v.CreatedAt)
+	}
+
+	return resp, nil
+}
+
+// expireExports drops the archives of the completed exports of the user.
+func (us *UsersServiceImpl) expireExports(ctx context.Context, userUID string) error {
+
+	completed, err := us.repo.DataRequestsRepository().ReadDataRequests(ctx, &model.ReadDataRequestsReq{
+		UserUID: userUID,
+		Kind:    constant.DataExport.String(),
+		Status:  constant.DataCompleted.String(),
+		Limit:   util.MaxListLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, v := range completed {
+		if _, err = us.expireExport(ctx, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expireExport drops the archive of a completed export, the export staying
+// completed when its blob can't be deleted so a later run retries.
+func (us *UsersServiceImpl) expireExport(ctx context.Context, request *model.DataRequest) (bool, error) {
+
+	if request.ArchiveKey != "" && us.blob != nil {
+		if err := us.blob.Delete(ctx, request.ArchiveKey); err != nil {
+			log.Println("error delete data export blob", request.ArchiveKey, err)
+			return false, nil
+		}
+	}
+
+	return us.repo.DataRequestsRepository().UpdateDataRequestStatus(ctx, &model.UpdateDataRequestStatusReq{
+		UID:    request.UID,
+		From:   constant.DataCompleted.String(),
+		Status: constant.DataExpired.String(),
+	})
+}
+
+// dataRequestResp signs the URL of the archive of a completed export.
+func (us *UsersServiceImpl) dataRequestResp(ctx context.Context, request *model.DataRequest) (resp service.DataRequestResp, err error) {
+
+	resp = dataRequestOf(request)
+
+	if request.Kind == constant.DataExport.String() && request.Status == constant.DataCompleted.String() &&
+		request.ArchiveKey != "" && us.blob != nil {
+		if resp.DownloadURL, err = us.blob.SignedURL(ctx, request.ArchiveKey, photoURLTTL()); err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, nil
+}
+
+func dataRequestOf(request *model.DataRequest) service.DataRequestResp {
+	return service.DataRequestResp{
+		UID:         request.UID,
+		UserUID:     request.UserUID,
+		Kind:        request.Kind,
+		Status:      request.Status,
+		Note:        request.Note,
+		Error:       request.Error,
+		CreatedBy:   request.CreatedBy,
+		CreatedAt:   request.CreatedAt,
+		CompletedBy: request.CompletedBy,
+		CompletedAt: request.CompletedAt,
+		ExpiresAt:   request.ExpiresAt,
+	}
+}
+
+func exportRetention() time.Duration {
+	if config.GlobalCfg != nil && config.GlobalCfg.Privacy.ExportRetention > 0 {
+		return time.Duration(config.GlobalCfg.Privacy.ExportRetention) * time.Second
+	}
+	return defaultExportRetention
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/config"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/blob"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/shared/util"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// photoSize bounds the sides of the stored photo, photoThumbnailSizes
+	// being the sides of its square thumbnails.
+	photoSize = 1024
+
+	defaultMaxPhotoBytes = 5 << 20
+	defaultPhotoURLTTL   = 15 * time.Minute
+)
+
+var photoThumbnailSizes = []int{256, 64}
+
+// UploadPhoto stores the photo of the user and its thumbnails, re-encoded
+// without their metadata, in place of the previous photo.
+func (us *UsersServiceImpl) UploadPhoto(ctx context.Context, req service.UploadPhotoReq) (resp service.PhotoResp, err error) {
+
+	if err = us.checkOwnerOrAdmin(ctx, req.ActorUID, req.ActorRoleUID, req.UserUID); err != nil {
+		return resp, err
+	}
+
+	if us.blob == nil {
+		return resp, errors.New("photo storage is not configured")
+	}
+
+	if limit := maxPhotoBytes(); int64(len(req.Data)) > limit {
+		return resp, fmt.Errorf("photo must be at most %d bytes", limit)
+	}
+
+	img, err := util.DecodeImage(req.Data)
+	if err != nil {
+		return resp, err
+	}
+
+	user, err := us.repo.UsersRepository().ReadUserByUID(ctx, &model.ReadUserByUIDReq{UID: req.UserUID})
+	if err != nil {
+		return resp, err
+	} else if user == nil {
+		return resp, fmt.Errorf("%w: user %s", service.ErrNotFound, req.UserUID)
+	}
+
+	orgUID, err := sql.TenantFromContext(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	key := fmt.Sprintf("photos/%s/%s/%s%s", orgUID, user.UID, util.NewULIDGenerate(), img.Extension())
+
+	variants := map[string]*util.Image{key: img.Fit(photoSize)}
+	for _, size := range photoThumbnailSizes {
+		variants[photoThumbnailKey(key, size)] = img.Thumbnail(size)
+	}
+
+	for k, v := range variants {
+		data, err := v.Encode()
+		if err == nil {
+			err = us.blob.Put(ctx, k, data, v.ContentType())
+		}
+
+		if err != nil {
+			us.deletePhotoBlobs(ctx, constant.BlobScheme+key)
+			return resp, err
+		}
+	}
+
+	photo := constant.BlobScheme + key
+
+	err = us.repo.UsersRepository().UpdateUserProfile(ctx, &model.UpdateUserProfileReq{
+		UID:       user.UID,
+		Photo:     &photo,
+		UpdatedBy: req.ActorUID,
+	})
+	if err != nil {
+		us.deletePhotoBlobs(ctx, photo)
+		return resp, err
+	}
+
+	us.deletePhotoBlobs(ctx, user.Photo)
+
+	return PhotoOf(ctx, us.blob, photo)
+}
+
+// DeletePhoto clears the photo of the user, dropping its blobs.
+func (us *UsersServiceImpl) DeletePhoto(ctx context.Context, req service.DeletePhotoReq) error {
+
+	if err := us.checkOwnerOrAdmin(ctx, req.ActorUID, req.ActorRoleUID, req.UserUID); err != nil {
+		return err
+	}
+
+	user, err := us.repo.UsersRepository().ReadUserByUID(ctx, &model.ReadUserByUIDReq{UID: req.UserUID})
+	if err != nil {
+		return err
+	} else if user == nil {
+		return fmt.Errorf("%w: user %s", service.ErrNotFound, req.UserUID)
+	}
+
+	if user.Photo == "" {
+		return fmt.Errorf("%w: user %s has no photo", service.ErrNotFound, req.UserUID)
+	}
+
+	none := ""
+
+	err = us.repo.UsersRepository().UpdateUserProfile(ctx, &model.UpdateUserProfileReq{
+		UID:       user.UID,
+		Photo:     &none,
+		UpdatedBy: req.ActorUID,
+	})
+	if err != nil {
+		return err
+	}
+
+	us.deletePhotoBlobs(ctx, user.Photo)
+
+	return nil
+}
+
+// PhotoOf gives the URLs of the photo column of a user, signing those of an
+// uploaded photo and its thumbnails.
+func PhotoOf(ctx context.Context, store blob.BlobStore, photo string) (resp service.PhotoResp, err error) {
+
+	key, ok := photoKey(photo)
+	if !ok {
+		return service.PhotoResp{URL: photo}, nil
+	}
+
+	if store == nil {
+		return resp, errors.New("photo storage is not configured")
+	}
+
+	ttl := photoURLTTL()
+
+	if resp.URL, err = store.SignedURL(ctx, key, ttl); err != nil {
+		return resp, err
+	}
+
+	resp.Thumbnails = map[string]string{}
+	for _, size := range photoThumbnailSizes {
+		url, err := store.SignedURL(ctx, photoThumbnailKey(key, size), ttl)
+		if err != nil {
+			return resp, err
+		}
+		resp.Thumbnails[strconv.Itoa(size)] = url
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	resp.ExpiresAt = &expiresAt
+
+	return resp, nil
+}
+
+// checkPhotoURL refuses the photos set as URL that point into the blob store,
+// which would expose the blobs of any key.
+func checkPhotoURL(photo *string) error {
+	if photo != nil && strings.HasPrefix(*photo, constant.BlobScheme) {
+		return errors.New("photo must be uploaded, not set to a blob key")
+	}
+	return nil
+}
+
+// deletePhotoBlobs drops the blobs of an uploaded photo, only logging the
+// failures as the photo is no longer referenced.
+func (us *UsersServiceImpl) deletePhotoBlobs(ctx context.Context, photo string) {
+
+	key, ok := photoKey(photo)
+	if !ok || us.blob == nil {
+		return
+	}
+
+	keys := []string{key}
+	for _, size := range photoThumbnailSizes {
+		keys = append(keys, photoThumbnailKey(key, size))
+	}
+
+	for _, k := range keys {
+		if err := us.blob.Delete(ctx, k); err != nil {
+			log.Println("error delete photo blob", k, err)
+		}
+	}
+}
+
+func photoKey(photo string) (string, bool) {
+	if !strings.HasPrefix(photo, constant.BlobScheme) {
+		return "", false
+	}
+	return strings.TrimPrefix(photo, constant.BlobScheme), true
+}
+
+// photoThumbnailKey names the thumbnail of size after the photo key, e.g.
+// photos/o/u/p_256.jpg for photos/o/u/p.jpg.
+func photoThumbnailKey(key string, size int) string {
+	ext := path.Ext(key)
+	return fmt.Sprintf("%s_%d%s", strings.TrimSuffix(key, ext), size, ext)
+}
+
+func maxPhotoBytes() int64 {
+	if config.GlobalCfg != nil && config.GlobalCfg.Storage.MaxUploadBytes > 0 {
+		return config.GlobalCfg.Storage.MaxUploadBytes
+	}
+	return defaultMaxPhotoBytes
+}
+
+func photoURLTTL() time.Duration {
+	if config.GlobalCfg != nil && config.GlobalCfg.Storage.URLTTL > 0 {
+		return time.Duration(config.GlobalCfg.Storage.URLTTL) * time.Second
+	}
+	return defaultPhotoURLTTL
+}
@@ -0,0 +1,507 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/service/users/repository"
+	"github/yogabagas/join-app/shared/util"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// minSectionYear is the earliest year the YEAR columns of an education hold,
+// which may end up to maxSectionYearsAhead years from now while in progress.
+const (
+	minSectionYear       = 1901
+	maxSectionYearsAhead = 10
+)
+
+// ProfileSectionsService manages the education, work experience, language
+// and social link sections of a profile. Each change returns the section as
+// it stands afterwards.
+type ProfileSectionsService interface {
+	GetEducations(ctx context.Context, req service.GetProfileSectionReq) ([]service.EducationResp, error)
+	CreateEducation(ctx context.Context, req service.EducationReq) ([]service.EducationResp, error)
+	UpdateEducation(ctx context.Context, req service.EducationReq) ([]service.EducationResp, error)
+	DeleteEducation(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.EducationResp, error)
+	ReorderEducations(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.EducationResp, error)
+
+	GetWorkExperiences(ctx context.Context, req service.GetProfileSectionReq) ([]service.WorkExperienceResp, error)
+	CreateWorkExperience(ctx context.Context, req service.WorkExperienceReq) ([]service.WorkExperienceResp, error)
+	UpdateWorkExperience(ctx context.Context, req service.WorkExperienceReq) ([]service.WorkExperienceResp, error)
+	DeleteWorkExperience(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.WorkExperienceResp, error)
+	ReorderWorkExperiences(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.WorkExperienceResp, error)
+
+	GetLanguages(ctx context.Context, req service.GetProfileSectionReq) ([]service.LanguageResp, error)
+	CreateLanguage(ctx context.Context, req service.LanguageReq) ([]service.LanguageResp, error)
+	DeleteLanguage(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.LanguageResp, error)
+	ReorderLanguages(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.LanguageResp, error)
+
+	GetSocials(ctx context.Context, req service.GetProfileSectionReq) ([]service.SocialResp, error)
+	CreateSocial(ctx context.Context, req service.SocialReq) ([]service.SocialResp, error)
+	UpdateSocial(ctx context.Context, req service.SocialReq) ([]service.SocialResp, error)
+	DeleteSocial(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.SocialResp, error)
+	ReorderSocials(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.SocialResp, error)
+}
+
+// profileSection runs the changes every section of a profile shares, on the
+// entries E it stores and shows as R. name names an entry in the errors.
+type profileSection[E any, PE interface {
+	*E
+	Entry() *model.ProfileSection
+}, R any] struct {
+	name string
+	repo func(rr sql.RepositoryRegistry) repository.ProfileSectionsRepository[E]
+	resp func(e *E) R
+	// conflicts tells the entries that can't both be on a profile.
+	conflicts func(a, b *E) bool
+}
+
+var (
+	educationsSection = profileSection[model.UserEducation, *model.UserEducation, service.EducationResp]{
+		name: "education",
+		repo: func(rr sql.RepositoryRegistry) repository.ProfileSectionsRepository[model.UserEducation] {
+			return rr.UserEducationsRepository()
+		},
+		resp: educationResp,
+	}
+	workExperiencesSection = profileSection[model.UserWorkExperience, *model.UserWorkExperience, service.WorkExperienceResp]{
+		name: "work experience",
+		repo: func(rr sql.RepositoryRegistry) repository.ProfileSectionsRepository[model.UserWorkExperience] {
+			return rr.UserWorkExperiencesRepository()
+		},
+		resp: workExperienceResp,
+	}
+	languagesSection = profileSection[model.UserLanguage, *model.UserLanguage, service.LanguageResp]{
+		name: "language",
+		repo: func(rr sql.RepositoryRegistry) repository.ProfileSectionsRepository[model.UserLanguage] {
+			return rr.UserLanguagesRepository()
+		},
+		resp: languageResp,
+		conflicts: func(a, b *model.UserLanguage) bool {
+			return a.Language == b.Language
+		},
+	}
+	socialsSection = profileSection[model.UserSocial, *model.UserSocial, service.SocialResp]{
+		name: "social link",
+		repo: func(rr sql.RepositoryRegistry) repository.ProfileSectionsRepository[model.UserSocial] {
+			return rr.UserSocialsRepository()
+		},
+		resp: socialResp,
+	}
+)
+
+func (us *UsersServiceImpl) GetEducations(ctx context.Context, req service.GetProfileSectionReq) ([]service.EducationResp, error) {
+	return educationsSection.get(ctx, us, req.ProfileSectionRef)
+}
+
+func (us *UsersServiceImpl) CreateEducation(ctx context.Context, req service.EducationReq) ([]service.EducationResp, error) {
+	return educationsSection.create(ctx, us, req.ProfileSectionRef, func() (*model.UserEducation, error) {
+		return educationOf(req)
+	})
+}
+
+func (us *UsersServiceImpl) UpdateEducation(ctx context.Context, req service.EducationReq) ([]service.EducationResp, error) {
+	return educationsSection.update(ctx, us, req.ProfileSectionRef, func() (*model.UserEducation, error) {
+		return educationOf(req)
+	})
+}
+
+func (us *UsersServiceImpl) DeleteEducation(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.EducationResp, error) {
+	return educationsSection.delete(ctx, us, req.ProfileSectionRef)
+}
+
+func (us *UsersServiceImpl) ReorderEducations(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.EducationResp, error) {
+	return educationsSection.reorder(ctx, us, req)
+}
+
+func (us *UsersServiceImpl) GetWorkExperiences(ctx context.Context, req service.GetProfileSectionReq) ([]service.WorkExperienceResp, error) {
+	return workExperiencesSection.get(ctx, us, req.ProfileSectionRef)
+}
+
+func (us *UsersServiceImpl) CreateWorkExperience(ctx context.Context, req service.WorkExperienceReq) ([]service.WorkExperienceResp, error) {
+	return workExperiencesSection.create(ctx, us, req.ProfileSectionRef, func() (*model.UserWorkExperience, error) {
+		return workExperienceOf(req)
+	})
+}
+
+func (us *UsersServiceImpl) UpdateWorkExperience(ctx context.Context, req service.WorkExperienceReq) ([]service.WorkExperienceResp, error) {
+	return workExperiencesSection.update(ctx, us, req.ProfileSectionRef, func() (*model.UserWorkExperience, error) {
+		return workExperienceOf(req)
+	})
+}
+
+func (us *UsersServiceImpl) DeleteWorkExperience(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.WorkExperienceResp, error) {
+	return workExperiencesSection.delete(ctx, us, req.ProfileSectionRef)
+}
+
+func (us *UsersServiceImpl) ReorderWorkExperiences(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.WorkExperienceResp, error) {
+	return workExperiencesSection.reorder(ctx, us, req)
+}
+
+func (us *UsersServiceImpl) GetLanguages(ctx context.Context, req service.GetProfileSectionReq) ([]service.LanguageResp, error) {
+	return languagesSection.get(ctx, us, req.ProfileSectionRef)
+}
+
+func (us *UsersServiceImpl) CreateLanguage(ctx context.Context, req service.LanguageReq) ([]service.LanguageResp, error) {
+	return languagesSection.create(ctx, us, req.ProfileSectionRef, func() (*model.UserLanguage, error) {
+		return languageOf(req)
+	})
+}
+
+func (us *UsersServiceImpl) DeleteLanguage(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.LanguageResp, error) {
+	return languagesSection.delete(ctx, us, req.ProfileSectionRef)
+}
+
+func (us *UsersServiceImpl) ReorderLanguages(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.LanguageResp, error) {
+	return languagesSection.reorder(ctx, us, req)
+}
+
+func (us *UsersServiceImpl) GetSocials(ctx context.Context, req service.GetProfileSectionReq) ([]service.SocialResp, error) {
+	return socialsSection.get(ctx, us, req.ProfileSectionRef)
+}
+
+func (us *UsersServiceImpl) CreateSocial(ctx context.Context, req service.SocialReq) ([]service.SocialResp, error) {
+	return socialsSection.create(ctx, us, req.ProfileSectionRef, func() (*model.UserSocial, error) {
+		return socialOf(req)
+	})
+}
+
+func (us *UsersServiceImpl) UpdateSocial(ctx context.Context, req service.SocialReq) ([]service.SocialResp, error) {
+	return socialsSection.update(ctx, us, req.ProfileSectionRef, func() (*model.UserSocial, error) {
+		return socialOf(req)
+	})
+}
+
+func (us *UsersServiceImpl) DeleteSocial(ctx context.Context, req service.DeleteProfileSectionReq) ([]service.SocialResp, error) {
+	return socialsSection.delete(ctx, us, req.ProfileSectionRef)
+}
+
+func (us *UsersServiceImpl) ReorderSocials(ctx context.Context, req service.ReorderProfileSectionReq) ([]service.SocialResp, error) {
+	return socialsSection.reorder(ctx, us, req)
+}
+
+func (s profileSection[E, PE, R]) get(ctx context.Context, us *UsersServiceImpl, ref service.ProfileSectionRef) ([]R, error) {
+
+	if err := us.checkOwnerOrAdmin(ctx, ref.ActorUID, ref.ActorRoleUID, ref.UserUID); err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, us, ref.UserUID)
+}
+
+// create adds entry at the end of the section. entryOf reads the entry from
+// the request once the actor is checked.
+func (s profileSection[E, PE, R]) create(ctx context.Context, us *UsersServiceImpl, ref service.ProfileSectionRef,
+	entryOf func() (*E, error)) ([]R, error) {
+
+	if err := us.checkOwnerOrAdmin(ctx, ref.ActorUID, ref.ActorRoleUID, ref.UserUID); err != nil {
+		return nil, err
+	}
+
+	entry, err := entryOf()
+	if err != nil {
+		return nil, err
+	}
+	PE(entry).Entry().UID = util.NewULIDGenerate()
+	PE(entry).Entry().UserUID = ref.UserUID
+
+	if s.conflicts != nil {
+		current, err := s.entries(ctx, us, ref.UserUID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range current {
+			if s.conflicts(v, entry) {
+				return nil, fmt.Errorf("%w: this %s is already on the profile", service.ErrConflict, s.name)
+			}
+		}
+	}
+
+	if err = s.repo(us.repo).CreateSection(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, us, ref.UserUID)
+}
+
+func (s profileSection[E, PE, R]) update(ctx context.Context, us *UsersServiceImpl, ref service.ProfileSectionRef,
+	entryOf func() (*E, error)) ([]R, error) {
+
+	if err := us.checkOwnerOrAdmin(ctx, ref.ActorUID, ref.ActorRoleUID, ref.UserUID); err != nil {
+		return nil, err
+	}
+
+	entry, err := entryOf()
+	if err != nil {
+		return nil, err
+	}
+	PE(entry).Entry().UID = ref.UID
+	PE(entry).Entry().UserUID = ref.UserUID
+
+	current, err := s.entries(ctx, us, ref.UserUID)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, v := range current {
+		found = found || PE(v).Entry().UID == ref.UID
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s %s is not found", service.ErrNotFound, s.name, ref.UID)
+	}
+
+	if err = s.repo(us.repo).UpdateSection(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, us, ref.UserUID)
+}
+
+func (s profileSection[E, PE, R]) delete(ctx context.Context, us *UsersServiceImpl, ref service.ProfileSectionRef) ([]R, error) {
+
+	if err := us.checkOwnerOrAdmin(ctx, ref.ActorUID, ref.ActorRoleUID, ref.UserUID); err != nil {
+		return nil, err
+	}
+
+	err := s.repo(us.repo).DeleteSection(ctx, &model.DeleteProfileSectionReq{
+		UserUID: ref.UserUID,
+		UID:     ref.UID,
+	})
+	if errors.Is(err, sql.ErrNoRow) {
+		return nil, fmt.Errorf("%w: %s %s is not found", service.ErrNotFound, s.name, ref.UID)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, us, ref.UserUID)
+}
+
+// reorder checks the new order lists each of the current entries exactly
+// once, then saves it in one go.
+func (s profileSection[E, PE, R]) reorder(ctx context.Context, us *UsersServiceImpl, req service.ReorderProfileSectionReq) ([]R, error) {
+
+	if err := us.checkOwnerOrAdmin(ctx, req.ActorUID, req.ActorRoleUID, req.UserUID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.entries(ctx, us, req.UserUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.UIDs) != len(current) {
+		return nil, validation.Errors{"uids": validation.NewError("validation_order_length",
+			fmt.Sprintf("must list all %d %s entries", len(current), s.name))}
+	}
+
+	remaining := make(map[string]bool, len(current))
+	for _, v := range current {
+		remaining[PE(v).Entry().UID] = true
+	}
+
+	for i, v := range req.UIDs {
+		if !remaining[v] {
+			return nil, validation.Errors{"uids": validation.Errors{strconv.Itoa(i): validation.NewError("validation_order_entry",
+				fmt.Sprintf("%s %s is unknown or listed twice", s.name, v))}}
+		}
+		delete(remaining, v)
+	}
+
+	_, err = us.repo.DoInTransaction(ctx, func(rr sql.RepositoryRegistry) (out interface{}, err error) {
+		return nil, s.repo(rr).ReorderSection(ctx, &model.ReorderProfileSectionReq{
+			UserUID: req.UserUID,
+			UIDs:    req.UIDs,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, us, req.UserUID)
+}
+
+func (s profileSection[E, PE, R]) entries(ctx context.Context, us *UsersServiceImpl, userUID string) ([]*E, error) {
+	return s.repo(us.repo).ReadSection(ctx, &model.ReadProfileSectionReq{
+		UserUID: userUID,
+	})
+}
+
+// list reads the section of userUID as it is shown.
+func (s profileSection[E, PE, R]) list(ctx context.Context, us *UsersServiceImpl, userUID string) ([]R, error) {
+
+	entries, err := s.entries(ctx, us, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]R, 0, len(entries))
+	for _, v := range entries {
+		resp = append(resp, s.resp(v))
+	}
+
+	return resp, nil
+}
+
+func educationResp(v *model.UserEducation) service.EducationResp {
+	return service.EducationResp{
+		UID:       v.UID,
+		College:   v.College,
+		Degree:    v.Degree,
+		Major:     v.Major,
+		StartYear: v.StartYear,
+		EndYear:   v.EndYear,
+		Position:  v.Position,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
+
+func workExperienceResp(v *model.UserWorkExperience) service.WorkExperienceResp {
+
+	experience := service.WorkExperienceResp{
+		UID:         v.UID,
+		Role:        v.Role,
+		Company:     v.Company,
+		Industry:    v.Industry,
+		StartDate:   v.StartDate.Format(time.DateOnly),
+		Description: v.Description,
+		Position:    v.Position,
+		CreatedAt:   v.CreatedAt,
+		UpdatedAt:   v.UpdatedAt,
+	}
+
+	if v.EndDate != nil {
+		end := v.EndDate.Format(time.DateOnly)
+		experience.EndDate = &end
+	}
+
+	return experience
+}
+
+func languageResp(v *model.UserLanguage) service.LanguageResp {
+	return service.LanguageResp{
+		UID:       v.UID,
+		Language:  v.Language,
+		Name:      util.ISO639[v.Language],
+		Position:  v.Position,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
+
+func socialResp(v *model.UserSocial) service.SocialResp {
+	return service.SocialResp{
+		UID:       v.UID,
+		Title:     v.Title,
+		Link:      v.Link,
+		Position:  v.Position,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
+
+func educationOf(req service.EducationReq) (*model.UserEducation, error) {
+
+	education := &model.UserEducation{
+		College:   strings.TrimSpace(req.College),
+		Degree:    strings.TrimSpace(req.Degree),
+		Major:     strings.TrimSpace(req.Major),
+		StartYear: req.StartYear,
+		EndYear:   req.EndYear,
+	}
+
+	if education.College == "" {
+		return nil, errors.New("college is required")
+	}
+
+	maxYear := time.Now().Year() + maxSectionYearsAhead
+
+	if education.StartYear < minSectionYear || education.StartYear > time.Now().Year() {
+		return nil, fmt.Errorf("start_year must be between %d and %d", minSectionYear, time.Now().Year())
+	}
+
+	if education.EndYear != nil && (*education.EndYear < education.StartYear || *education.EndYear > maxYear) {
+		return nil, fmt.Errorf("end_year must be between start_year and %d", maxYear)
+	}
+
+	return education, nil
+}
+
+func workExperienceOf(req service.WorkExperienceReq) (*model.UserWorkExperience, error) {
+
+	experience := &model.UserWorkExperience{
+		Role:        strings.TrimSpace(req.Role),
+		Company:     strings.TrimSpace(req.Company),
+		Industry:    strings.TrimSpace(req.Industry),
+		Description: strings.TrimSpace(req.Description),
+	}
+
+	if experience.Role == "" || experience.Company == "" {
+		return nil, errors.New("role and company are required")
+	}
+
+	start, err := time.Parse(time.DateOnly, req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("start_date: %w", err)
+	}
+
+	if start.After(time.Now()) {
+		return nil, errors.New("start_date can't be in the future")
+	}
+	experience.StartDate = start
+
+	if req.EndDate != nil && *req.EndDate != "" {
+		end, err := time.Parse(time.DateOnly, *req.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("end_date: %w", err)
+		}
+
+		if end.Before(start) {
+			return nil, errors.New("end_date can't be before start_date")
+		}
+		experience.EndDate = &end
+	}
+
+	return experience, nil
+}
+
+func languageOf(req service.LanguageReq) (*model.UserLanguage, error) {
+
+	code := strings.ToLower(strings.TrimSpace(req.Language))
+	if _, ok := util.ISO639[code]; !ok {
+		return nil, fmt.Errorf("language %q is not an ISO 639-1 code", req.Language)
+	}
+
+	return &model.UserLanguage{Language: code}, nil
+}
+
+func socialOf(req service.SocialReq) (*model.UserSocial, error) {
+
+	social := &model.UserSocial{
+		Title: strings.TrimSpace(req.Title),
+		Link:  strings.TrimSpace(req.Link),
+	}
+
+	if social.Title == "" {
+		return nil, errors.New("title is required")
+	}
+
+	link, err := url.Parse(social.Link)
+	if err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+		return nil, fmt.Errorf("link %q must be an http or https URL", req.Link)
+	}
+
+	return social, nil
+}
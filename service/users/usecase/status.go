@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/cache"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/util"
+	"log"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// SuspendUser deactivates the credential of the user, so they can't log in
+// anymore, and drops their sessions. The sessions left over by a failed drop
+// are refused anyway, the credential being inactive, so the failure is only
+// logged.
+func (us *UsersServiceImpl) SuspendUser(ctx context.Context, req service.UpdateUserStatusReq) (resp service.UserDetailResp, err error) {
+
+	if strings.TrimSpace(req.Reason) == "" {
+		return resp, validation.Errors{"reason": validation.ErrRequired}
+	}
+
+	if err = us.updateUserActive(ctx, req, false); err != nil {
+		return resp, err
+	}
+
+	if err = cache.RevokeSessions(ctx, us.cache, req.UID); err != nil {
+		log.Println("error revoke sessions of suspended user", req.UID, err)
+	}
+
+	return us.userDetail(ctx, req.UID)
+}
+
+func (us *UsersServiceImpl) ReactivateUser(ctx context.Context, req service.UpdateUserStatusReq) (resp service.UserDetailResp, err error) {
+
+	if err = us.updateUserActive(ctx, req, true); err != nil {
+		return resp, err
+	}
+
+	return us.userDetail(ctx, req.UID)
+}
+
+// updateUserActive sets whether the credential of the user is active and
+// records the change with its reason in their status history. The actors
+// can't change their own status, only the one of the users beneath them.
+func (us *UsersServiceImpl) updateUserActive(ctx context.Context, req service.UpdateUserStatusReq, isActive bool) error {
+
+	if req.UID == req.ActorUID {
+		return fmt.Errorf("%w: you can't change your own status", service.ErrForbidden)
+	}
+
+	if err := us.checkOwnerOrAdmin(ctx, req.ActorUID, req.ActorRoleUID, req.UID); err != nil {
+		return err
+	}
+
+	credentialsRepo := us.repo.UserCredentialsRepository()
+
+	credential, err := credentialsRepo.ReadCredentialByUserUID(ctx, &model.ReadCredentialByUserUIDReq{
+		UserUID: req.UID,
+	})
+	if err != nil {
+		return err
+	} else if credential == nil {
+		return fmt.Errorf("%w: user %s has no credential", service.ErrNotFound, req.UID)
+	}
+
+	if credential.IsActive == isActive {
+		if isActive {
+			return fmt.Errorf("%w: user %s is already active", service.ErrConflict, req.UID)
+		}
+		return fmt.Errorf("%w: user %s is already suspended", service.ErrConflict, req.UID)
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+
+	_, err = us.repo.DoInTransaction(ctx, func(rr sql.RepositoryRegistry) (out interface{}, err error) {
+
+		err = rr.UserCredentialsRepository().UpdateCredentialActive(ctx, &model.UpdateCredentialActiveReq{
+			UserUID:   req.UID,
+			IsActive:  isActive,
+			Reason:    reason,
+			UpdatedBy: req.ActorUID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, rr.UserCredentialsRepository().InsertStatusHistory(ctx, &model.UserStatusHistory{
+			UID:       util.NewULIDGenerate(),
+			UserUID:   req.UID,
+			IsActive:  isActive,
+			Reason:    reason,
+			CreatedBy: req.ActorUID,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	us.revokePrincipal(ctx, req.UID)
+
+	return nil
+}
+
+// revokePrincipal drops the cached principal of the user, whose credential
+// changed. The principal expires on its own shortly, so a failure is only
+// logged.
+func (us *UsersServiceImpl) revokePrincipal(ctx context.Context, userUID string) {
+
+	orgUID, err := sql.TenantFromContext(ctx)
+	if err == nil {
+		err = cache.RevokePrincipals(ctx, us.cache, orgUID, userUID)
+	}
+
+	if err != nil {
+		log.Println("error revoke principal of user", userUID, err)
+	}
+}
@@ -0,0 +1,513 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/config"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/sql"
+	"github/yogabagas/join-app/domain/service"
+	rolesUsecase "github/yogabagas/join-app/service/roles/usecase"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/shared/util"
+	"io"
+	"log"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	// maxImportRows bounds an import, every password being hashed.
+	maxImportRows = 1000
+
+	// importBatchSize is the number of users created per transaction, a
+	// failing batch failing its rows only.
+	importBatchSize = 100
+)
+
+// importColumns are the columns an import requires, along with the optional
+// country and bio. The other columns, such as the uid and expertises of an
+// export, are ignored.
+var importColumns = []string{"first_name", "last_name", "email", "birthdate", "gender", "username", "password", "role"}
+
+var exportColumns = []string{"uid", "first_name", "last_name", "email", "birthdate", "gender", "country", "bio",
+	"username", "role", "expertises", "created_at"}
+
+type importRow struct {
+	resp     service.ImportRowResp
+	user     *model.User
+	username string
+	password string
+	roleUID  string
+}
+
+func (r *importRow) fail(field, format string, args ...interface{}) {
+	r.resp.Errors = append(r.resp.Errors, service.ImportRowError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// ImportUsers creates the users of a CSV along with their credentials and
+// role. Every row is validated first, the valid rows then being created in
+// batches unless on a dry run, and the rows failing being reported instead.
+// The actor can only assign the roles beneath theirs, the system assigning
+// any role.
+func (us *UsersServiceImpl) ImportUsers(ctx context.Context, req service.ImportUsersReq) (resp service.ImportUsersResp, err error) {
+
+	rows, err := readImportRows(req.Data)
+	if err != nil {
+		return resp, err
+	}
+
+	roles, err := us.repo.RolesRepository().ReadRoles(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	hierarchy := rolesUsecase.NewHierarchy(roles)
+
+	roleUIDs := make(map[string]string, len(roles))
+	for _, v := range roles {
+		roleUIDs[strings.ToLower(v.Name)] = v.UID
+	}
+
+	for _, row := range rows {
+		if row.roleUID == "" {
+			continue
+		}
+
+		uid, ok := roleUIDs[strings.ToLower(row.roleUID)]
+		switch {
+		case !ok:
+			row.fail("role", "role %s is not found", row.roleUID)
+		case req.ActorUID != constant.System && !hierarchy.Manages(req.ActorRoleUID, uid):
+			row.fail("role", "role %s can only be assigned by the roles above it", row.roleUID)
+		}
+		row.roleUID = uid
+	}
+
+	if err = us.checkImportDuplicates(ctx, rows); err != nil {
+		return resp, err
+	}
+
+	resp = service.ImportUsersResp{DryRun: req.DryRun, Total: len(rows)}
+
+	valid := []*importRow{}
+	for _, row := range rows {
+		if len(row.resp.Errors) > 0 {
+			row.resp.Status = constant.ImportFailed.String()
+			continue
+		}
+
+		row.resp.Status = constant.ImportValid.String()
+		valid = append(valid, row)
+	}
+
+	if !req.DryRun {
+		for i := 0; i < len(valid); i += importBatchSize {
+			end := i + importBatchSize
+			if end > len(valid) {
+				end = len(valid)
+			}
+
+			us.createImportBatch(ctx, valid[i:end], req.ActorUID)
+		}
+	}
+
+	for _, row := range rows {
+		switch row.resp.Status {
+		case constant.ImportCreated.String():
+			resp.Created++
+		case constant.ImportFailed.String():
+			resp.Failed++
+		}
+		resp.Rows = append(resp.Rows, row.resp)
+	}
+
+	return resp, nil
+}
+
+// readImportRows parses the CSV and validates each row on its own, holding
+// the role name in roleUID until the roles are resolved.
+func readImportRows(data []byte) ([]*importRow, error) {
+
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, errors.New("csv is empty")
+	} else if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, v := range header {
+		name := strings.ToLower(strings.TrimSpace(v))
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("column %s is repeated", name)
+		}
+		columns[name] = i
+	}
+
+	for _, name := range importColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("column %s is required", name)
+		}
+	}
+
+	rows := []*importRow{}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if len(rows) == maxImportRows {
+			return nil, fmt.Errorf("an import takes at most %d rows", maxImportRows)
+		}
+
+		line, _ := r.FieldPos(0)
+		rows = append(rows, importRowOf(line, func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			} else if name == "password" {
+				return record[i]
+			}
+			return unescapeCell(strings.TrimSpace(record[i]))
+		}))
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("csv has no rows")
+	}
+
+	return rows, nil
+}
+
+func importRowOf(line int, field func(name string) string) *importRow {
+
+	row := &importRow{
+		resp: service.ImportRowResp{Row: line, Email: field("email")},
+		user: &model.User{
+			UID:         util.NewULIDGenerate(),
+			FirstName:   field("first_name"),
+			LastName:    field("last_name"),
+			Email:       field("email"),
+			Country:     field("country"),
+			Description: field("bio"),
+		},
+		username: field("username"),
+		password: field("password"),
+		roleUID:  field("role"),
+	}
+
+	for _, name := range importColumns {
+		if field(name) == "" {
+			row.fail(name, "%s is required", name)
+		}
+	}
+
+	if email := row.user.Email; email != "" {
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			row.fail("email", "%s is not an email address", email)
+		}
+	}
+
+	if birthdate := field("birthdate"); birthdate != "" {
+		hbd, err := time.Parse(time.DateOnly, birthdate)
+		if err != nil {
+			row.fail("birthdate", "birthdate must be a YYYY-MM-DD date")
+		} else if hbd.After(time.Now()) {
+			row.fail("birthdate", "birthdate can't be in the future")
+		}
+		row.user.Birthdate = hbd
+	}
+
+	if gender := field("gender"); gender != "" {
+		g, ok := genderOf(gender)
+		if !ok {
+			row.fail("gender", "gender must be %s or %s", constant.Female, constant.Male)
+		}
+		row.user.Gender = g
+	}
+
+	if row.password != "" {
+		err := checkPassword(row.password, row.user.FirstName, row.user.LastName, row.user.Email, row.username)
+		var e validation.Error
+		if errors.As(err, &e) {
+			row.fail("password", "password %s", e.Message())
+		} else if err != nil {
+			row.fail("password", "%s", err.Error())
+		}
+	}
+
+	return row
+}
+
+// genderOf reads a gender by name or by number.
+func genderOf(v string) (int, bool) {
+	for _, g := range []constant.Gender{constant.Female, constant.Male} {
+		if strings.EqualFold(v, g.String()) || v == strconv.Itoa(g.Int()) {
+			return g.Int(), true
+		}
+	}
+	return 0, false
+}
+
+// checkImportDuplicates fails the rows repeating the email or username of a
+// previous row, and the rows whose email is held by a user of the
+// organization or whose username is taken.
+func (us *UsersServiceImpl) checkImportDuplicates(ctx context.Context, rows []*importRow) error {
+
+	emails, usernames := map[string]int{}, map[string]int{}
+	emailArgs, usernameArgs := []string{}, []string{}
+
+	for _, row := range rows {
+		if email := strings.ToLower(row.user.Email); email != "" {
+			if line, ok := emails[email]; ok {
+				row.fail("email", "email is already in row %d", line)
+			} else {
+				emails[email] = row.resp.Row
+				emailArgs = append(emailArgs, row.user.Email)
+			}
+		}
+
+		if username := strings.ToLower(row.username); username != "" {
+			if line, ok := usernames[username]; ok {
+				row.fail("username", "username is already in row %d", line)
+			} else {
+				usernames[username] = row.resp.Row
+				usernameArgs = append(usernameArgs, row.username)
+			}
+		}
+	}
+
+	held, err := us.repo.UsersRepository().ReadUsersByEmails(ctx, &model.ReadUsersByEmailsReq{Emails: emailArgs})
+	if err != nil {
+		return err
+	}
+
+	taken, err := us.repo.UserCredentialsRepository().ReadUsernames(ctx, &model.ReadUsernamesReq{Usernames: usernameArgs})
+	if err != nil {
+		return err
+	}
+
+	heldEmails, takenUsernames := map[string]bool{}, map[string]bool{}
+	for _, v := range held {
+		heldEmails[strings.ToLower(v.Email)] = true
+	}
+	for _, v := range taken {
+		takenUsernames[strings.ToLower(v)] = true
+	}
+
+	for _, row := range rows {
+		if line := emails[strings.ToLower(row.user.Email)]; line == row.resp.Row && heldEmails[strings.ToLower(row.user.Email)] {
+			row.fail("email", "email is already used by another user")
+		}
+
+		if line := usernames[strings.ToLower(row.username)]; line == row.resp.Row && takenUsernames[strings.ToLower(row.username)] {
+			row.fail("username", "username is already taken")
+		}
+	}
+
+	return nil
+}
+
+// createImportBatch creates the users of the batch with their credentials and
+// role in one transaction, failing every row of the batch when it fails.
+func (us *UsersServiceImpl) createImportBatch(ctx context.Context, batch []*importRow, actorUID string) {
+
+	var fail = func(err error) {
+		log.Println("error import users", err)
+		for _, row := range batch {
+			row.resp.Status = constant.ImportFailed.String()
+			row.fail("", "%s", err.Error())
+		}
+	}
+
+	passwords := make([]string, len(batch))
+	for i, row := range batch {
+		pwd, err := util.Hash(config.GlobalCfg.PasswordAlg, row.password)
+		if err != nil {
+			fail(err)
+			return
+		}
+		passwords[i] = util.Base64(pwd)
+	}
+
+	var InTransaction = func(rr sql.RepositoryRegistry) (out interface{}, err error) {
+
+		for i, row := range batch {
+			row.user.CreatedBy, row.user.UpdatedBy = actorUID, actorUID
+
+			if err = rr.UsersRepository().CreateUsers(ctx, row.user); err != nil {
+				return nil, fmt.Errorf("row %d: %w", row.resp.Row, err)
+			}
+
+			err = rr.UserCredentialsRepository().InsertCredential(ctx, &model.UserCredential{
+				UserUID:  row.user.UID,
+				Username: row.username,
+				Password: passwords[i],
+			})
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", row.resp.Row, err)
+			}
+
+			err = rr.AuthzRepository().CreateAuthz(ctx, &model.Authz{
+				UID:       util.NewULIDGenerate(),
+				UserUID:   row.user.UID,
+				RoleUID:   row.roleUID,
+				CreatedBy: actorUID,
+				UpdatedBy: actorUID,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", row.resp.Row, err)
+			}
+		}
+
+		return nil, nil
+	}
+
+	if _, err := us.repo.DoInTransaction(ctx, InTransaction); err != nil {
+		fail(err)
+		return
+	}
+
+	for _, row := range batch {
+		row.resp.Status = constant.ImportCreated.String()
+		row.resp.UID = row.user.UID
+	}
+}
+
+// ExportUsers writes every user matched by the filters of the listing, paging
+// by cursor in the default order and by offset when sorted. Nothing is
+// written when the filters are invalid.
+func (us *UsersServiceImpl) ExportUsers(ctx context.Context, req service.ExportUsersReq) error {
+
+	format := constant.ExportFormat(req.Format)
+	if format == "" {
+		format = constant.ExportCSV
+	} else if format != constant.ExportCSV && format != constant.ExportJSONL {
+		return fmt.Errorf("format must be %s or %s", constant.ExportCSV, constant.ExportJSONL)
+	}
+
+	req.List.Limit, req.List.Page, req.List.Cursor = util.MaxListLimit, 0, ""
+
+	list, err := util.ListReqOf(req.List)
+	if err != nil {
+		return err
+	}
+
+	var write func(row service.UserExportRow) error
+	var flush func() error
+
+	for first := true; ; first = false {
+		users, err := us.repo.UsersRepository().ReadUsersWithPagination(ctx, list)
+		if err != nil {
+			return err
+		}
+
+		if first {
+			if write, flush, err = exportWriter(format, req.Writer); err != nil {
+				return err
+			}
+		}
+
+		userUIDs := make([]string, 0, len(users.Users))
+		for _, v := range users.Users {
+			userUIDs = append(userUIDs, v.UID)
+		}
+
+		expertises, err := us.expertiseNames(ctx, userUIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range users.Users {
+			err = write(service.UserExportRow{
+				UID:        v.UID,
+				FirstName:  v.FirstName,
+				LastName:   v.LastName,
+				Email:      v.Email,
+				Birthdate:  v.Birthdate.Format(time.DateOnly),
+				Gender:     constant.Gender(v.Gender).String(),
+				Country:    v.Country,
+				Bio:        v.Description,
+				Username:   v.Username,
+				Role:       v.RoleName,
+				Expertises: append([]string{}, expertises[v.UID]...),
+				CreatedAt:  v.CreatedAt.UTC().Format(time.RFC3339),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err = flush(); err != nil {
+			return err
+		}
+
+		switch {
+		case len(list.Sorts) == 0 && users.Page.NextUID != "":
+			list.After = users.Page.NextUID
+		case len(list.Sorts) > 0 && len(users.Users) == list.Limit:
+			list.Offset += list.Limit
+		default:
+			return nil
+		}
+	}
+}
+
+// exportWriter writes the header of the format to w, and returns the
+// functions writing a row and flushing the rows written.
+func exportWriter(format constant.ExportFormat, w io.Writer) (write func(service.UserExportRow) error, flush func() error, err error) {
+
+	if format == constant.ExportJSONL {
+		enc := json.NewEncoder(w)
+		return func(row service.UserExportRow) error { return enc.Encode(row) }, func() error { return nil }, nil
+	}
+
+	cw := csv.NewWriter(w)
+	if err = cw.Write(exportColumns); err != nil {
+		return nil, nil, err
+	}
+
+	write = func(row service.UserExportRow) error {
+		return cw.Write([]string{row.UID, escapeCell(row.FirstName), escapeCell(row.LastName), escapeCell(row.Email),
+			row.Birthdate, row.Gender, escapeCell(row.Country), escapeCell(row.Bio), escapeCell(row.Username),
+			escapeCell(row.Role), escapeCell(strings.Join(row.Expertises, ";")), row.CreatedAt})
+	}
+
+	flush = func() error {
+		cw.Flush()
+		return cw.Error()
+	}
+
+	return write, flush, nil
+}
+
+// escapeCell quotes the cells a spreadsheet would run as a formula with a
+// leading apostrophe, which unescapeCell strips on import.
+func escapeCell(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+func unescapeCell(v string) string {
+	if len(v) > 1 && v[0] == '\'' && strings.ContainsRune("=+-@\t\r", rune(v[1])) {
+		return v[1:]
+	}
+	return v
+}
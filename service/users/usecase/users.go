@@ -3,34 +3,60 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github/yogabagas/join-app/config"
 	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/repository/blob"
 	"github/yogabagas/join-app/domain/repository/cache"
 	"github/yogabagas/join-app/domain/repository/sql"
 	"github/yogabagas/join-app/domain/service"
+	rolesUsecase "github/yogabagas/join-app/service/roles/usecase"
 	"github/yogabagas/join-app/service/users/presenter"
 	"github/yogabagas/join-app/shared/util"
 	"log"
+	"strings"
 
-	"github/yogabagas/join-app/shared/constant"
 	"time"
 )
 
 type UsersServiceImpl struct {
 	repo      sql.RepositoryRegistry
 	cache     cache.Cache
+	blob      blob.BlobStore
 	presenter presenter.UsersPresenter
 }
 
 type UsersService interface {
 	CreateUsers(ctx context.Context, req service.CreateUsersReq) error
-	GetUsersWithPagination(ctx context.Context, req service.GetUsersWithPaginationReq) (service.GetUsersWithPaginationResp, error)
+	GetUsersWithPagination(ctx context.Context, req service.ListReq) (service.GetUsersWithPaginationResp, error)
+	GetUser(ctx context.Context, req service.GetUserReq) (service.UserDetailResp, error)
+	UpdateProfile(ctx context.Context, req service.UpdateProfileReq) (service.UserDetailResp, error)
+	DeleteUser(ctx context.Context, req service.DeleteUserReq) error
+	RestoreUser(ctx context.Context, req service.RestoreUserReq) (service.UserDetailResp, error)
+	GetUserExpertises(ctx context.Context, req service.GetProfileSectionReq) ([]service.ExpertiseTagResp, error)
+	SetUserExpertises(ctx context.Context, req service.SetUserExpertisesReq) ([]service.ExpertiseTagResp, error)
+	UploadPhoto(ctx context.Context, req service.UploadPhotoReq) (service.PhotoResp, error)
+	DeletePhoto(ctx context.Context, req service.DeletePhotoReq) error
+	ImportUsers(ctx context.Context, req service.ImportUsersReq) (service.ImportUsersResp, error)
+	ExportUsers(ctx context.Context, req service.ExportUsersReq) error
+	ChangePassword(ctx context.Context, req service.ChangePasswordReq) error
+	SuspendUser(ctx context.Context, req service.UpdateUserStatusReq) (service.UserDetailResp, error)
+	ReactivateUser(ctx context.Context, req service.UpdateUserStatusReq) (service.UserDetailResp, error)
+	RequestDataExport(ctx context.Context, req service.DataRequestReq) (service.DataRequestResp, error)
+	RequestErasure(ctx context.Context, req service.DataRequestReq) (service.DataRequestResp, error)
+	GetDataRequests(ctx context.Context, req service.GetDataRequestsReq) ([]service.DataRequestResp, error)
+	EraseUser(ctx context.Context, req service.EraseUserReq) (service.DataRequestResp, error)
+	ProcessDataRequests(ctx context.Context) (service.ProcessDataRequestsResp, error)
+
+	ProfileSectionsService
 }
 
-func NewUsersService(repository sql.RepositoryRegistry, cache cache.Cache, presenter presenter.UsersPresenter) UsersService {
+func NewUsersService(repository sql.RepositoryRegistry, cache cache.Cache, blob blob.BlobStore,
+	presenter presenter.UsersPresenter) UsersService {
 	return &UsersServiceImpl{
 		repo:      repository,
 		cache:     cache,
+		blob:      blob,
 		presenter: presenter,
 	}
 }
@@ -45,10 +71,22 @@ func (us *UsersServiceImpl) CreateUsers(ctx context.Context, req service.CreateU
 	})
 	if err != nil {
 		return err
-	} else if role == nil {
+	} else if role == nil || role.UID == "" {
 		return errors.New("role is not found")
 	}
 
+	if !isSelfServiceRole(role.Name) {
+		return fmt.Errorf("%w: role %s can't be picked when signing up", service.ErrForbidden, role.Name)
+	}
+
+	if err = checkPhotoURL(&req.Photo); err != nil {
+		return err
+	}
+
+	if err = checkPassword(req.Password, req.FirstName, req.LastName, req.Email, req.Username); err != nil {
+		return passwordFieldError("password", err)
+	}
+
 	pwd, err := util.Hash(config.GlobalCfg.PasswordAlg, req.Password)
 	if err != nil {
 		return err
@@ -75,6 +113,7 @@ func (us *UsersServiceImpl) CreateUsers(ctx context.Context, req service.CreateU
 			Gender:      req.Gender,
 			Country:     req.Country,
 			Description: req.Bio,
+			Photo:       req.Photo,
 			CreatedBy:   userUID,
 			UpdatedBy:   userUID,
 		})
@@ -97,7 +136,9 @@ func (us *UsersServiceImpl) CreateUsers(ctx context.Context, req service.CreateU
 	}
 
 	_, err = us.repo.DoInTransaction(ctx, InTransaction)
-	if err != nil {
+	if errors.Is(err, sql.ErrDuplicate) {
+		return fmt.Errorf("%w: email or username is already taken", service.ErrConflict)
+	} else if err != nil {
 		log.Println("error do in transaction", err)
 		return err
 	}
@@ -118,25 +159,258 @@ func (us *UsersServiceImpl) CreateUsers(ctx context.Context, req service.CreateU
 	return nil
 }
 
-func (us *UsersServiceImpl) GetUsersWithPagination(ctx context.Context, req service.GetUsersWithPaginationReq) (resp service.GetUsersWithPaginationResp, err error) {
+func (us *UsersServiceImpl) GetUsersWithPagination(ctx context.Context, req service.ListReq) (resp service.GetUsersWithPaginationResp, err error) {
 
-	usersRepo := us.repo.UsersRepository()
+	list, err := util.ListReqOf(req)
+	if err != nil {
+		return resp, err
+	}
 
-	users, err := usersRepo.ReadUsersWithPagination(ctx, &model.ReadUsersWithPaginationReq{
-		Fullname: req.Fullname,
-		Limit:    req.Limit,
-		Offset:   util.PageToOffset(req.Limit, req.Page),
-	})
+	users, err := us.repo.UsersRepository().ReadUsersWithPagination(ctx, list)
 	if err != nil {
 		return
 	}
 
-	count, err := usersRepo.CountUsers(ctx, &model.CountUsersReq{
-		IsDeleted: constant.False.Int(),
-	})
+	userUIDs := []string{}
+	for _, v := range users.Users {
+		userUIDs = append(userUIDs, v.UID)
+	}
+
+	expertises, err := us.expertiseNames(ctx, userUIDs)
 	if err != nil {
 		return
 	}
 
-	return us.presenter.GetUsersWithPagination(ctx, req, users, count)
+	return us.presenter.GetUsersWithPagination(ctx, list, users, expertises)
+}
+
+func (us *UsersServiceImpl) GetUser(ctx context.Context, req service.GetUserReq) (resp service.UserDetailResp, err error) {
+
+	if err = us.checkOwnerOrAdmin(ctx, req.ActorUID, req.ActorRoleUID, req.UID); err != nil {
+		return resp, err
+	}
+
+	return us.userDetail(ctx, req.UID)
+}
+
+func (us *UsersServiceImpl) UpdateProfile(ctx context.Context, req service.UpdateProfileReq) (resp service.UserDetailResp, err error) {
+
+	if err = us.checkOwnerOrAdmin(ctx, req.ActorUID, req.ActorRoleUID, req.UID); err != nil {
+		return resp, err
+	}
+
+	if err = checkPhotoURL(req.Photo); err != nil {
+		return resp, err
+	}
+
+	update := &model.UpdateUserProfileReq{
+		UID:         req.UID,
+		Description: req.Bio,
+		Gender:      req.Gender,
+		Country:     req.Country,
+		Photo:       req.Photo,
+		UpdatedBy:   req.ActorUID,
+	}
+
+	if update.FirstName, err = trimmedName("first_name", req.FirstName); err != nil {
+		return resp, err
+	}
+
+	if update.LastName, err = trimmedName("last_name", req.LastName); err != nil {
+		return resp, err
+	}
+
+	if req.Birthdate != nil {
+		hbd, err := time.Parse(time.DateOnly, *req.Birthdate)
+		if err != nil {
+			return resp, err
+		}
+		update.Birthdate = &hbd
+	}
+
+	var previous *model.User
+	if req.Photo != nil {
+		previous, err = us.repo.UsersRepository().ReadUserByUID(ctx, &model.ReadUserByUIDReq{UID: req.UID})
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	if err = us.repo.UsersRepository().UpdateUserProfile(ctx, update); err != nil {
+		return resp, err
+	}
+
+	if previous != nil && previous.Photo != *req.Photo {
+		us.deletePhotoBlobs(ctx, previous.Photo)
+	}
+
+	return us.userDetail(ctx, req.UID)
+}
+
+// DeleteUser soft-deletes the user and drops their sessions, so they can
+// neither log in nor keep using a token issued before.
+func (us *UsersServiceImpl) DeleteUser(ctx context.Context, req service.DeleteUserReq) error {
+
+	if err := us.checkOwnerOrAdmin(ctx, req.ActorUID, req.ActorRoleUID, req.UID); err != nil {
+		return err
+	}
+
+	err := us.repo.UsersRepository().UpdateUserDeleted(ctx, &model.UpdateUserDeletedReq{
+		UID:       req.UID,
+		IsDeleted: true,
+		UpdatedBy: req.ActorUID,
+	})
+	if err != nil {
+		return userDeletedError(err, req.UID, true)
+	}
+
+	us.revokePrincipal(ctx, req.UID)
+
+	return cache.RevokeSessions(ctx, us.cache, req.UID)
+}
+
+func (us *UsersServiceImpl) RestoreUser(ctx context.Context, req service.RestoreUserReq) (resp service.UserDetailResp, err error) {
+
+	if err = us.checkOwnerOrAdmin(ctx, req.ActorUID, req.ActorRoleUID, req.UID); err != nil {
+		return resp, err
+	}
+
+	err = us.repo.UsersRepository().UpdateUserDeleted(ctx, &model.UpdateUserDeletedReq{
+		UID:       req.UID,
+		IsDeleted: false,
+		UpdatedBy: req.ActorUID,
+	})
+	if err != nil {
+		return resp, userDeletedError(err, req.UID, false)
+	}
+
+	us.revokePrincipal(ctx, req.UID)
+
+	return us.userDetail(ctx, req.UID)
+}
+
+// userDeletedError tells a user missing from a user already in the state
+// UpdateUserDeleted was to move them to.
+func userDeletedError(err error, userUID string, isDeleted bool) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRow):
+		return fmt.Errorf("%w: user %s is not found", service.ErrNotFound, userUID)
+	case errors.Is(err, sql.ErrUnchanged) && isDeleted:
+		return fmt.Errorf("%w: user %s is already deleted", service.ErrConflict, userUID)
+	case errors.Is(err, sql.ErrUnchanged):
+		return fmt.Errorf("%w: user %s is not deleted", service.ErrConflict, userUID)
+	}
+	return err
+}
+
+func (us *UsersServiceImpl) userDetail(ctx context.Context, userUID string) (resp service.UserDetailResp, err error) {
+
+	user, err := us.repo.UsersRepository().ReadUserByUID(ctx, &model.ReadUserByUIDReq{
+		UID: userUID,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if user == nil {
+		return resp, fmt.Errorf("user %s is not found", userUID)
+	}
+
+	roles, err := us.repo.AuthzRepository().ReadAuthzByUserUID(ctx, &model.ReadAuthzByUserUIDReq{
+		UserUID: userUID,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	expertises, err := us.expertiseNames(ctx, []string{userUID})
+	if err != nil {
+		return resp, err
+	}
+
+	credential, err := us.repo.UserCredentialsRepository().ReadCredentialByUserUID(ctx, &model.ReadCredentialByUserUIDReq{
+		UserUID: userUID,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	photo, err := PhotoOf(ctx, us.blob, user.Photo)
+	if err != nil {
+		return resp, err
+	}
+
+	return us.presenter.GetUser(ctx, user, credential, roles, expertises[userUID], photo)
+}
+
+// checkOwnerOrAdmin lets actors through to their own account, and to the
+// accounts whose every active role sits beneath the actor's role. Users
+// without any role are left to the roots of the hierarchy.
+func (us *UsersServiceImpl) checkOwnerOrAdmin(ctx context.Context, actorUID, actorRoleUID, userUID string) error {
+
+	if userUID == "" {
+		return errors.New("user uid is required")
+	}
+
+	if actorUID == userUID {
+		return nil
+	}
+
+	held, err := us.repo.AuthzRepository().ReadAuthzByUserUID(ctx, &model.ReadAuthzByUserUIDReq{
+		UserUID: userUID,
+	})
+	if err != nil {
+		return err
+	}
+
+	roles, err := us.repo.RolesRepository().ReadRoles(ctx)
+	if err != nil {
+		return err
+	}
+
+	hierarchy := rolesUsecase.NewHierarchy(roles)
+
+	allowed := true
+	if len(held) == 0 {
+		actor, ok := hierarchy[actorRoleUID]
+		allowed = ok && actor.ParentUID == ""
+	}
+
+	for _, v := range held {
+		if !hierarchy.Manages(actorRoleUID, v.RoleUID) {
+			allowed = false
+			break
+		}
+	}
+
+	if !allowed {
+		return fmt.Errorf("%w: only the user or a role above theirs can access user %s", service.ErrForbidden, userUID)
+	}
+
+	return nil
+}
+
+// trimmedName trims a name being updated, which can't be blanked.
+func trimmedName(field string, name *string) (*string, error) {
+	if name == nil {
+		return nil, nil
+	}
+
+	trimmed := strings.TrimSpace(*name)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%s can't be empty", field)
+	}
+
+	return &trimmed, nil
+}
+
+// isSelfServiceRole reports whether anyone may sign up with the role, every
+// other role has to be assigned by someone above it.
+func isSelfServiceRole(name string) bool {
+	for _, v := range config.GlobalCfg.Authorization.SelfServiceRoles {
+		if strings.EqualFold(v, name) {
+			return true
+		}
+	}
+	return false
 }
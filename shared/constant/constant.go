@@ -18,6 +18,32 @@ type (
 	CacheKey string
 
 	Gender int
+
+	Decision string
+
+	ElevationStatus string
+
+	ReviewStatus string
+
+	ReviewDecision string
+
+	MentorSort string
+
+	MentorFacet string
+
+	FilterOp string
+
+	ImportStatus string
+
+	ExportFormat string
+
+	UserStatus string
+
+	DataRequestKind string
+
+	DataRequestStatus string
+
+	NotificationKind string
 )
 
 var (
@@ -40,15 +66,86 @@ var (
 
 	Default KeyID = "default"
 
-	Claim ContextKey = "claim"
+	Claim  ContextKey = "claim"
+	Tenant ContextKey = "tenant"
+
+	DefaultOrganization = "default"
+	OrganizationHeader  = "X-Organization"
+
+	System = "system"
 
-	UserAuth      CacheKey = "auth::user-uid:%s"
-	RoleMenu      CacheKey = "resources::role-uid:%s:type:%d"
-	JWKPrivateKey CacheKey = "jwk::private-key:%s"
-	MenuResource  CacheKey = "resources::type:%d"
+	UserAuth            CacheKey = "auth::user-uid:%s"
+	UserSession         CacheKey = "auth::user-uid:%s:sid:%s"
+	RoleMenu            CacheKey = "resources::role-uid:%s:type:%d"
+	RoleMenuPattern     CacheKey = "resources::role-uid:%s:type:*"
+	AllRoleMenuPattern  CacheKey = "resources::role-uid:*"
+	JWKPrivateKey       CacheKey = "jwk::private-key:%s"
+	MenuResource        CacheKey = "resources::org-uid:%s:type:%d"
+	MenuResourcePattern CacheKey = "resources::org-uid:%s:type:*"
+	UserPrincipal       CacheKey = "authz::org-uid:%s:user-uid:%s"
+	PrincipalPattern    CacheKey = "authz::org-uid:%s:user-uid:*"
+
+	// BlobScheme prefixes the photos kept in the blob store by their key, the
+	// other photos being plain URLs.
+	BlobScheme = "blob:"
 
 	Female Gender = 0
 	Male   Gender = 1
+
+	Allow Decision = "allow"
+	Deny  Decision = "deny"
+
+	ElevationPending  ElevationStatus = "pending"
+	ElevationApproved ElevationStatus = "approved"
+	ElevationDenied   ElevationStatus = "denied"
+
+	ReviewOpen   ReviewStatus = "open"
+	ReviewClosed ReviewStatus = "closed"
+
+	ReviewPending   ReviewDecision = ""
+	ReviewConfirmed ReviewDecision = "confirmed"
+	ReviewRevoked   ReviewDecision = "revoked"
+
+	SortRelevance MentorSort = "relevance"
+	SortRating    MentorSort = "rating"
+	SortNewest    MentorSort = "newest"
+
+	FacetExpertise MentorFacet = "expertise"
+	FacetCountry   MentorFacet = "country"
+	FacetLanguage  MentorFacet = "language"
+	FacetGender    MentorFacet = "gender"
+
+	FilterEq     FilterOp = "eq"
+	FilterNe     FilterOp = "ne"
+	FilterGt     FilterOp = "gt"
+	FilterGte    FilterOp = "gte"
+	FilterLt     FilterOp = "lt"
+	FilterLte    FilterOp = "lte"
+	FilterIn     FilterOp = "in"
+	FilterLike   FilterOp = "like"
+	FilterPrefix FilterOp = "prefix"
+	FilterMatch  FilterOp = "match"
+
+	ImportValid   ImportStatus = "valid"
+	ImportCreated ImportStatus = "created"
+	ImportFailed  ImportStatus = "failed"
+
+	ExportCSV   ExportFormat = "csv"
+	ExportJSONL ExportFormat = "jsonl"
+
+	UserActive    UserStatus = "active"
+	UserSuspended UserStatus = "suspended"
+
+	DataExport  DataRequestKind = "export"
+	DataErasure DataRequestKind = "erasure"
+
+	DataPending   DataRequestStatus = "pending"
+	DataRunning   DataRequestStatus = "running"
+	DataCompleted DataRequestStatus = "completed"
+	DataFailed    DataRequestStatus = "failed"
+	DataExpired   DataRequestStatus = "expired"
+
+	NotifyElevationDecision NotificationKind = "elevation_decision"
 )
 
 func (pa PassAlgorithm) String() string {
@@ -109,6 +206,10 @@ func ResourceTypeAtoi(s string) ResourcesType {
 	}
 }
 
+func (ck CacheKey) String() string {
+	return string(ck)
+}
+
 func (ct ContextKey) String() string {
 	return string(ct)
 }
@@ -127,3 +228,63 @@ func (g Gender) String() string {
 		return ""
 	}
 }
+
+func (d Decision) String() string {
+	return string(d)
+}
+
+func (es ElevationStatus) String() string {
+	return string(es)
+}
+
+func (rs ReviewStatus) String() string {
+	return string(rs)
+}
+
+func (rd ReviewDecision) String() string {
+	return string(rd)
+}
+
+func (ms MentorSort) String() string {
+	return string(ms)
+}
+
+func (mf MentorFacet) String() string {
+	return string(mf)
+}
+
+func (fo FilterOp) String() string {
+	return string(fo)
+}
+
+func (is ImportStatus) String() string {
+	return string(is)
+}
+
+func (ef ExportFormat) String() string {
+	return string(ef)
+}
+
+func (us UserStatus) String() string {
+	return string(us)
+}
+
+func (dk DataRequestKind) String() string {
+	return string(dk)
+}
+
+func (ds DataRequestStatus) String() string {
+	return string(ds)
+}
+
+func (nk NotificationKind) String() string {
+	return string(nk)
+}
+
+// StatusOf is the status of a user whose credential is active or not.
+func StatusOf(isActive bool) UserStatus {
+	if isActive {
+		return UserActive
+	}
+	return UserSuspended
+}
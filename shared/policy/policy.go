@@ -0,0 +1,606 @@
+// Package policy implements the small expression language used to attach
+// attribute-based conditions to access rules.
+//
+// A condition is a boolean expression over three attribute roots:
+//
+//	subject.*   claims of the caller (sub, role_uid, ...)
+//	resource.*  attributes of the requested resource (route variables, owner_uid, ...)
+//	context.*   request context (hour, weekday, method, ip, ...)
+//
+// e.g. `resource.owner_uid == subject.sub || context.hour >= 9 && context.hour < 17`.
+//
+// The language has no function calls, assignments or loops and the size of an
+// expression is bounded, so evaluating an untrusted condition is always cheap
+// and side-effect free.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	MaxLength = 1024
+	maxDepth  = 32
+	maxNodes  = 256
+	// maxCached bounds the programs kept by Cached, the conditions coming from
+	// a handful of access rules.
+	maxCached = 1024
+)
+
+var (
+	ErrEmptyCondition = errors.New("condition is empty")
+	ErrNotBoolean     = errors.New("condition does not evaluate to a boolean")
+
+	cached   = make(map[string]*Program)
+	cachedMu sync.RWMutex
+
+	roots = map[string]bool{
+		"subject":  true,
+		"resource": true,
+		"context":  true,
+	}
+)
+
+// Env holds the attributes a condition is evaluated against, keyed by root.
+type Env map[string]interface{}
+
+type Program struct {
+	src  string
+	root node
+}
+
+// Compile parses and validates a condition.
+func Compile(src string) (*Program, error) {
+	if src == "" {
+		return nil, ErrEmptyCondition
+	}
+
+	if len(src) > MaxLength {
+		return nil, fmt.Errorf("condition exceeds %d characters", MaxLength)
+	}
+
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	root, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.value, tok.pos)
+	}
+
+	return &Program{src: src, root: root}, nil
+}
+
+// Cached returns the program of a condition, compiled the first time the
+// condition is seen and reused afterwards. The conditions failing to compile
+// aren't kept.
+func Cached(src string) (*Program, error) {
+	cachedMu.RLock()
+	prg, ok := cached[src]
+	cachedMu.RUnlock()
+
+	if ok {
+		return prg, nil
+	}
+
+	prg, err := Compile(src)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedMu.Lock()
+	if len(cached) < maxCached {
+		cached[src] = prg
+	}
+	cachedMu.Unlock()
+
+	return prg, nil
+}
+
+// Evaluate evaluates a condition, compiling it once through Cached. An empty
+// condition always allows.
+func Evaluate(src string, env Env) (bool, error) {
+	if src == "" {
+		return true, nil
+	}
+
+	prg, err := Cached(src)
+	if err != nil {
+		return false, err
+	}
+
+	return prg.Eval(env)
+}
+
+func (p *Program) String() string {
+	return p.src
+}
+
+func (p *Program) Eval(env Env) (bool, error) {
+	v, err := p.root.eval(env)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := v.(bool)
+	if !ok {
+		return false, ErrNotBoolean
+	}
+
+	return b, nil
+}
+
+type node interface {
+	eval(env Env) (interface{}, error)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+type pathNode struct {
+	parts []string
+}
+
+type listNode struct {
+	items []node
+}
+
+type notNode struct {
+	operand node
+}
+
+type logicalNode struct {
+	op          string
+	left, right node
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+type inNode struct {
+	needle, haystack node
+}
+
+func (n *literalNode) eval(Env) (interface{}, error) {
+	return n.value, nil
+}
+
+func (n *pathNode) eval(env Env) (interface{}, error) {
+	var cur interface{} = map[string]interface{}(env)
+
+	for _, part := range n.parts {
+		switch m := cur.(type) {
+		case map[string]interface{}:
+			cur = m[part]
+		case Env:
+			cur = m[part]
+		case map[string]string:
+			v, ok := m[part]
+			if !ok {
+				return nil, nil
+			}
+			cur = v
+		default:
+			return nil, nil
+		}
+	}
+
+	return normalize(cur), nil
+}
+
+func (n *listNode) eval(env Env) (interface{}, error) {
+	items := make([]interface{}, 0, len(n.items))
+	for _, item := range n.items {
+		v, err := item.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+	return items, nil
+}
+
+func (n *notNode) eval(env Env) (interface{}, error) {
+	v, err := n.operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("operand of ! is not a boolean")
+	}
+	return !b, nil
+}
+
+func (n *logicalNode) eval(env Env) (interface{}, error) {
+	l, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	lb, ok := l.(bool)
+	if !ok {
+		return nil, fmt.Errorf("left operand of %s is not a boolean", n.op)
+	}
+
+	if n.op == "&&" && !lb {
+		return false, nil
+	}
+	if n.op == "||" && lb {
+		return true, nil
+	}
+
+	r, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	rb, ok := r.(bool)
+	if !ok {
+		return nil, fmt.Errorf("right operand of %s is not a boolean", n.op)
+	}
+	return rb, nil
+}
+
+func (n *compareNode) eval(env Env) (interface{}, error) {
+	l, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equal(l, r), nil
+	case "!=":
+		return !equal(l, r), nil
+	}
+
+	switch lv := l.(type) {
+	case float64:
+		rv, ok := r.(float64)
+		if !ok {
+			return false, nil
+		}
+		return compareOrdered(n.op, lv, rv), nil
+	case string:
+		rv, ok := r.(string)
+		if !ok {
+			return false, nil
+		}
+		return compareOrdered(n.op, lv, rv), nil
+	}
+
+	return false, nil
+}
+
+func (n *inNode) eval(env Env) (interface{}, error) {
+	needle, err := n.needle.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	haystack, err := n.haystack.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	items, ok := haystack.([]interface{})
+	if !ok {
+		return false, nil
+	}
+
+	for _, item := range items {
+		if equal(needle, item) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func compareOrdered[T float64 | string](op string, l, r T) bool {
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	case ">=":
+		return l >= r
+	}
+	return false
+}
+
+func equal(l, r interface{}) bool {
+	switch lv := l.(type) {
+	case nil:
+		return r == nil
+	case float64, string, bool:
+		return l == r
+	case []interface{}:
+		rv, ok := r.([]interface{})
+		if !ok || len(lv) != len(rv) {
+			return false
+		}
+		for i := range lv {
+			if !equal(lv[i], rv[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// normalize converts attribute values into the handful of types the
+// evaluator understands: float64, string, bool, []interface{} and maps.
+func normalize(v interface{}) interface{} {
+	switch t := v.(type) {
+	case int:
+		return float64(t)
+	case int8:
+		return float64(t)
+	case int16:
+		return float64(t)
+	case int32:
+		return float64(t)
+	case int64:
+		return float64(t)
+	case uint:
+		return float64(t)
+	case uint8:
+		return float64(t)
+	case uint16:
+		return float64(t)
+	case uint32:
+		return float64(t)
+	case uint64:
+		return float64(t)
+	case float32:
+		return float64(t)
+	case []string:
+		items := make([]interface{}, 0, len(t))
+		for _, s := range t {
+			items = append(items, s)
+		}
+		return items
+	case []interface{}:
+		items := make([]interface{}, 0, len(t))
+		for _, item := range t {
+			items = append(items, normalize(item))
+		}
+		return items
+	}
+	return v
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	nodes  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) newNode(depth int) error {
+	p.nodes++
+	if p.nodes > maxNodes {
+		return fmt.Errorf("condition exceeds %d terms", maxNodes)
+	}
+	if depth > maxDepth {
+		return fmt.Errorf("condition nests deeper than %d levels", maxDepth)
+	}
+	return nil
+}
+
+func (p *parser) parseOr(depth int) (node, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokOperator && p.peek().value == "||" {
+		p.next()
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.newNode(depth); err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd(depth int) (node, error) {
+	left, err := p.parseNot(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokOperator && p.peek().value == "&&" {
+		p.next()
+		right, err := p.parseNot(depth)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.newNode(depth); err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot(depth int) (node, error) {
+	if tok := p.peek(); tok.kind == tokOperator && tok.value == "!" {
+		p.next()
+		if err := p.newNode(depth + 1); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseNot(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	return p.parseComparison(depth)
+}
+
+func (p *parser) parseComparison(depth int) (node, error) {
+	left, err := p.parseOperand(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	switch {
+	case tok.kind == tokIdent && tok.value == "in":
+		p.next()
+		right, err := p.parseOperand(depth)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.newNode(depth); err != nil {
+			return nil, err
+		}
+		return &inNode{needle: left, haystack: right}, nil
+	case tok.kind == tokOperator && tok.value != "&&" && tok.value != "||" && tok.value != "!":
+		p.next()
+		right, err := p.parseOperand(depth)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.newNode(depth); err != nil {
+			return nil, err
+		}
+		return &compareNode{op: tok.value, left: left, right: right}, nil
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseOperand(depth int) (node, error) {
+	if err := p.newNode(depth); err != nil {
+		return nil, err
+	}
+
+	tok := p.next()
+
+	switch tok.kind {
+	case tokLParen:
+		inner, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, fmt.Errorf("expected ) at position %d", closing.pos)
+		}
+		return inner, nil
+	case tokLBracket:
+		list := &listNode{}
+		if p.peek().kind == tokRBracket {
+			p.next()
+			return list, nil
+		}
+		for {
+			item, err := p.parseOperand(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			list.items = append(list.items, item)
+
+			sep := p.next()
+			if sep.kind == tokRBracket {
+				return list, nil
+			}
+			if sep.kind != tokComma {
+				return nil, fmt.Errorf("expected , or ] at position %d", sep.pos)
+			}
+		}
+	case tokString:
+		return &literalNode{value: tok.value}, nil
+	case tokNumber:
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.value, tok.pos)
+		}
+		return &literalNode{value: f}, nil
+	case tokIdent:
+		switch tok.value {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+
+		if !roots[tok.value] {
+			return nil, fmt.Errorf("unknown attribute root %q at position %d, expected subject, resource or context", tok.value, tok.pos)
+		}
+
+		path := &pathNode{parts: []string{tok.value}}
+		for p.peek().kind == tokDot {
+			p.next()
+			field := p.next()
+			if field.kind != tokIdent {
+				return nil, fmt.Errorf("expected attribute name at position %d", field.pos)
+			}
+			path.parts = append(path.parts, field.value)
+		}
+
+		if len(path.parts) < 2 {
+			return nil, fmt.Errorf("attribute root %q must be followed by a field at position %d", tok.value, tok.pos)
+		}
+		return path, nil
+	case tokEOF:
+		return nil, errors.New("unexpected end of condition")
+	}
+
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.value, tok.pos)
+}
+
+// TimeContext returns the time-based attributes exposed under context.*.
+func TimeContext(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"hour":    now.Hour(),
+		"minute":  now.Minute(),
+		"weekday": strings.ToLower(now.Weekday().String()),
+		"date":    now.Format(time.DateOnly),
+		"time":    now.Format("15:04"),
+		"unix":    now.Unix(),
+	}
+}
@@ -0,0 +1,116 @@
+package policy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCompileBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{name: "empty", src: "", wantErr: ErrEmptyCondition.Error()},
+		{name: "at the length limit", src: "subject.sub == '" + strings.Repeat("a", MaxLength-len("subject.sub == ''")) + "'"},
+		{name: "over the length limit", src: "subject.sub == '" + strings.Repeat("a", MaxLength) + "'", wantErr: "exceeds 1024 characters"},
+		{name: "nested to the depth limit", src: strings.Repeat("(", maxDepth) + "true" + strings.Repeat(")", maxDepth)},
+		{name: "nested over the depth limit", src: strings.Repeat("(", maxDepth+1) + "true" + strings.Repeat(")", maxDepth+1), wantErr: "nests deeper than 32 levels"},
+		{name: "negated over the depth limit", src: strings.Repeat("!", maxDepth+1) + "true", wantErr: "nests deeper than 32 levels"},
+		{name: "lists over the depth limit", src: "1 in " + strings.Repeat("[", maxDepth+1) + "1" + strings.Repeat("]", maxDepth+1), wantErr: "nests deeper than 32 levels"},
+		{name: "over the term limit", src: strings.TrimSuffix(strings.Repeat("true||", maxNodes/2+2), "||"), wantErr: "exceeds 256 terms"},
+		{name: "unterminated string", src: "subject.sub == 'a", wantErr: "unterminated string"},
+		{name: "unclosed paren", src: "(true", wantErr: "expected )"},
+		{name: "trailing token", src: "true true", wantErr: `unexpected "true"`},
+		{name: "dangling operator", src: "true &&", wantErr: "unexpected end of condition"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.src)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Compile() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Compile() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompileOperators(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{name: "equality", src: "subject.sub == resource.owner"},
+		{name: "ordering", src: "context.hour >= 9 && context.hour < 17"},
+		{name: "membership", src: "subject.role_uid in ['a', 'b']"},
+		{name: "negation", src: "!(context.weekday == 'sunday')"},
+		{name: "assignment", src: "subject.sub = 'a'", wantErr: "unexpected character '='"},
+		{name: "arithmetic", src: "context.hour + 1 > 9", wantErr: "unexpected character '+'"},
+		{name: "bitwise", src: "true & false", wantErr: "unexpected character '&'"},
+		{name: "statement separator", src: "true; false", wantErr: "unexpected character ';'"},
+		{name: "function call", src: "exec('rm')", wantErr: `unknown attribute root "exec"`},
+		{name: "method call", src: "subject.sub('a')", wantErr: `unexpected "("`},
+		{name: "unknown root", src: "env.PATH == ''", wantErr: `unknown attribute root "env"`},
+		{name: "bare root", src: "subject == null", wantErr: "must be followed by a field"},
+		{name: "numeric field", src: "subject.1 == 1", wantErr: "expected attribute name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.src)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Compile() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Compile() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	env := Env{
+		"subject":  map[string]interface{}{"sub": "u1", "role_uid": "mentor"},
+		"resource": map[string]string{"owner": "u1"},
+		"context":  map[string]interface{}{"hour": 10},
+	}
+
+	tests := []struct {
+		name    string
+		src     string
+		want    bool
+		wantErr error
+	}{
+		{name: "empty allows", src: "", want: true},
+		{name: "owner", src: "resource.owner == subject.sub", want: true},
+		{name: "not owner", src: "resource.owner != subject.sub", want: false},
+		{name: "office hours", src: "context.hour >= 9 && context.hour < 17", want: true},
+		{name: "role in list", src: "subject.role_uid in ['admin', 'mentor']", want: true},
+		{name: "missing attribute", src: "resource.mentor_uid == subject.sub", want: false},
+		{name: "short circuit", src: "true || subject.sub", want: true},
+		{name: "not a boolean", src: "subject.sub", wantErr: ErrNotBoolean},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Evaluate(tt.src, env)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Evaluate() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+// The uploaded images are decoded only when they fit these bounds, so a
+// small file can't inflate into a huge bitmap.
+const (
+	MaxImageSide   = 8000
+	MaxImagePixels = 40_000_000
+)
+
+var ErrUnsupportedImage = errors.New("image must be a JPEG or PNG")
+
+// Image is an uploaded image decoded upright, having lost its metadata.
+type Image struct {
+	Bitmap *image.RGBA
+	// Format is jpeg or png, the format the image is encoded back to.
+	Format string
+}
+
+// DecodeImage checks data is a JPEG or PNG of bounded size and decodes it,
+// turning it upright as told by its EXIF orientation.
+func DecodeImage(data []byte) (*Image, error) {
+
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return nil, ErrUnsupportedImage
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image: %w", err)
+	}
+
+	if "image/"+format != contentType {
+		return nil, ErrUnsupportedImage
+	}
+
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > MaxImageSide || cfg.Height > MaxImageSide ||
+		cfg.Width*cfg.Height > MaxImagePixels {
+		return nil, fmt.Errorf("image must be at most %dx%d pixels", MaxImageSide, MaxImageSide)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image: %w", err)
+	}
+
+	bitmap := image.NewRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(bitmap, bitmap.Bounds(), img, img.Bounds().Min, draw.Src)
+
+	if format == "jpeg" {
+		bitmap = orient(bitmap, jpegOrientation(data))
+	}
+
+	return &Image{Bitmap: bitmap, Format: format}, nil
+}
+
+// Encode writes the image in its format. The encoders write no metadata, so
+// the EXIF of the upload, GPS position included, is dropped.
+func (i *Image) Encode() ([]byte, error) {
+
+	var buf bytes.Buffer
+	var err error
+
+	if i.Format == "png" {
+		err = png.Encode(&buf, i.Bitmap)
+	} else {
+		err = jpeg.Encode(&buf, i.Bitmap, &jpeg.Options{Quality: 85})
+	}
+
+	return buf.Bytes(), err
+}
+
+func (i *Image) ContentType() string {
+	return "image/" + i.Format
+}
+
+func (i *Image) Extension() string {
+	if i.Format == "png" {
+		return ".png"
+	}
+	return ".jpg"
+}
+
+// Fit scales the image down to fit a size x size square, keeping its ratio.
+func (i *Image) Fit(size int) *Image {
+
+	w, h := i.Bitmap.Bounds().Dx(), i.Bitmap.Bounds().Dy()
+	if w <= size && h <= size {
+		return i
+	}
+
+	if w >= h {
+		h, w = h*size/w, size
+	} else {
+		w, h = w*size/h, size
+	}
+
+	if w < 1 {
+		w = 1
+	}
+
+	if h < 1 {
+		h = 1
+	}
+
+	return &Image{Bitmap: resize(i.Bitmap, w, h), Format: i.Format}
+}
+
+// Thumbnail crops the center square of the image and scales it to size.
+func (i *Image) Thumbnail(size int) *Image {
+
+	b := i.Bitmap.Bounds()
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+	square := i.Bitmap.SubImage(image.Rect(x0, y0, x0+side, y0+side)).(*image.RGBA)
+
+	if side < size {
+		size = side
+	}
+
+	return &Image{Bitmap: resize(square, size, size), Format: i.Format}
+}
+
+// resize scales src to w x h, averaging the source pixels each destination
+// pixel covers.
+func resize(src *image.RGBA, w, h int) *image.RGBA {
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+
+	for y := 0; y < h; y++ {
+		y0, y1 := y*sh/h, (y+1)*sh/h
+		if y1 == y0 {
+			y1++
+		}
+
+		for x := 0; x < w; x++ {
+			x0, x1 := x*sw/w, (x+1)*sw/w
+			if x1 == x0 {
+				x1++
+			}
+
+			var r, g, bl, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := src.PixOffset(b.Min.X, b.Min.Y+sy)
+				for sx := x0; sx < x1; sx++ {
+					p := src.Pix[row+sx*4 : row+sx*4+4]
+					r, g, bl, a = r+uint32(p[0]), g+uint32(p[1]), bl+uint32(p[2]), a+uint32(p[3])
+					n++
+				}
+			}
+
+			d := dst.PixOffset(x, y)
+			dst.Pix[d], dst.Pix[d+1], dst.Pix[d+2], dst.Pix[d+3] = uint8(r/n), uint8(g/n), uint8(bl/n), uint8(a/n)
+		}
+	}
+
+	return dst
+}
+
+// orient turns src upright from the EXIF orientation o, 1 to 8.
+func orient(src *image.RGBA, o int) *image.RGBA {
+
+	if o < 2 || o > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if o >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+
+			switch o {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+
+			s := src.PixOffset(src.Bounds().Min.X+sx, src.Bounds().Min.Y+sy)
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[s:s+4])
+		}
+	}
+
+	return dst
+}
+
+// jpegOrientation reads the orientation tag of the EXIF segment of a JPEG,
+// 1 when there is none.
+func jpegOrientation(data []byte) int {
+
+	// Walk the segments up to the start of the scan.
+	for i := 2; i+4 <= len(data) && data[i] == 0xFF; {
+		marker := data[i+1]
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+
+		if marker == 0xDA || size < 2 || i+2+size > len(data) {
+			break
+		}
+
+		segment := data[i+4 : i+2+size]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+
+		i += 2 + size
+	}
+
+	return 1
+}
+
+// exifOrientation finds the orientation tag, 0x0112, in the first IFD of the
+// TIFF structure of an EXIF segment.
+func exifOrientation(tiff []byte) int {
+
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < entries; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			break
+		}
+
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+
+	return 1
+}
@@ -0,0 +1,139 @@
+package util
+
+import (
+	"encoding/base64"
+	"errors"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/service"
+	"strings"
+	"unicode"
+
+	ulid "github.com/oklog/ulid/v2"
+)
+
+// The list endpoints answer DefaultListLimit rows unless asked for more, and
+// never more than MaxListLimit.
+const (
+	DefaultListLimit = 10
+	MaxListLimit     = 100
+)
+
+// ListReqOf turns the page asked for into the offset of its first row, or
+// into the uid the page starts after or before when asked by cursor,
+// bringing the limit within bounds.
+func ListReqOf(req service.ListReq) (*model.ListReq, error) {
+
+	list := &model.ListReq{
+		Limit: req.Limit,
+	}
+
+	if list.Limit <= 0 {
+		list.Limit = DefaultListLimit
+	} else if list.Limit > MaxListLimit {
+		list.Limit = MaxListLimit
+	}
+
+	for _, v := range req.Filters {
+		list.Filters = append(list.Filters, model.ListFilter(v))
+	}
+
+	for _, v := range req.Sorts {
+		list.Sorts = append(list.Sorts, model.ListSort(v))
+	}
+
+	if req.Cursor == "" {
+		list.Offset = PageToOffset(list.Limit, req.Page)
+		return list, nil
+	}
+
+	uid, before, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if before {
+		list.Before = uid
+	} else {
+		list.After = uid
+	}
+
+	return list, nil
+}
+
+// PaginationOf describes the page req asked for, turning the uids bounding
+// it into cursors.
+func PaginationOf(req *model.ListReq, page model.ListPage) service.Pagination {
+
+	pagination := service.Pagination{
+		PerPage:   req.Limit,
+		TotalPage: GetTotalPage(page.Total, req.Limit),
+		TotalData: page.Total,
+	}
+
+	if req.After == "" && req.Before == "" {
+		pagination.Page = req.Offset/req.Limit + 1
+	}
+
+	if page.NextUID != "" {
+		pagination.NextCursor = encodeCursor(page.NextUID, false)
+	}
+
+	if page.PrevUID != "" {
+		pagination.PrevCursor = encodeCursor(page.PrevUID, true)
+	}
+
+	return pagination
+}
+
+// A cursor is the uid of the row a page ends at, the next page starting
+// after it, or starts at, the previous page ending before it. It is opaque
+// to the clients so its shape can change.
+const (
+	cursorNext = "n"
+	cursorPrev = "p"
+)
+
+func encodeCursor(uid string, before bool) string {
+
+	direction := cursorNext
+	if before {
+		direction = cursorPrev
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(direction + uid))
+}
+
+func decodeCursor(cursor string) (uid string, before bool, err error) {
+
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(b) == 0 {
+		return "", false, errors.New("malformed cursor")
+	}
+
+	direction, uid := string(b[:1]), string(b[1:])
+
+	if direction != cursorNext && direction != cursorPrev {
+		return "", false, errors.New("malformed cursor")
+	}
+
+	if _, err = ulid.ParseStrict(uid); err != nil {
+		return "", false, errors.New("malformed cursor")
+	}
+
+	return uid, direction == cursorPrev, nil
+}
+
+// FulltextQuery turns the words of query into a fulltext search in boolean
+// mode requiring each of them as a prefix, dropping the operators.
+func FulltextQuery(query string) string {
+
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, v := range words {
+		words[i] = "+" + v + "*"
+	}
+
+	return strings.Join(words, " ")
+}
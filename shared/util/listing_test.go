@@ -0,0 +1,133 @@
+package util
+
+import (
+	"encoding/base64"
+	"github/yogabagas/join-app/domain/model"
+	"github/yogabagas/join-app/domain/service"
+	"strings"
+	"testing"
+)
+
+const testUID = "01HF8Z7Q4W2K9N3B5C6D7E8F9G"
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		uid    string
+		before bool
+	}{
+		{name: "next", uid: testUID},
+		{name: "previous", uid: testUID, before: true},
+		{name: "generated", uid: NewULIDGenerate()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uid, before, err := decodeCursor(encodeCursor(tt.uid, tt.before))
+			if err != nil {
+				t.Fatalf("decodeCursor() error = %v", err)
+			}
+			if uid != tt.uid || before != tt.before {
+				t.Fatalf("decodeCursor() = %q, %v, want %q, %v", uid, before, tt.uid, tt.before)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorTampered(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	valid := encodeCursor(testUID, false)
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "!!!"},
+		{name: "padded", cursor: base64.URLEncoding.EncodeToString([]byte("n" + testUID + "x"))},
+		{name: "standard alphabet", cursor: strings.NewReplacer("-", "+", "_", "/").Replace(encode("n" + testUID + "\xfb\xff"))},
+		{name: "empty payload", cursor: encode("")},
+		{name: "direction only", cursor: encode("n")},
+		{name: "unknown direction", cursor: encode("x" + testUID)},
+		{name: "lower case direction", cursor: encode("N" + testUID)},
+		{name: "truncated uid", cursor: encode("n" + testUID[:25])},
+		{name: "uid with a suffix", cursor: encode("n" + testUID + "0")},
+		{name: "uid out of the alphabet", cursor: encode("n" + testUID[:25] + "U")},
+		{name: "uid overflowing", cursor: encode("n8ZZZZZZZZZZZZZZZZZZZZZZZZ")},
+		{name: "sql in the uid", cursor: encode("n' OR '1'='1")},
+		{name: "truncated cursor", cursor: valid[:len(valid)-2]},
+		{name: "appended cursor", cursor: valid + "AA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uid, _, err := decodeCursor(tt.cursor)
+			if err == nil {
+				t.Fatalf("decodeCursor(%q) = %q, want an error", tt.cursor, uid)
+			}
+			if err.Error() != "malformed cursor" {
+				t.Fatalf("decodeCursor() error = %v, want malformed cursor", err)
+			}
+		})
+	}
+}
+
+func TestListReqOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     service.ListReq
+		want    model.ListReq
+		wantErr bool
+	}{
+		{name: "default limit", req: service.ListReq{}, want: model.ListReq{Limit: DefaultListLimit}},
+		{name: "limit bounded", req: service.ListReq{Limit: 1000, Page: 2}, want: model.ListReq{Limit: MaxListLimit, Offset: MaxListLimit}},
+		{name: "page", req: service.ListReq{Limit: 20, Page: 3}, want: model.ListReq{Limit: 20, Offset: 40}},
+		{name: "next cursor", req: service.ListReq{Cursor: encodeCursor(testUID, false), Page: 3}, want: model.ListReq{Limit: DefaultListLimit, After: testUID}},
+		{name: "previous cursor", req: service.ListReq{Cursor: encodeCursor(testUID, true)}, want: model.ListReq{Limit: DefaultListLimit, Before: testUID}},
+		{name: "tampered cursor", req: service.ListReq{Cursor: "bi0tMQ"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ListReqOf(tt.req)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ListReqOf() = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListReqOf() error = %v", err)
+			}
+			if got.Limit != tt.want.Limit || got.Offset != tt.want.Offset || got.After != tt.want.After || got.Before != tt.want.Before {
+				t.Fatalf("ListReqOf() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginationOfCursors(t *testing.T) {
+	const nextUID, prevUID = "01HF8Z7Q4W2K9N3B5C6D7E8F9H", "01HF8Z7Q4W2K9N3B5C6D7E8F9A"
+
+	pagination := PaginationOf(&model.ListReq{Limit: 10, After: testUID}, model.ListPage{
+		Total:   30,
+		NextUID: nextUID,
+		PrevUID: prevUID,
+	})
+
+	if pagination.Page != 0 || pagination.TotalPage != 3 {
+		t.Fatalf("PaginationOf() page = %d of %d, want 0 of 3", pagination.Page, pagination.TotalPage)
+	}
+
+	next, err := ListReqOf(service.ListReq{Cursor: pagination.NextCursor})
+	if err != nil || next.After != nextUID || next.Before != "" {
+		t.Fatalf("next cursor = %+v, %v, want after %s", next, err, nextUID)
+	}
+
+	prev, err := ListReqOf(service.ListReq{Cursor: pagination.PrevCursor})
+	if err != nil || prev.Before != prevUID || prev.After != "" {
+		t.Fatalf("previous cursor = %+v, %v, want before %s", prev, err, prevUID)
+	}
+}
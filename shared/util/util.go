@@ -3,6 +3,7 @@ package util
 import (
 	"crypto/md5"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
 	"errors"
 	"github/yogabagas/join-app/shared/constant"
@@ -48,6 +49,31 @@ func Hash(alg, pwd string) ([]byte, error) {
 
 }
 
+// CompareHash tells whether pwd is the password of hashed, a Base64 encoded
+// hash made by Hash with alg.
+func CompareHash(alg, pwd, hashed string) (bool, error) {
+
+	b, err := base64.StdEncoding.DecodeString(hashed)
+	if err != nil {
+		return false, err
+	}
+
+	switch alg {
+	case constant.Bcrypt.String():
+		return bcrypt.CompareHashAndPassword(b, []byte(pwd)) == nil, nil
+	case constant.Argon.String():
+		return argon2.VerifyEncoded([]byte(pwd), b)
+	case constant.MD5.String(), constant.SHA.String():
+		h, err := Hash(alg, pwd)
+		if err != nil {
+			return false, err
+		}
+		return subtle.ConstantTimeCompare(h, b) == 1, nil
+	default:
+		return false, errors.New("[CLIENT] - Unsupported algorithm")
+	}
+}
+
 func Base64(b []byte) string {
 	return base64.StdEncoding.EncodeToString(b)
 }
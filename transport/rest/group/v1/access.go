@@ -10,4 +10,7 @@ import (
 func NewAccessV1(h handler.HandlerImpl, r *mux.Router) {
 	r.HandleFunc("/access", h.UpsertAccess).Methods(http.MethodPut)
 	r.HandleFunc("/access/{type}", h.GetAccessByRoleUID).Methods(http.MethodGet)
+	r.HandleFunc("/roles/{uid}/access/history", h.GetAccessHistory).Methods(http.MethodGet)
+	r.HandleFunc("/roles/{uid}/access/history/diff", h.DiffAccessHistory).Methods(http.MethodGet)
+	r.HandleFunc("/roles/{uid}/access/history/{version:[0-9]+}/rollback", h.RollbackAccess).Methods(http.MethodPost)
 }
@@ -0,0 +1,16 @@
+package v1
+
+import (
+	"github/yogabagas/join-app/transport/rest/handler"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func NewAccessReviewsV1(h handler.HandlerImpl, r *mux.Router) {
+	r.HandleFunc("/access-reviews", h.CreateAccessReview).Methods(http.MethodPost)
+	r.HandleFunc("/access-reviews", h.GetAccessReviews).Methods(http.MethodGet)
+	r.HandleFunc("/access-reviews/{uid}/items", h.GetAccessReviewItems).Methods(http.MethodGet)
+	r.HandleFunc("/access-reviews/{uid}/items/{item_uid}/decision", h.DecideAccessReviewItem).Methods(http.MethodPost)
+	r.HandleFunc("/access-reviews/{uid}/export", h.ExportAccessReview).Methods(http.MethodGet)
+}
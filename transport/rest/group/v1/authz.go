@@ -9,5 +9,9 @@ import (
 
 func NewAuthzV1(h handler.HandlerImpl, r *mux.Router) {
 	r.HandleFunc("/login", h.Login).Methods(http.MethodPost)
+	r.HandleFunc("/refresh", h.RefreshToken).Methods(http.MethodPost)
 	r.HandleFunc("/logout", h.Logout).Methods(http.MethodDelete)
+	r.HandleFunc("/authz", h.GrantRole).Methods(http.MethodPost)
+	r.HandleFunc("/authz/check", h.CheckPermission).Methods(http.MethodPost)
+	r.HandleFunc("/authz/check/batch", h.CheckPermissionBatch).Methods(http.MethodPost)
 }
@@ -0,0 +1,16 @@
+package v1
+
+import (
+	"github/yogabagas/join-app/transport/rest/handler"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func NewElevationsV1(h handler.HandlerImpl, r *mux.Router) {
+	r.HandleFunc("/elevations", h.CreateElevation).Methods(http.MethodPost)
+	r.HandleFunc("/elevations", h.GetElevations).Methods(http.MethodGet)
+	r.HandleFunc("/elevations/mine", h.GetMyElevations).Methods(http.MethodGet)
+	r.HandleFunc("/elevations/{uid}/approve", h.ApproveElevation).Methods(http.MethodPost)
+	r.HandleFunc("/elevations/{uid}/deny", h.DenyElevation).Methods(http.MethodPost)
+}
@@ -0,0 +1,20 @@
+package v1
+
+import (
+	"github/yogabagas/join-app/transport/rest/handler"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func NewExpertisesV1(h handler.HandlerImpl, r *mux.Router) {
+	r.HandleFunc("/expertises", h.CreateExpertise).Methods(http.MethodPost)
+	r.HandleFunc("/expertises", h.GetExpertises).Methods(http.MethodGet)
+	r.HandleFunc("/expertises/autocomplete", h.AutocompleteExpertises).Methods(http.MethodGet)
+	r.HandleFunc("/expertises/{uid}", h.UpdateExpertise).Methods(http.MethodPut)
+	r.HandleFunc("/expertises/{uid}", h.DeleteExpertise).Methods(http.MethodDelete)
+	r.HandleFunc("/me/expertises", h.GetUserExpertises).Methods(http.MethodGet)
+	r.HandleFunc("/me/expertises", h.SetUserExpertises).Methods(http.MethodPut)
+	r.HandleFunc("/users/{uid}/expertises", h.GetUserExpertises).Methods(http.MethodGet)
+	r.HandleFunc("/users/{uid}/expertises", h.SetUserExpertises).Methods(http.MethodPut)
+}
@@ -0,0 +1,12 @@
+package v1
+
+import (
+	"github/yogabagas/join-app/transport/rest/handler"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func NewMentorsV1(h handler.HandlerImpl, r *mux.Router) {
+	r.HandleFunc("/mentors/search", h.SearchMentors).Methods(http.MethodGet)
+}
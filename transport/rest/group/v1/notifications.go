@@ -0,0 +1,13 @@
+package v1
+
+import (
+	"github/yogabagas/join-app/transport/rest/handler"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func NewNotificationsV1(h handler.HandlerImpl, r *mux.Router) {
+	r.HandleFunc("/me/notifications", h.GetNotifications).Methods(http.MethodGet)
+	r.HandleFunc("/me/notifications/{uid}/read", h.ReadNotification).Methods(http.MethodPost)
+}
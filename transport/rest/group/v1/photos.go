@@ -0,0 +1,16 @@
+package v1
+
+import (
+	"github/yogabagas/join-app/transport/rest/handler"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func NewPhotosV1(h handler.HandlerImpl, r *mux.Router) {
+	r.HandleFunc("/me/photo", h.UploadPhoto).Methods(http.MethodPut)
+	r.HandleFunc("/me/photo", h.DeletePhoto).Methods(http.MethodDelete)
+	r.HandleFunc("/users/{uid}/photo", h.UploadPhoto).Methods(http.MethodPut)
+	r.HandleFunc("/users/{uid}/photo", h.DeletePhoto).Methods(http.MethodDelete)
+	r.HandleFunc("/blobs/{key:.+}", h.GetBlob).Methods(http.MethodGet)
+}
@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"github/yogabagas/join-app/transport/rest/handler"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func NewProfileSectionsV1(h handler.HandlerImpl, r *mux.Router) {
+	for _, prefix := range []string{"/me", "/users/{uid}"} {
+		r.HandleFunc(prefix+"/educations", h.GetEducations).Methods(http.MethodGet)
+		r.HandleFunc(prefix+"/educations", h.CreateEducation).Methods(http.MethodPost)
+		r.HandleFunc(prefix+"/educations/order", h.ReorderEducations).Methods(http.MethodPut)
+		r.HandleFunc(prefix+"/educations/{section_uid}", h.UpdateEducation).Methods(http.MethodPut)
+		r.HandleFunc(prefix+"/educations/{section_uid}", h.DeleteEducation).Methods(http.MethodDelete)
+		r.HandleFunc(prefix+"/work-experiences", h.GetWorkExperiences).Methods(http.MethodGet)
+		r.HandleFunc(prefix+"/work-experiences", h.CreateWorkExperience).Methods(http.MethodPost)
+		r.HandleFunc(prefix+"/work-experiences/order", h.ReorderWorkExperiences).Methods(http.MethodPut)
+		r.HandleFunc(prefix+"/work-experiences/{section_uid}", h.UpdateWorkExperience).Methods(http.MethodPut)
+		r.HandleFunc(prefix+"/work-experiences/{section_uid}", h.DeleteWorkExperience).Methods(http.MethodDelete)
+		r.HandleFunc(prefix+"/languages", h.GetLanguages).Methods(http.MethodGet)
+		r.HandleFunc(prefix+"/languages", h.CreateLanguage).Methods(http.MethodPost)
+		r.HandleFunc(prefix+"/languages/order", h.ReorderLanguages).Methods(http.MethodPut)
+		r.HandleFunc(prefix+"/languages/{section_uid}", h.DeleteLanguage).Methods(http.MethodDelete)
+		r.HandleFunc(prefix+"/socials", h.GetSocials).Methods(http.MethodGet)
+		r.HandleFunc(prefix+"/socials", h.CreateSocial).Methods(http.MethodPost)
+		r.HandleFunc(prefix+"/socials/order", h.ReorderSocials).Methods(http.MethodPut)
+		r.HandleFunc(prefix+"/socials/{section_uid}", h.UpdateSocial).Methods(http.MethodPut)
+		r.HandleFunc(prefix+"/socials/{section_uid}", h.DeleteSocial).Methods(http.MethodDelete)
+	}
+}
@@ -9,5 +9,6 @@ import (
 
 func NewResourcesV1(h handler.HandlerImpl, r *mux.Router) {
 	r.HandleFunc("/resources", h.CreateResources).Methods(http.MethodPost)
+	r.HandleFunc("/resources", h.GetResources).Methods(http.MethodGet)
 	r.HandleFunc("/resources/{type}", h.GetResourcesByType).Methods(http.MethodGet)
 }
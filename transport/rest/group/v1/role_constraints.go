@@ -0,0 +1,15 @@
+package v1
+
+import (
+	"github/yogabagas/join-app/transport/rest/handler"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func NewRoleConstraintsV1(h handler.HandlerImpl, r *mux.Router) {
+	r.HandleFunc("/role-constraints", h.CreateRoleConstraint).Methods(http.MethodPost)
+	r.HandleFunc("/role-constraints", h.GetRoleConstraints).Methods(http.MethodGet)
+	r.HandleFunc("/role-constraints/violations", h.GetRoleConstraintViolations).Methods(http.MethodGet)
+	r.HandleFunc("/role-constraints/{uid}", h.DeleteRoleConstraint).Methods(http.MethodDelete)
+}
@@ -9,4 +9,5 @@ import (
 
 func NewRolesV1(h handler.HandlerImpl, r *mux.Router) {
 	r.HandleFunc("/roles", h.CreateRoles).Methods(http.MethodPost)
+	r.HandleFunc("/roles", h.GetRoles).Methods(http.MethodGet)
 }
@@ -10,4 +10,20 @@ import (
 func NewUsersV1(h handler.HandlerImpl, r *mux.Router) {
 	r.HandleFunc("/users", h.CreateUsers).Methods(http.MethodPost)
 	r.HandleFunc("/users", h.GetUsersWithPagination).Methods(http.MethodGet)
+	r.HandleFunc("/users/import", h.ImportUsers).Methods(http.MethodPost)
+	r.HandleFunc("/users/export", h.ExportUsers).Methods(http.MethodGet)
+	r.HandleFunc("/users/{uid}", h.GetUser).Methods(http.MethodGet)
+	r.HandleFunc("/users/{uid}", h.UpdateUser).Methods(http.MethodPatch)
+	r.HandleFunc("/users/{uid}", h.DeleteUser).Methods(http.MethodDelete)
+	r.HandleFunc("/users/{uid}/restore", h.RestoreUser).Methods(http.MethodPost)
+	r.HandleFunc("/users/{uid}/suspend", h.SuspendUser).Methods(http.MethodPost)
+	r.HandleFunc("/users/{uid}/reactivate", h.ReactivateUser).Methods(http.MethodPost)
+	r.HandleFunc("/users/{uid}/erase", h.EraseUser).Methods(http.MethodPost)
+	r.HandleFunc("/data-requests", h.GetDataRequests).Methods(http.MethodGet)
+	r.HandleFunc("/me", h.GetMe).Methods(http.MethodGet)
+	r.HandleFunc("/me", h.UpdateMe).Methods(http.MethodPatch)
+	r.HandleFunc("/me/password", h.ChangePassword).Methods(http.MethodPost)
+	r.HandleFunc("/me/data-exports", h.RequestDataExport).Methods(http.MethodPost)
+	r.HandleFunc("/me/erasure-requests", h.RequestErasure).Methods(http.MethodPost)
+	r.HandleFunc("/me/data-requests", h.GetMyDataRequests).Methods(http.MethodGet)
 }
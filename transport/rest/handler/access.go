@@ -1,12 +1,12 @@
 package handler
 
 import (
-	"encoding/json"
 	"errors"
 	"github/yogabagas/join-app/domain/service"
 	"github/yogabagas/join-app/shared/constant"
 	"github/yogabagas/join-app/transport/rest/handler/response"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 )
@@ -20,6 +20,8 @@ import (
 // @Param access body service.UpsertAccessReq true "Request Upsert Access"
 // @Success 200 {object} response.JSONResponse().APIStatusCreated()
 // @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
 // @Failure 500 {object} response.JSONResponse
 // @Router /v1/access [PUT]
 func (h *HandlerImpl) UpsertAccess(w http.ResponseWriter, r *http.Request) {
@@ -33,14 +35,20 @@ func (h *HandlerImpl) UpsertAccess(w http.ResponseWriter, r *http.Request) {
 
 	var req service.UpsertAccessReq
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
 		return
 	}
 
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	req.ActorRoleUID = claims.RoleUID
+	req.CreatedBy = claims.Sub
+	req.UpdatedBy = claims.Sub
+
 	err := h.Controller.AccessController.UpsertAccess(r.Context(), req)
 	if err != nil {
-		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
 		return
 	}
 
@@ -76,6 +84,7 @@ func (h *HandlerImpl) GetAccessByRoleUID(w http.ResponseWriter, r *http.Request)
 	}
 
 	req := service.GetAccessByRoleUIDReq{
+		UserUID: claims.Sub,
 		RoleUID: claims.RoleUID,
 		Type:    constant.ResourceTypeAtoi(t).Int(),
 	}
@@ -88,3 +97,129 @@ func (h *HandlerImpl) GetAccessByRoleUID(w http.ResponseWriter, r *http.Request)
 
 	res.SetData(resp).Send(w)
 }
+
+// GetAccessHistory handler
+// @Summary GetAccessHistory
+// @Description GetAccessHistory for list the versions of a role's access, newest first
+// @Tags Access
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "role uid"
+// @Success 200 {object} response.JSONResponse{data=[]service.AccessHistoryResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/roles/{uid}/access/history [GET]
+func (h *HandlerImpl) GetAccessHistory(w http.ResponseWriter, r *http.Request) {
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	req := service.GetAccessHistoryReq{
+		RoleUID: mux.Vars(r)["uid"],
+	}
+
+	resp, err := h.Controller.AccessController.GetAccessHistory(r.Context(), req)
+	if err != nil {
+		res.SetError(response.ErrInternalServerError).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// DiffAccessHistory handler
+// @Summary DiffAccessHistory
+// @Description DiffAccessHistory for compare two versions of a role's access, version 0 being no access at all
+// @Tags Access
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "role uid"
+// @Param from query int true "from version"
+// @Param to query int true "to version"
+// @Success 200 {object} response.JSONResponse{data=service.DiffAccessHistoryResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/roles/{uid}/access/history/diff [GET]
+func (h *HandlerImpl) DiffAccessHistory(w http.ResponseWriter, r *http.Request) {
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil || from < 0 {
+		res.SetError(response.ErrBadRequest).SetMessage(errors.New("from must be a version number").Error()).Send(w)
+		return
+	}
+
+	to, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil || to < 0 {
+		res.SetError(response.ErrBadRequest).SetMessage(errors.New("to must be a version number").Error()).Send(w)
+		return
+	}
+
+	req := service.DiffAccessHistoryReq{
+		RoleUID: mux.Vars(r)["uid"],
+		From:    from,
+		To:      to,
+	}
+
+	resp, err := h.Controller.AccessController.DiffAccessHistory(r.Context(), req)
+	if err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// RollbackAccess handler
+// @Summary RollbackAccess
+// @Description RollbackAccess for restore a role's access to a previous recorded version, from 1, recorded as a new version
+// @Tags Access
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "role uid"
+// @Param version path int true "version to restore"
+// @Success 200 {object} response.JSONResponse().APIStatusCreated()
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/roles/{uid}/access/history/{version}/rollback [POST]
+func (h *HandlerImpl) RollbackAccess(w http.ResponseWriter, r *http.Request) {
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	version, err := strconv.Atoi(vars["version"])
+	if err != nil || version < 1 {
+		res.SetError(response.ErrBadRequest).SetMessage(errors.New("version must be a recorded version, from 1").Error()).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	req := service.RollbackAccessReq{
+		RoleUID:      vars["uid"],
+		Version:      version,
+		ActorRoleUID: claims.RoleUID,
+		UpdatedBy:    claims.Sub,
+	}
+
+	if err = h.Controller.AccessController.RollbackAccess(r.Context(), req); err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.APIStatusCreated().Send(w)
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// CreateAccessReview handler
+// @Summary CreateAccessReview
+// @Description CreateAccessReview for open a campaign over every current grant, each assigned to a reviewer
+// @Tags AccessReviews
+// @Produce json
+// @Security ApiKeyAuth
+// @Param reviews body service.CreateAccessReviewReq true "Request Create Access Review"
+// @Success 200 {object} response.JSONResponse{data=service.AccessReviewResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/access-reviews [POST]
+func (h *HandlerImpl) CreateAccessReview(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.CreateAccessReviewReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.CreatedBy = claims.Sub
+
+	resp, err := h.Controller.AccessReviewsController.CreateAccessReview(r.Context(), req)
+	if err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.APIStatusCreated().SetData(resp).Send(w)
+}
+
+// GetAccessReviews handler
+// @Summary GetAccessReviews
+// @Description GetAccessReviews for list the access review campaigns of the organization
+// @Tags AccessReviews
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "open or closed"
+// @Success 200 {object} response.JSONResponse{data=[]service.AccessReviewResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/access-reviews [GET]
+func (h *HandlerImpl) GetAccessReviews(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	resp, err := h.Controller.AccessReviewsController.GetAccessReviews(r.Context(), service.GetAccessReviewsReq{
+		Status: r.URL.Query().Get("status"),
+	})
+	if err != nil {
+		res.SetError(response.ErrInternalServerError).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// GetAccessReviewItems handler
+// @Summary GetAccessReviewItems
+// @Description GetAccessReviewItems for list the grants under review, reviewer=me keeps the ones assigned to the caller
+// @Tags AccessReviews
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "access review uid"
+// @Param reviewer query string false "me or a reviewer uid"
+// @Param pending query bool false "only the items without decision"
+// @Success 200 {object} response.JSONResponse{data=[]service.AccessReviewItemResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/access-reviews/{uid}/items [GET]
+func (h *HandlerImpl) GetAccessReviewItems(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	req := service.GetAccessReviewItemsReq{
+		ReviewUID:   mux.Vars(r)["uid"],
+		ReviewerUID: r.URL.Query().Get("reviewer"),
+	}
+
+	if req.ReviewerUID == "me" {
+		req.ReviewerUID = r.Context().Value(constant.Claim).(service.JWTClaims).Sub
+	}
+
+	if pending := r.URL.Query().Get("pending"); pending != "" {
+		p, err := strconv.ParseBool(pending)
+		if err != nil {
+			res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+			return
+		}
+		req.Pending = p
+	}
+
+	resp, err := h.Controller.AccessReviewsController.GetAccessReviewItems(r.Context(), req)
+	if err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// DecideAccessReviewItem handler
+// @Summary DecideAccessReviewItem
+// @Description DecideAccessReviewItem for confirm or revoke a grant under review, revoking it right away
+// @Tags AccessReviews
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "access review uid"
+// @Param item_uid path string true "access review item uid"
+// @Param decision body service.DecideAccessReviewItemReq true "Request Decide Access Review Item"
+// @Success 200 {object} response.JSONResponse{data=service.AccessReviewItemResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/access-reviews/{uid}/items/{item_uid}/decision [POST]
+func (h *HandlerImpl) DecideAccessReviewItem(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.DecideAccessReviewItemReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	vars := mux.Vars(r)
+	req.ReviewUID = vars["uid"]
+	req.ItemUID = vars["item_uid"]
+	req.ActorUID = claims.Sub
+
+	resp, err := h.Controller.AccessReviewsController.DecideAccessReviewItem(r.Context(), req)
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// ExportAccessReview handler
+// @Summary ExportAccessReview
+// @Description ExportAccessReview for download the decisions of a campaign as CSV
+// @Tags AccessReviews
+// @Produce text/csv
+// @Security ApiKeyAuth
+// @Param uid path string true "access review uid"
+// @Success 200 {string} string "CSV file"
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/access-reviews/{uid}/export [GET]
+func (h *HandlerImpl) ExportAccessReview(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	resp, err := h.Controller.AccessReviewsController.ExportAccessReview(r.Context(), service.ExportAccessReviewReq{
+		ReviewUID: mux.Vars(r)["uid"],
+	})
+	if err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="access-review-%s.csv"`, resp.Review.UID))
+
+	cw := csv.NewWriter(w)
+
+	_ = cw.Write([]string{"review_uid", "review_name", "deadline", "item_uid", "authz_uid", "user_uid", "user_email",
+		"role_uid", "role_name", "reviewer_uid", "decision", "decided_by", "decided_at", "note"})
+
+	for _, v := range resp.Items {
+		decision, decidedAt := v.Decision, ""
+		if decision == "" {
+			decision = "pending"
+		}
+		if v.DecidedAt != nil {
+			decidedAt = v.DecidedAt.UTC().Format(time.RFC3339)
+		}
+
+		_ = cw.Write([]string{resp.Review.UID, resp.Review.Name, resp.Review.Deadline.UTC().Format(time.RFC3339), v.UID,
+			v.AuthzUID, v.UserUID, v.UserEmail, v.RoleUID, v.RoleName, v.ReviewerUID, decision, v.DecidedBy, decidedAt, v.Note})
+	}
+
+	cw.Flush()
+}
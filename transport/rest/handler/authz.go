@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"github/yogabagas/join-app/domain/service"
 	"github/yogabagas/join-app/shared/constant"
 	"github/yogabagas/join-app/shared/util"
@@ -15,8 +14,12 @@ import (
 // @Tags Users
 // @Produce json
 // @Param users body service.LoginReq true "Request Login"
+// @Param X-Organization header string false "organization uid or slug, the default organization when unset"
 // @Success 200 {object} response.JSONResponse().APIStatusSuccess()
 // @Failure 400 {object} response.JSONResponse
+// @Failure 401 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
 // @Failure 500 {object} response.JSONResponse
 // @Router /v1/login [POST]
 func (h *HandlerImpl) Login(w http.ResponseWriter, r *http.Request) {
@@ -30,8 +33,8 @@ func (h *HandlerImpl) Login(w http.ResponseWriter, r *http.Request) {
 
 	var req service.LoginReq
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
 		return
 	}
 
@@ -42,13 +45,58 @@ func (h *HandlerImpl) Login(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.Controller.AuthzController.Login(r.Context(), req)
 	if err != nil {
-		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		usecaseError(res, err, response.ErrInternalServerError).Send(w)
 		return
 	}
 
 	res.APIStatusSuccess().SetResult(user).Send(w)
 }
 
+// RefreshToken handler
+// @Summary RefreshToken
+// @Description RefreshToken for get new tokens of the session of a refresh token, as long as the session wasn't ended and the account isn't suspended
+// @Tags Users
+// @Produce json
+// @Param refresh body service.RefreshReq true "Request Refresh Token"
+// @Success 200 {object} response.JSONResponse().APIStatusSuccess()
+// @Failure 401 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/refresh [POST]
+func (h *HandlerImpl) RefreshToken(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.RefreshReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims, err := h.Controller.JWKController.VerifyRefreshJWT(r.Context(), service.VerifyTokenReq{
+		Token: "Bearer " + req.RefreshToken,
+	})
+	if err != nil {
+		res.SetError(response.ErrUnauthorized).SetMessage("invalid refresh token, please re-authenticate").Send(w)
+		return
+	}
+
+	tokens, err := h.Controller.AuthzController.RefreshToken(r.Context(), claims)
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrUnauthorized)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.APIStatusSuccess().SetResult(tokens).Send(w)
+}
+
 // Logout handler
 // @Summary Logout
 // @Description Logout endpoint
@@ -70,7 +118,8 @@ func (h *HandlerImpl) Logout(w http.ResponseWriter, r *http.Request) {
 	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
 
 	req := service.LogoutReq{
-		UserUID: claims.Sub,
+		UserUID:    claims.Sub,
+		SessionUID: claims.SessionUID,
 	}
 
 	err := h.Controller.AuthzController.Logout(r.Context(), req)
@@ -81,3 +130,120 @@ func (h *HandlerImpl) Logout(w http.ResponseWriter, r *http.Request) {
 
 	res.APIStatusNoContent().Send(w)
 }
+
+// CheckPermission handler
+// @Summary CheckPermission
+// @Description CheckPermission answers whether a user can do an action on a resource
+// @Tags Authz
+// @Produce json
+// @Security ApiKeyAuth
+// @Param check body service.CheckPermissionReq true "Request Check Permission"
+// @Success 200 {object} response.JSONResponse{data=service.CheckPermissionResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/authz/check [POST]
+func (h *HandlerImpl) CheckPermission(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.CheckPermissionReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	resp, err := h.Controller.AuthzController.CheckPermission(r.Context(), req)
+	if err != nil {
+		usecaseError(res, err, response.ErrInternalServerError).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// CheckPermissionBatch handler
+// @Summary CheckPermissionBatch
+// @Description CheckPermissionBatch evaluates several permission checks at once, a check that can't be made denying with its error
+// @Tags Authz
+// @Produce json
+// @Security ApiKeyAuth
+// @Param check body service.CheckPermissionBatchReq true "Request Check Permission Batch"
+// @Success 200 {object} response.JSONResponse{data=service.CheckPermissionBatchResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/authz/check/batch [POST]
+func (h *HandlerImpl) CheckPermissionBatch(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.CheckPermissionBatchReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	resp, err := h.Controller.AuthzController.CheckPermissionBatch(r.Context(), req)
+	if err != nil {
+		usecaseError(res, err, response.ErrInternalServerError).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// GrantRole handler
+// @Summary GrantRole
+// @Description GrantRole for grant a role to a user, optionally only between valid_from and valid_until
+// @Tags Authz
+// @Produce json
+// @Security ApiKeyAuth
+// @Param authz body service.GrantRoleReq true "Request Grant Role"
+// @Success 200 {object} response.JSONResponse{data=service.GrantRoleResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/authz [POST]
+func (h *HandlerImpl) GrantRole(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.GrantRoleReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.ActorRoleUID = claims.RoleUID
+	req.CreatedBy = claims.Sub
+
+	resp, err := h.Controller.AuthzController.GrantRole(r.Context(), req)
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.APIStatusCreated().SetData(resp).Send(w)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// GetBlob handler
+// @Summary GetBlob
+// @Description GetBlob for serve a blob of the local store at a signed URL, open to anyone holding the URL until it expires
+// @Tags Blobs
+// @Produce octet-stream
+// @Param key path string true "blob key"
+// @Param expires query int true "unix time the URL expires at"
+// @Param signature query string true "signature of the URL"
+// @Success 200 {file} file
+// @Failure 403 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/blobs/{key} [GET]
+func (h *HandlerImpl) GetBlob(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	query := r.URL.Query()
+
+	resp, err := h.Controller.BlobsController.GetBlob(r.Context(), service.GetBlobReq{
+		Key:       mux.Vars(r)["key"],
+		Expires:   query.Get("expires"),
+		Signature: query.Get("signature"),
+	})
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrInternalServerError)).SetMessage(err.Error()).Send(w)
+		return
+	}
+	defer resp.Body.Close()
+
+	w.Header().Set("Content-Type", resp.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(resp.Size, 10))
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if _, err = io.Copy(w, resp.Body); err != nil {
+		log.Println("error serve blob", err)
+	}
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// RequestDataExport handler
+// @Summary RequestDataExport
+// @Description RequestDataExport for queue an archive of the personal data of the caller, downloadable from their data requests once compiled
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body service.DataRequestReq false "Request Data Export"
+// @Success 201 {object} response.JSONResponse{data=service.DataRequestResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/data-exports [POST]
+func (h *HandlerImpl) RequestDataExport(w http.ResponseWriter, r *http.Request) {
+	h.createDataRequest(w, r, h.Controller.UsersController.RequestDataExport)
+}
+
+// RequestErasure handler
+// @Summary RequestErasure
+// @Description RequestErasure for ask an administrator to erase the personal data of the caller
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body service.DataRequestReq false "Request Erasure"
+// @Success 201 {object} response.JSONResponse{data=service.DataRequestResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/erasure-requests [POST]
+func (h *HandlerImpl) RequestErasure(w http.ResponseWriter, r *http.Request) {
+	h.createDataRequest(w, r, h.Controller.UsersController.RequestErasure)
+}
+
+// createDataRequest reads the data request of the caller, an empty body
+// standing for no note.
+func (h *HandlerImpl) createDataRequest(w http.ResponseWriter, r *http.Request,
+	create func(ctx context.Context, req service.DataRequestReq) (service.DataRequestResp, error)) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.DataRequestReq
+
+	if err := decodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.UserUID = claims.Sub
+	req.ActorUID = claims.Sub
+
+	resp, err := create(r.Context(), req)
+	if err != nil {
+		usecaseError(res, err, response.ErrBadRequest).Send(w)
+		return
+	}
+
+	res.APIStatusCreated().SetData(resp).Send(w)
+}
+
+// GetMyDataRequests handler
+// @Summary GetMyDataRequests
+// @Description GetMyDataRequests for list the data requests of the caller, the latest first, with the download URL of the completed exports
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param kind query string false "export or erasure"
+// @Param status query string false "pending, running, completed, failed or expired"
+// @Param limit query int false "at most 100"
+// @Success 200 {object} response.JSONResponse{data=[]service.DataRequestResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/data-requests [GET]
+func (h *HandlerImpl) GetMyDataRequests(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	h.getDataRequests(w, r, claims.Sub)
+}
+
+// GetDataRequests handler
+// @Summary GetDataRequests
+// @Description GetDataRequests for list the data requests of the organization, the latest first
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_uid query string false "requester uid"
+// @Param kind query string false "export or erasure"
+// @Param status query string false "pending, running, completed, failed or expired"
+// @Param limit query int false "at most 100"
+// @Success 200 {object} response.JSONResponse{data=[]service.DataRequestResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/data-requests [GET]
+func (h *HandlerImpl) GetDataRequests(w http.ResponseWriter, r *http.Request) {
+	h.getDataRequests(w, r, r.URL.Query().Get("user_uid"))
+}
+
+func (h *HandlerImpl) getDataRequests(w http.ResponseWriter, r *http.Request, userUID string) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	req := service.GetDataRequestsReq{
+		UserUID: userUID,
+		Kind:    r.URL.Query().Get("kind"),
+		Status:  r.URL.Query().Get("status"),
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil {
+			res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+			return
+		}
+		req.Limit = l
+	}
+
+	resp, err := h.Controller.UsersController.GetDataRequests(r.Context(), req)
+	if err != nil {
+		usecaseError(res, err, response.ErrInternalServerError).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// EraseUser handler
+// @Summary EraseUser
+// @Description EraseUser for anonymize the personal data of a user beneath the caller, keeping their uid for the records they created, and complete their pending erasure request
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "user uid"
+// @Param erasure body service.EraseUserReq true "Request Erase User"
+// @Success 200 {object} response.JSONResponse{data=service.DataRequestResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/users/{uid}/erase [POST]
+func (h *HandlerImpl) EraseUser(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.EraseUserReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.UID = mux.Vars(r)["uid"]
+	req.ActorUID = claims.Sub
+	req.ActorRoleUID = claims.RoleUID
+
+	resp, err := h.Controller.UsersController.EraseUser(r.Context(), req)
+	if err != nil {
+		usecaseError(res, err, response.ErrBadRequest).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
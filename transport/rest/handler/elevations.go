@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// CreateElevation handler
+// @Summary CreateElevation
+// @Description CreateElevation for request a role for a limited time, granted once an approver accepts it
+// @Tags Elevations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param elevations body service.CreateElevationReq true "Request Create Elevation"
+// @Success 200 {object} response.JSONResponse{data=service.ElevationResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/elevations [POST]
+func (h *HandlerImpl) CreateElevation(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.CreateElevationReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.UserUID = claims.Sub
+
+	resp, err := h.Controller.ElevationsController.CreateElevation(r.Context(), req)
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.APIStatusCreated().SetData(resp).Send(w)
+}
+
+// GetElevations handler
+// @Summary GetElevations
+// @Description GetElevations for list the elevation requests of the organization
+// @Tags Elevations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "pending, approved or denied"
+// @Param user_uid query string false "requester uid"
+// @Success 200 {object} response.JSONResponse{data=[]service.ElevationResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/elevations [GET]
+func (h *HandlerImpl) GetElevations(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	req := service.GetElevationsReq{
+		UserUID: r.URL.Query().Get("user_uid"),
+		Status:  r.URL.Query().Get("status"),
+	}
+
+	resp, err := h.Controller.ElevationsController.GetElevations(r.Context(), req)
+	if err != nil {
+		res.SetError(response.ErrInternalServerError).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// GetMyElevations handler
+// @Summary GetMyElevations
+// @Description GetMyElevations for list the elevation requests of the caller
+// @Tags Elevations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "pending, approved or denied"
+// @Success 200 {object} response.JSONResponse{data=[]service.ElevationResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/elevations/mine [GET]
+func (h *HandlerImpl) GetMyElevations(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	req := service.GetElevationsReq{
+		UserUID: claims.Sub,
+		Status:  r.URL.Query().Get("status"),
+	}
+
+	resp, err := h.Controller.ElevationsController.GetElevations(r.Context(), req)
+	if err != nil {
+		res.SetError(response.ErrInternalServerError).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// ApproveElevation handler
+// @Summary ApproveElevation
+// @Description ApproveElevation for grant the requested role until the requested duration has elapsed
+// @Tags Elevations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "elevation request uid"
+// @Param decision body service.DecideElevationReq false "Request Decide Elevation"
+// @Success 200 {object} response.JSONResponse{data=service.ElevationResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/elevations/{uid}/approve [POST]
+func (h *HandlerImpl) ApproveElevation(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	req, err := decideElevationReq(r)
+	if err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	resp, err := h.Controller.ElevationsController.ApproveElevation(r.Context(), req)
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// DenyElevation handler
+// @Summary DenyElevation
+// @Description DenyElevation for refuse an elevation request, the note explaining why is required
+// @Tags Elevations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "elevation request uid"
+// @Param decision body service.DecideElevationReq true "Request Decide Elevation"
+// @Success 200 {object} response.JSONResponse{data=service.ElevationResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/elevations/{uid}/deny [POST]
+func (h *HandlerImpl) DenyElevation(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	req, err := decideElevationReq(r)
+	if err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	resp, err := h.Controller.ElevationsController.DenyElevation(r.Context(), req)
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// decideElevationReq reads the optional decision body along with the request
+// uid and the approver from the claims.
+func decideElevationReq(r *http.Request) (req service.DecideElevationReq, err error) {
+
+	if r.ContentLength != 0 {
+		if err = decodeJSON(r, &req); err != nil {
+			return req, err
+		}
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.UID = mux.Vars(r)["uid"]
+	req.ActorUID = claims.Sub
+	req.ActorRoleUID = claims.RoleUID
+
+	return req, nil
+}
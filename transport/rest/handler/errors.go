@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"errors"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+)
+
+// errorOf maps a use case error to the response error it is sent as, fallback
+// being used for every error without a more specific status.
+func errorOf(err, fallback error) error {
+	if errors.Is(err, service.ErrForbidden) {
+		return response.ErrForbiddenResource
+	}
+	if errors.Is(err, service.ErrConflict) {
+		return response.ErrConflict
+	}
+	if errors.Is(err, service.ErrNotFound) {
+		return response.ErrNotFound
+	}
+	if errors.Is(err, service.ErrUnauthorized) {
+		return response.ErrUnauthorized
+	}
+	return fallback
+}
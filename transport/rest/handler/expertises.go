@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// CreateExpertise handler
+// @Summary CreateExpertise
+// @Description CreateExpertise for add an expertise to the catalog
+// @Tags Expertises
+// @Produce json
+// @Security ApiKeyAuth
+// @Param expertises body service.CreateExpertiseReq true "Request Create Expertise"
+// @Success 201 {object} response.JSONResponse{data=service.ExpertiseResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/expertises [POST]
+func (h *HandlerImpl) CreateExpertise(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.CreateExpertiseReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.CreatedBy = claims.Sub
+
+	resp, err := h.Controller.ExpertisesController.CreateExpertise(r.Context(), req)
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.APIStatusCreated().SetData(resp).Send(w)
+}
+
+// GetExpertises handler
+// @Summary GetExpertises
+// @Description GetExpertises for list the whole expertise catalog
+// @Tags Expertises
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} response.JSONResponse{data=[]service.ExpertiseResp}
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/expertises [GET]
+func (h *HandlerImpl) GetExpertises(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	resp, err := h.Controller.ExpertisesController.GetExpertises(r.Context())
+	if err != nil {
+		res.SetError(response.ErrInternalServerError).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// AutocompleteExpertises handler
+// @Summary AutocompleteExpertises
+// @Description AutocompleteExpertises for suggest the expertises whose name starts with the query
+// @Tags Expertises
+// @Produce json
+// @Security ApiKeyAuth
+// @Param q query string false "name prefix"
+// @Param limit query int false "max suggestions, 10 by default and 50 at most"
+// @Success 200 {object} response.JSONResponse{data=[]service.ExpertiseTagResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/expertises/autocomplete [GET]
+func (h *HandlerImpl) AutocompleteExpertises(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	req := service.AutocompleteExpertisesReq{
+		Query: r.URL.Query().Get("q"),
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil {
+			res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+			return
+		}
+		req.Limit = l
+	}
+
+	resp, err := h.Controller.ExpertisesController.AutocompleteExpertises(r.Context(), req)
+	if err != nil {
+		res.SetError(response.ErrInternalServerError).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// UpdateExpertise handler
+// @Summary UpdateExpertise
+// @Description UpdateExpertise for rename an expertise of the catalog
+// @Tags Expertises
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "expertise uid"
+// @Param expertises body service.UpdateExpertiseReq true "Request Update Expertise"
+// @Success 200 {object} response.JSONResponse{data=service.ExpertiseResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/expertises/{uid} [PUT]
+func (h *HandlerImpl) UpdateExpertise(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPut {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.UpdateExpertiseReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.UID = mux.Vars(r)["uid"]
+	req.UpdatedBy = claims.Sub
+
+	resp, err := h.Controller.ExpertisesController.UpdateExpertise(r.Context(), req)
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// DeleteExpertise handler
+// @Summary DeleteExpertise
+// @Description DeleteExpertise for remove an expertise from the catalog, and so from the profiles tagged with it
+// @Tags Expertises
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "expertise uid"
+// @Success 200 {object} response.JSONResponse().APIStatusSuccess()
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/expertises/{uid} [DELETE]
+func (h *HandlerImpl) DeleteExpertise(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodDelete {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	req := service.DeleteExpertiseReq{
+		UID:       mux.Vars(r)["uid"],
+		UpdatedBy: claims.Sub,
+	}
+
+	if err := h.Controller.ExpertisesController.DeleteExpertise(r.Context(), req); err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.APIStatusSuccess().Send(w)
+}
+
+// GetUserExpertises handler
+// @Summary GetUserExpertises
+// @Description GetUserExpertises for list the expertises a user is tagged with
+// @Tags Expertises
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users route"
+// @Success 200 {object} response.JSONResponse{data=[]service.ExpertiseTagResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/expertises [GET]
+// @Router /v1/users/{uid}/expertises [GET]
+func (h *HandlerImpl) GetUserExpertises(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	resp, err := h.Controller.UsersController.GetUserExpertises(r.Context(), service.GetProfileSectionReq{
+		ProfileSectionRef: sectionRef(r),
+	})
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// SetUserExpertises handler
+// @Summary SetUserExpertises
+// @Description SetUserExpertises for replace the expertises a user is tagged with
+// @Tags Expertises
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users route"
+// @Param expertises body service.SetUserExpertisesReq true "Request Set User Expertises"
+// @Success 200 {object} response.JSONResponse{data=[]service.ExpertiseTagResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/expertises [PUT]
+// @Router /v1/users/{uid}/expertises [PUT]
+func (h *HandlerImpl) SetUserExpertises(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPut {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.SetUserExpertisesReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.UserUID = sectionOwner(r, claims)
+	req.ActorUID = claims.Sub
+	req.ActorRoleUID = claims.RoleUID
+
+	resp, err := h.Controller.UsersController.SetUserExpertises(r.Context(), req)
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
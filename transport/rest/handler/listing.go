@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"fmt"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// listReq reads the filter[field]=value, filter[field][op]=value, sort and
+// page or cursor parameters shared by the list endpoints, e.g.
+// ?filter[country][in]=ID,SG&filter[name][match]=john&sort=-created_at,name&limit=20&page=2.
+// The fields and operators are checked by the listing itself.
+func listReq(query url.Values) (req service.ListReq, err error) {
+
+	limit, err := queryInt(query, "limit")
+	if err != nil {
+		return req, err
+	}
+
+	page, err := queryInt(query, "page")
+	if err != nil {
+		return req, err
+	}
+
+	if limit != nil {
+		req.Limit = *limit
+	}
+
+	if page != nil {
+		req.Page = *page
+	}
+
+	req.Cursor = query.Get("cursor")
+
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if !strings.HasPrefix(k, "filter[") {
+			continue
+		}
+
+		filter, err := listFilter(k, query.Get(k))
+		if err != nil {
+			return req, err
+		}
+		req.Filters = append(req.Filters, filter)
+	}
+
+	for _, v := range strings.Split(query.Get("sort"), ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		s := service.ListSort{Field: strings.TrimPrefix(v, "-"), Desc: strings.HasPrefix(v, "-")}
+		req.Sorts = append(req.Sorts, s)
+	}
+
+	return req, nil
+}
+
+// listFilter parses the filter[field] or filter[field][op] parameter key.
+func listFilter(key, value string) (filter service.ListFilter, err error) {
+
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(key, "filter["), "]"), "][")
+	if !strings.HasSuffix(key, "]") || len(parts) > 2 || parts[0] == "" {
+		return filter, fmt.Errorf("malformed filter %q", key)
+	}
+
+	filter.Field = parts[0]
+	filter.Op = constant.FilterEq.String()
+	if len(parts) == 2 {
+		filter.Op = parts[1]
+	}
+
+	if filter.Op == constant.FilterIn.String() {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				filter.Values = append(filter.Values, v)
+			}
+		}
+	} else {
+		filter.Values = []string{value}
+	}
+
+	return filter, nil
+}
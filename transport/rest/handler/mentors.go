@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SearchMentors handler
+// @Summary SearchMentors
+// @Description SearchMentors for find the mentors of the organization, with the facet counts of the search
+// @Tags Mentors
+// @Produce json
+// @Security ApiKeyAuth
+// @Param q query string false "name to look for"
+// @Param expertise query []string false "expertise uids, any of them" collectionFormat(multi)
+// @Param country query []string false "countries, any of them" collectionFormat(multi)
+// @Param language query []string false "ISO 639-1 language codes, any of them" collectionFormat(multi)
+// @Param gender query int false "gender"
+// @Param min_years query int false "min years of experience"
+// @Param max_years query int false "max years of experience"
+// @Param min_rating query number false "min average rating"
+// @Param sort query string false "relevance, rating or newest"
+// @Param page query int false "page"
+// @Param limit query int false "mentors per page, 10 by default and 100 at most"
+// @Success 200 {object} response.JSONResponse{data=service.SearchMentorsResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/mentors/search [GET]
+func (h *HandlerImpl) SearchMentors(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	query := r.URL.Query()
+
+	req := service.SearchMentorsReq{
+		Query:         query.Get("q"),
+		ExpertiseUIDs: query["expertise"],
+		Countries:     query["country"],
+		Languages:     query["language"],
+		Sort:          query.Get("sort"),
+	}
+
+	var errGender, errMinYears, errMaxYears, errMinRating error
+
+	req.Gender, errGender = queryInt(query, "gender")
+	req.MinYears, errMinYears = queryInt(query, "min_years")
+	req.MaxYears, errMaxYears = queryInt(query, "max_years")
+	req.MinRating, errMinRating = queryFloat(query, "min_rating")
+	page, errPage := queryInt(query, "page")
+	limit, errLimit := queryInt(query, "limit")
+
+	if err := errors.Join(errGender, errMinYears, errMaxYears, errMinRating, errPage, errLimit); err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	if page != nil {
+		req.Page = *page
+	}
+
+	if limit != nil {
+		req.Limit = *limit
+	}
+
+	resp, err := h.Controller.MentorsController.SearchMentors(r.Context(), req)
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// queryInt reads the optional integer parameter name of the query.
+func queryInt(query url.Values, name string) (*int, error) {
+
+	v := query.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return &n, nil
+}
+
+// queryFloat reads the optional decimal parameter name of the query.
+func queryFloat(query url.Values, name string) (*float64, error) {
+
+	v := query.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return &f, nil
+}
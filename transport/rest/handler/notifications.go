@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// GetMyNotifications handler
+// @Summary GetMyNotifications
+// @Description GetMyNotifications for list the notifications of the caller, the latest first
+// @Tags Notifications
+// @Produce json
+// @Security ApiKeyAuth
+// @Param unread query bool false "only the unread notifications"
+// @Param limit query int false "at most 100"
+// @Success 200 {object} response.JSONResponse{data=[]service.NotificationResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/notifications [GET]
+func (h *HandlerImpl) GetNotifications(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	req := service.GetNotificationsReq{UserUID: claims.Sub}
+
+	if unread := r.URL.Query().Get("unread"); unread != "" {
+		u, err := strconv.ParseBool(unread)
+		if err != nil {
+			res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+			return
+		}
+		req.Unread = u
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil {
+			res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+			return
+		}
+		req.Limit = l
+	}
+
+	resp, err := h.Controller.NotificationsController.GetNotifications(r.Context(), req)
+	if err != nil {
+		usecaseError(res, err, response.ErrInternalServerError).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// ReadNotification handler
+// @Summary ReadNotification
+// @Description ReadNotification for mark a notification of the caller as read
+// @Tags Notifications
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "notification uid"
+// @Success 200 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/notifications/{uid}/read [POST]
+func (h *HandlerImpl) ReadNotification(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	req := service.ReadNotificationReq{
+		UID:     mux.Vars(r)["uid"],
+		UserUID: claims.Sub,
+	}
+
+	if err := h.Controller.NotificationsController.ReadNotification(r.Context(), req); err != nil {
+		usecaseError(res, err, response.ErrInternalServerError).Send(w)
+		return
+	}
+
+	res.Send(w)
+}
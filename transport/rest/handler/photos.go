@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+	"io"
+	"net/http"
+)
+
+// maxPhotoRequestBytes caps the upload requests before they are parsed, the
+// use case enforcing the configured photo size.
+const maxPhotoRequestBytes = 32 << 20
+
+// UploadPhoto handler
+// @Summary UploadPhoto
+// @Description UploadPhoto for replace the photo of a user with a JPEG or PNG, stored without its metadata along with square thumbnails
+// @Tags Users
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users route"
+// @Param photo formData file true "JPEG or PNG photo"
+// @Success 200 {object} response.JSONResponse{data=service.PhotoResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/photo [PUT]
+// @Router /v1/users/{uid}/photo [PUT]
+func (h *HandlerImpl) UploadPhoto(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPut {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxPhotoRequestBytes)
+
+	file, _, err := r.FormFile("photo")
+	if err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(errors.Join(errors.New("photo file is required"), err).Error()).Send(w)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	resp, err := h.Controller.UsersController.UploadPhoto(r.Context(), service.UploadPhotoReq{
+		UserUID:      sectionOwner(r, claims),
+		ActorUID:     claims.Sub,
+		ActorRoleUID: claims.RoleUID,
+		Data:         data,
+	})
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// DeletePhoto handler
+// @Summary DeletePhoto
+// @Description DeletePhoto for clear the photo of a user
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users route"
+// @Success 200 {object} response.JSONResponse
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/photo [DELETE]
+// @Router /v1/users/{uid}/photo [DELETE]
+func (h *HandlerImpl) DeletePhoto(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodDelete {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	err := h.Controller.UsersController.DeletePhoto(r.Context(), service.DeletePhotoReq{
+		UserUID:      sectionOwner(r, claims),
+		ActorUID:     claims.Sub,
+		ActorRoleUID: claims.RoleUID,
+	})
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.APIStatusSuccess().Send(w)
+}
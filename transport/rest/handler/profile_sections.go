@@ -0,0 +1,432 @@
+package handler
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// The profile section handlers serve both /v1/me/... and /v1/users/{uid}/...,
+// the user being the caller when the path doesn't name one.
+func sectionOwner(r *http.Request, claims service.JWTClaims) string {
+	if uid := mux.Vars(r)["uid"]; uid != "" {
+		return uid
+	}
+	return claims.Sub
+}
+
+// sectionRef names the section entry a request is about, on behalf of the
+// caller.
+func sectionRef(r *http.Request) service.ProfileSectionRef {
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	return service.ProfileSectionRef{
+		UID:          mux.Vars(r)["section_uid"],
+		UserUID:      sectionOwner(r, claims),
+		ActorUID:     claims.Sub,
+		ActorRoleUID: claims.RoleUID,
+	}
+}
+
+// serveSection serves a request on a profile section with change, reading
+// the body of the POST and PUT requests, and answers the section as it stands
+// afterwards.
+func serveSection[Req any, PReq interface {
+	*Req
+	SetRef(ref service.ProfileSectionRef)
+}, Resp any](w http.ResponseWriter, r *http.Request, method string, change func(ctx context.Context, req Req) ([]Resp, error)) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != method {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req Req
+
+	if method == http.MethodPost || method == http.MethodPut {
+		if err := decodeJSON(r, &req); err != nil {
+			requestError(res, err).Send(w)
+			return
+		}
+	}
+	PReq(&req).SetRef(sectionRef(r))
+
+	resp, err := change(r.Context(), req)
+	if err != nil {
+		usecaseError(res, err, response.ErrBadRequest).Send(w)
+		return
+	}
+
+	if method == http.MethodPost {
+		res.APIStatusCreated()
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// GetEducations handler
+// @Summary GetEducations
+// @Description GetEducations for list the educations of a profile in their order
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Success 200 {object} response.JSONResponse{data=[]service.EducationResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/educations [GET]
+// @Router /v1/users/{uid}/educations [GET]
+func (h *HandlerImpl) GetEducations(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodGet, h.Controller.UsersController.GetEducations)
+}
+
+// CreateEducation handler
+// @Summary CreateEducation
+// @Description CreateEducation for add a education at the end of a profile section
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param educations body service.EducationReq true "Request Create Education"
+// @Success 201 {object} response.JSONResponse{data=[]service.EducationResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/educations [POST]
+// @Router /v1/users/{uid}/educations [POST]
+func (h *HandlerImpl) CreateEducation(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodPost, h.Controller.UsersController.CreateEducation)
+}
+
+// UpdateEducation handler
+// @Summary UpdateEducation
+// @Description UpdateEducation for change a education of a profile
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param section_uid path string true "education uid"
+// @Param educations body service.EducationReq true "Request Update Education"
+// @Success 200 {object} response.JSONResponse{data=[]service.EducationResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/educations/{section_uid} [PUT]
+// @Router /v1/users/{uid}/educations/{section_uid} [PUT]
+func (h *HandlerImpl) UpdateEducation(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodPut, h.Controller.UsersController.UpdateEducation)
+}
+
+// DeleteEducation handler
+// @Summary DeleteEducation
+// @Description DeleteEducation for remove a education from a profile
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param section_uid path string true "education uid"
+// @Success 200 {object} response.JSONResponse{data=[]service.EducationResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/educations/{section_uid} [DELETE]
+// @Router /v1/users/{uid}/educations/{section_uid} [DELETE]
+func (h *HandlerImpl) DeleteEducation(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodDelete, h.Controller.UsersController.DeleteEducation)
+}
+
+// ReorderEducations handler
+// @Summary ReorderEducations
+// @Description ReorderEducations for set the order of the educations of a profile, listing every one of them
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param order body service.ReorderProfileSectionReq true "Request Reorder Educations"
+// @Success 200 {object} response.JSONResponse{data=[]service.EducationResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/educations/order [PUT]
+// @Router /v1/users/{uid}/educations/order [PUT]
+func (h *HandlerImpl) ReorderEducations(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodPut, h.Controller.UsersController.ReorderEducations)
+}
+
+// GetWorkExperiences handler
+// @Summary GetWorkExperiences
+// @Description GetWorkExperiences for list the work experiences of a profile in their order
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Success 200 {object} response.JSONResponse{data=[]service.WorkExperienceResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/work-experiences [GET]
+// @Router /v1/users/{uid}/work-experiences [GET]
+func (h *HandlerImpl) GetWorkExperiences(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodGet, h.Controller.UsersController.GetWorkExperiences)
+}
+
+// CreateWorkExperience handler
+// @Summary CreateWorkExperience
+// @Description CreateWorkExperience for add a work experience at the end of a profile section
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param work-experiences body service.WorkExperienceReq true "Request Create WorkExperience"
+// @Success 201 {object} response.JSONResponse{data=[]service.WorkExperienceResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/work-experiences [POST]
+// @Router /v1/users/{uid}/work-experiences [POST]
+func (h *HandlerImpl) CreateWorkExperience(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodPost, h.Controller.UsersController.CreateWorkExperience)
+}
+
+// UpdateWorkExperience handler
+// @Summary UpdateWorkExperience
+// @Description UpdateWorkExperience for change a work experience of a profile
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param section_uid path string true "work experience uid"
+// @Param work-experiences body service.WorkExperienceReq true "Request Update WorkExperience"
+// @Success 200 {object} response.JSONResponse{data=[]service.WorkExperienceResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/work-experiences/{section_uid} [PUT]
+// @Router /v1/users/{uid}/work-experiences/{section_uid} [PUT]
+func (h *HandlerImpl) UpdateWorkExperience(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodPut, h.Controller.UsersController.UpdateWorkExperience)
+}
+
+// DeleteWorkExperience handler
+// @Summary DeleteWorkExperience
+// @Description DeleteWorkExperience for remove a work experience from a profile
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param section_uid path string true "work experience uid"
+// @Success 200 {object} response.JSONResponse{data=[]service.WorkExperienceResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/work-experiences/{section_uid} [DELETE]
+// @Router /v1/users/{uid}/work-experiences/{section_uid} [DELETE]
+func (h *HandlerImpl) DeleteWorkExperience(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodDelete, h.Controller.UsersController.DeleteWorkExperience)
+}
+
+// ReorderWorkExperiences handler
+// @Summary ReorderWorkExperiences
+// @Description ReorderWorkExperiences for set the order of the work experiences of a profile, listing every one of them
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param order body service.ReorderProfileSectionReq true "Request Reorder WorkExperiences"
+// @Success 200 {object} response.JSONResponse{data=[]service.WorkExperienceResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/work-experiences/order [PUT]
+// @Router /v1/users/{uid}/work-experiences/order [PUT]
+func (h *HandlerImpl) ReorderWorkExperiences(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodPut, h.Controller.UsersController.ReorderWorkExperiences)
+}
+
+// GetLanguages handler
+// @Summary GetLanguages
+// @Description GetLanguages for list the languages of a profile in their order
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Success 200 {object} response.JSONResponse{data=[]service.LanguageResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/languages [GET]
+// @Router /v1/users/{uid}/languages [GET]
+func (h *HandlerImpl) GetLanguages(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodGet, h.Controller.UsersController.GetLanguages)
+}
+
+// CreateLanguage handler
+// @Summary CreateLanguage
+// @Description CreateLanguage for add a language at the end of a profile section
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param languages body service.LanguageReq true "Request Create Language"
+// @Success 201 {object} response.JSONResponse{data=[]service.LanguageResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/languages [POST]
+// @Router /v1/users/{uid}/languages [POST]
+func (h *HandlerImpl) CreateLanguage(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodPost, h.Controller.UsersController.CreateLanguage)
+}
+
+// DeleteLanguage handler
+// @Summary DeleteLanguage
+// @Description DeleteLanguage for remove a language from a profile
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param section_uid path string true "language uid"
+// @Success 200 {object} response.JSONResponse{data=[]service.LanguageResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/languages/{section_uid} [DELETE]
+// @Router /v1/users/{uid}/languages/{section_uid} [DELETE]
+func (h *HandlerImpl) DeleteLanguage(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodDelete, h.Controller.UsersController.DeleteLanguage)
+}
+
+// ReorderLanguages handler
+// @Summary ReorderLanguages
+// @Description ReorderLanguages for set the order of the languages of a profile, listing every one of them
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param order body service.ReorderProfileSectionReq true "Request Reorder Languages"
+// @Success 200 {object} response.JSONResponse{data=[]service.LanguageResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/languages/order [PUT]
+// @Router /v1/users/{uid}/languages/order [PUT]
+func (h *HandlerImpl) ReorderLanguages(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodPut, h.Controller.UsersController.ReorderLanguages)
+}
+
+// GetSocials handler
+// @Summary GetSocials
+// @Description GetSocials for list the social links of a profile in their order
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Success 200 {object} response.JSONResponse{data=[]service.SocialResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/socials [GET]
+// @Router /v1/users/{uid}/socials [GET]
+func (h *HandlerImpl) GetSocials(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodGet, h.Controller.UsersController.GetSocials)
+}
+
+// CreateSocial handler
+// @Summary CreateSocial
+// @Description CreateSocial for add a social link at the end of a profile section
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param socials body service.SocialReq true "Request Create Social"
+// @Success 201 {object} response.JSONResponse{data=[]service.SocialResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/socials [POST]
+// @Router /v1/users/{uid}/socials [POST]
+func (h *HandlerImpl) CreateSocial(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodPost, h.Controller.UsersController.CreateSocial)
+}
+
+// UpdateSocial handler
+// @Summary UpdateSocial
+// @Description UpdateSocial for change a social link of a profile
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param section_uid path string true "social link uid"
+// @Param socials body service.SocialReq true "Request Update Social"
+// @Success 200 {object} response.JSONResponse{data=[]service.SocialResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/socials/{section_uid} [PUT]
+// @Router /v1/users/{uid}/socials/{section_uid} [PUT]
+func (h *HandlerImpl) UpdateSocial(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodPut, h.Controller.UsersController.UpdateSocial)
+}
+
+// DeleteSocial handler
+// @Summary DeleteSocial
+// @Description DeleteSocial for remove a social link from a profile
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param section_uid path string true "social link uid"
+// @Success 200 {object} response.JSONResponse{data=[]service.SocialResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/socials/{section_uid} [DELETE]
+// @Router /v1/users/{uid}/socials/{section_uid} [DELETE]
+func (h *HandlerImpl) DeleteSocial(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodDelete, h.Controller.UsersController.DeleteSocial)
+}
+
+// ReorderSocials handler
+// @Summary ReorderSocials
+// @Description ReorderSocials for set the order of the social links of a profile, listing every one of them
+// @Tags ProfileSections
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string false "user uid, on the /v1/users routes"
+// @Param order body service.ReorderProfileSectionReq true "Request Reorder Socials"
+// @Success 200 {object} response.JSONResponse{data=[]service.SocialResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/socials/order [PUT]
+// @Router /v1/users/{uid}/socials/order [PUT]
+func (h *HandlerImpl) ReorderSocials(w http.ResponseWriter, r *http.Request) {
+	serveSection(w, r, http.MethodPut, h.Controller.UsersController.ReorderSocials)
+}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"errors"
 	"github/yogabagas/join-app/domain/service"
 	"github/yogabagas/join-app/transport/rest/handler/response"
@@ -20,6 +19,7 @@ import (
 // @Param users body service.CreateResourcesReq true "Request Create Resources"
 // @Success 200 {object} response.JSONResponse().APIStatusCreated()
 // @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
 // @Failure 500 {object} response.JSONResponse
 // @Router /v1/resources [POST]
 func (h *HandlerImpl) CreateResources(w http.ResponseWriter, r *http.Request) {
@@ -33,8 +33,8 @@ func (h *HandlerImpl) CreateResources(w http.ResponseWriter, r *http.Request) {
 
 	var req service.CreateResourcesReq
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
 		return
 	}
 
@@ -82,3 +82,42 @@ func (h *HandlerImpl) GetResourcesByType(w http.ResponseWriter, r *http.Request)
 
 	res.SetData(resp).Send(w)
 }
+
+// GetResources handler
+// @Summary GetResources
+// @Description GetResources for list the resources of the organization
+// @Tags Resources
+// @Produce json
+// @Security ApiKeyAuth
+// @Param filter[field][op] query string false "filter on name, type, action, parent_uid or created_at; op defaults to eq, in takes comma separated values"
+// @Param sort query string false "comma separated name, type, action or created_at, - for descending e.g. type,name"
+// @Param limit query int false "limit data; default 10, max 100"
+// @Param page query int false "number of page; default 1"
+// @Param cursor query string false "next_cursor or prev_cursor of a page in the default order, in place of page"
+// @Success 200 {object} response.JSONResponse{data=service.GetResourcesResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/resources [GET]
+func (h *HandlerImpl) GetResources(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	req, err := listReq(r.URL.Query())
+	if err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	resp, err := h.Controller.ResourcesController.GetResources(r.Context(), req)
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
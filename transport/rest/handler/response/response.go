@@ -20,6 +20,7 @@ var (
 	ErrUnauthorized        = errors.New("Unauthorized")
 	ErrConflict            = errors.New("Conflict")
 	ErrMethodNotAllowed    = errors.New("Method not allowed")
+	ErrUnprocessable       = errors.New("Unprocessable entity")
 )
 
 const (
@@ -31,6 +32,7 @@ const (
 	StatusCodeForbidden                 = "403000"
 	StatusCodeNotFound                  = "404000"
 	StatusCodeConflict                  = "409000"
+	StatusCodeUnprocessable             = "422000"
 	StatusCodeGenericPreconditionFailed = "412000"
 	StatusCodeOTPLimitReached           = "412550"
 	StatusCodeNoLinkerExist             = "412553"
@@ -64,6 +66,8 @@ func GetErrorCode(err error) string {
 		return StatusCodeTimeoutError
 	case ErrMethodNotAllowed:
 		return StatusCodeMethodNotAllowed
+	case ErrUnprocessable:
+		return StatusCodeUnprocessable
 	case nil:
 		return StatusCodeGenericSuccess
 	default:
@@ -81,6 +85,8 @@ func GetHTTPStatus(code int) string {
 		return "internal server error"
 	case http.StatusUnauthorized:
 		return "unauthorized"
+	case http.StatusUnprocessableEntity:
+		return "unprocessable entity"
 	default:
 		return "undefined"
 	}
@@ -99,6 +105,7 @@ type JSONResponse struct {
 	StatusCode  int                    `json:"status_code"`
 	Status      string                 `json:"status"`
 	ErrorString string                 `json:"error,omitempty"`
+	Fields      map[string]FieldError  `json:"fields,omitempty"`
 	Error       error                  `json:"-"`
 	RealError   string                 `json:"-"`
 	Latency     string                 `json:"latency,omitempty"`
@@ -107,6 +114,13 @@ type JSONResponse struct {
 	Result      interface{}            `json:"result,omitempty"`
 }
 
+// FieldError tells why a field of the request is invalid, Code being stable
+// for clients to branch on and Message readable.
+type FieldError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
 func NewJSONResponse() *JSONResponse {
 	return &JSONResponse{Code: StatusCodeGenericSuccess, StatusCode: GetHTTPCode(StatusCodeGenericSuccess), Status: GetHTTPStatus(http.StatusOK), Log: map[string]interface{}{}}
 }
@@ -146,6 +160,11 @@ func (r *JSONResponse) SetMessage(msg string) *JSONResponse {
 	return r
 }
 
+func (r *JSONResponse) SetFields(fields map[string]FieldError) *JSONResponse {
+	r.Fields = fields
+	return r
+}
+
 func (r *JSONResponse) SetLatency(latency float64) *JSONResponse {
 	r.Latency = fmt.Sprintf("%.2f ms", latency)
 	return r
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// CreateRoleConstraint handler
+// @Summary CreateRoleConstraint
+// @Description CreateRoleConstraint for declare a set of roles nobody may hold at the same time
+// @Tags RoleConstraints
+// @Produce json
+// @Security ApiKeyAuth
+// @Param constraints body service.CreateRoleConstraintReq true "Request Create Role Constraint"
+// @Success 200 {object} response.JSONResponse{data=service.RoleConstraintResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/role-constraints [POST]
+func (h *HandlerImpl) CreateRoleConstraint(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.CreateRoleConstraintReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.CreatedBy = claims.Sub
+
+	resp, err := h.Controller.RoleConstraintsController.CreateRoleConstraint(r.Context(), req)
+	if err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.APIStatusCreated().SetData(resp).Send(w)
+}
+
+// GetRoleConstraints handler
+// @Summary GetRoleConstraints
+// @Description GetRoleConstraints for list the separation of duties constraints of the organization
+// @Tags RoleConstraints
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} response.JSONResponse{data=[]service.RoleConstraintResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/role-constraints [GET]
+func (h *HandlerImpl) GetRoleConstraints(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	resp, err := h.Controller.RoleConstraintsController.GetRoleConstraints(r.Context())
+	if err != nil {
+		res.SetError(response.ErrInternalServerError).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// DeleteRoleConstraint handler
+// @Summary DeleteRoleConstraint
+// @Description DeleteRoleConstraint for drop a separation of duties constraint
+// @Tags RoleConstraints
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "constraint uid"
+// @Success 200 {object} response.JSONResponse().APIStatusSuccess()
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/role-constraints/{uid} [DELETE]
+func (h *HandlerImpl) DeleteRoleConstraint(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodDelete {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	req := service.DeleteRoleConstraintReq{
+		UID:       mux.Vars(r)["uid"],
+		UpdatedBy: claims.Sub,
+	}
+
+	if err := h.Controller.RoleConstraintsController.DeleteRoleConstraint(r.Context(), req); err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.APIStatusSuccess().Send(w)
+}
+
+// GetRoleConstraintViolations handler
+// @Summary GetRoleConstraintViolations
+// @Description GetRoleConstraintViolations for list the users whose current or upcoming grants break a constraint
+// @Tags RoleConstraints
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} response.JSONResponse{data=[]service.RoleConstraintViolationResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/role-constraints/violations [GET]
+func (h *HandlerImpl) GetRoleConstraintViolations(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	resp, err := h.Controller.RoleConstraintsController.GetRoleConstraintViolations(r.Context())
+	if err != nil {
+		res.SetError(response.ErrInternalServerError).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
@@ -1,8 +1,8 @@
 package handler
 
 import (
-	"encoding/json"
 	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
 	"github/yogabagas/join-app/transport/rest/handler/response"
 	"net/http"
 )
@@ -16,6 +16,8 @@ import (
 // @Param roles body service.CreateRolesReq true "Request Create Role"
 // @Success 200 {object} response.JSONResponse().APIStatusCreated()
 // @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
 // @Failure 500 {object} response.JSONResponse
 // @Router /v1/roles [POST]
 func (h *HandlerImpl) CreateRoles(w http.ResponseWriter, r *http.Request) {
@@ -29,15 +31,58 @@ func (h *HandlerImpl) CreateRoles(w http.ResponseWriter, r *http.Request) {
 
 	var req service.CreateRolesReq
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
 		return
 	}
 
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.ActorRoleUID = claims.RoleUID
+	req.CreatedBy = claims.Sub
+
 	if err := h.Controller.RolesController.CreateRoles(r.Context(), req); err != nil {
-		res.SetError(response.ErrInternalServerError).SetMessage(err.Error()).Send(w)
+		usecaseError(res, err, response.ErrInternalServerError).Send(w)
 		return
 	}
 
 	res.APIStatusCreated().Send(w)
 }
+
+// GetRoles handler
+// @Summary GetRoles
+// @Description GetRoles for list the roles of the organization
+// @Tags Roles
+// @Produce json
+// @Security ApiKeyAuth
+// @Param filter[field][op] query string false "filter on name (eq, in, like, prefix, match), parent_uid or created_at; op defaults to eq, in takes comma separated values"
+// @Param sort query string false "comma separated name or created_at, - for descending e.g. -created_at"
+// @Param limit query int false "limit data; default 10, max 100"
+// @Param page query int false "number of page; default 1"
+// @Param cursor query string false "next_cursor or prev_cursor of a page in the default order, in place of page"
+// @Success 200 {object} response.JSONResponse{data=service.GetRolesResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/roles [GET]
+func (h *HandlerImpl) GetRoles(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	req, err := listReq(r.URL.Query())
+	if err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	resp, err := h.Controller.RolesController.GetRoles(r.Context(), req)
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxImportRequestBytes caps the import requests, the use case bounding the
+// rows they hold.
+const maxImportRequestBytes = 10 << 20
+
+// ImportUsers handler
+// @Summary ImportUsers
+// @Description ImportUsers for create users with their credentials and role from a CSV with the columns first_name, last_name, email, birthdate, gender, username, password and role, plus the optional country and bio. Each row is validated and reported, the valid rows being created in batches unless on a dry run
+// @Tags Users
+// @Accept multipart/form-data,text/csv
+// @Produce json
+// @Security ApiKeyAuth
+// @Param file formData file false "CSV file, or the CSV as the text/csv body"
+// @Param dry_run query bool false "validate the rows without creating them"
+// @Success 200 {object} response.JSONResponse{data=service.ImportUsersResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/users/import [POST]
+func (h *HandlerImpl) ImportUsers(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	dryRun := false
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			res.SetError(response.ErrBadRequest).SetMessage("dry_run must be a boolean").Send(w)
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportRequestBytes)
+
+	body := io.Reader(r.Body)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			res.SetError(response.ErrBadRequest).SetMessage(errors.Join(errors.New("csv file is required"), err).Error()).Send(w)
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	resp, err := h.Controller.UsersController.ImportUsers(r.Context(), service.ImportUsersReq{
+		Data:         data,
+		DryRun:       dryRun,
+		ActorUID:     claims.Sub,
+		ActorRoleUID: claims.RoleUID,
+	})
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// ExportUsers handler
+// @Summary ExportUsers
+// @Description ExportUsers for download every user matched by the filters and sorts of GetUsersWithPagination, as CSV importable back or as JSON lines
+// @Tags Users
+// @Produce text/csv,application/x-ndjson
+// @Security ApiKeyAuth
+// @Param format query string false "csv or jsonl; default csv"
+// @Param filter[field][op] query string false "same filters as GET /v1/users"
+// @Param sort query string false "comma separated fields, - for descending e.g. -created_at,name"
+// @Success 200 {file} file
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/users/export [GET]
+func (h *HandlerImpl) ExportUsers(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	query := r.URL.Query()
+
+	req, err := listReq(query)
+	if err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	if name := query.Get("name"); name != "" {
+		req.Filters = append(req.Filters, service.ListFilter{Field: "name", Op: constant.FilterMatch.String(), Values: []string{name}})
+	}
+
+	format := query.Get("format")
+	if format == "" {
+		format = constant.ExportCSV.String()
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == constant.ExportJSONL.String() {
+		contentType = "application/x-ndjson"
+	}
+
+	out := &exportResponseWriter{ResponseWriter: w, header: func(h http.Header) {
+		h.Set("Content-Type", contentType)
+		h.Set("Content-Disposition", `attachment; filename="users.`+format+`"`)
+		h.Set("X-Content-Type-Options", "nosniff")
+	}}
+
+	err = h.Controller.UsersController.ExportUsers(r.Context(), service.ExportUsersReq{
+		List:   req,
+		Format: format,
+		Writer: out,
+	})
+	if err != nil {
+		if out.written {
+			log.Println("error export users", err)
+			return
+		}
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+	}
+}
+
+// exportResponseWriter sets the headers of an export on its first write, so
+// an export failing before writing anything still answers a JSON error.
+type exportResponseWriter struct {
+	http.ResponseWriter
+	header  func(h http.Header)
+	written bool
+}
+
+func (ew *exportResponseWriter) Write(b []byte) (int, error) {
+	if !ew.written {
+		ew.written = true
+		ew.header(ew.Header())
+	}
+	return ew.ResponseWriter.Write(b)
+}
@@ -1,11 +1,15 @@
 package handler
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
 	"github/yogabagas/join-app/transport/rest/handler/response"
+	"io"
 	"net/http"
-	"strconv"
+
+	"github.com/gorilla/mux"
 )
 
 // CreateUsers handler
@@ -14,8 +18,11 @@ import (
 // @Tags Users
 // @Produce json
 // @Param users body service.CreateUsersReq true "Request Create User"
+// @Param X-Organization header string false "organization uid or slug, the default organization when unset"
 // @Success 200 {object} response.JSONResponse().APIStatusCreated()
 // @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
 // @Failure 500 {object} response.JSONResponse
 // @Router /v1/users [POST]
 func (h *HandlerImpl) CreateUsers(w http.ResponseWriter, r *http.Request) {
@@ -29,14 +36,14 @@ func (h *HandlerImpl) CreateUsers(w http.ResponseWriter, r *http.Request) {
 
 	var req service.CreateUsersReq
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
 		return
 	}
 
 	err := h.Controller.UsersController.CreateUsers(r.Context(), req)
 	if err != nil {
-		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		usecaseError(res, err, response.ErrBadRequest).Send(w)
 		return
 	}
 
@@ -49,9 +56,12 @@ func (h *HandlerImpl) CreateUsers(w http.ResponseWriter, r *http.Request) {
 // @Tags Users
 // @Produce json
 // @Security ApiKeyAuth
-// @Param name query string false "user fullname e.g John Doe"
-// @Param limit query int false "limit data; default 10"
+// @Param name query string false "user fullname e.g John Doe, same as filter[name][match]"
+// @Param filter[field][op] query string false "filter on name (match, like, prefix), first_name, last_name, email, country, gender, birthdate, created_at, role, status (active or suspended) or expertise; op defaults to eq, in takes comma separated values"
+// @Param sort query string false "comma separated fields, - for descending e.g. -created_at,name"
+// @Param limit query int false "limit data; default 10, max 100"
 // @Param page query int false "number of page; default 1"
+// @Param cursor query string false "next_cursor or prev_cursor of a page in the default order, in place of page"
 // @Success 200 {object} response.JSONResponse{data=service.GetUsersWithPaginationResp}
 // @Failure 400 {object} response.JSONResponse
 // @Failure 500 {object} response.JSONResponse
@@ -65,37 +75,367 @@ func (h *HandlerImpl) GetUsersWithPagination(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	var req service.GetUsersWithPaginationReq
+	query := r.URL.Query()
+
+	req, err := listReq(query)
+	if err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	if name := query.Get("name"); name != "" {
+		req.Filters = append(req.Filters, service.ListFilter{Field: "name", Op: constant.FilterMatch.String(), Values: []string{name}})
+	}
 
-	if name := r.URL.Query().Get("name"); name != "" {
-		req.Fullname = name
+	resp, err := h.Controller.UsersController.GetUsersWithPagination(r.Context(), req)
+	if err != nil {
+		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		return
 	}
 
-	var limitToInt int
-	if limit := r.URL.Query().Get("limit"); limit != "" {
-		limitToInt, _ = strconv.Atoi(limit)
+	res.SetData(resp).Send(w)
+}
+
+// GetUser handler
+// @Summary GetUser
+// @Description GetUser for get the full profile of a user, open to the user and the roles above theirs
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "user uid"
+// @Success 200 {object} response.JSONResponse{data=service.UserDetailResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/users/{uid} [GET]
+func (h *HandlerImpl) GetUser(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
 	}
 
-	if limitToInt <= 0 {
-		limitToInt = 10
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	resp, err := h.Controller.UsersController.GetUser(r.Context(), service.GetUserReq{
+		UID:          mux.Vars(r)["uid"],
+		ActorUID:     claims.Sub,
+		ActorRoleUID: claims.RoleUID,
+	})
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
 	}
-	req.Limit = limitToInt
 
-	var pageToInt int
-	if page := r.URL.Query().Get("page"); page != "" {
-		pageToInt, _ = strconv.Atoi(page)
+	res.SetData(resp).Send(w)
+}
+
+// UpdateUser handler
+// @Summary UpdateUser
+// @Description UpdateUser for update the profile of a user, open to the user and the roles above theirs
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "user uid"
+// @Param users body service.UpdateProfileReq true "Request Update Profile"
+// @Success 200 {object} response.JSONResponse{data=service.UserDetailResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/users/{uid} [PATCH]
+func (h *HandlerImpl) UpdateUser(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPatch {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
 	}
 
-	if pageToInt <= 0 {
-		pageToInt = 1
+	var req service.UpdateProfileReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
 	}
-	req.Page = pageToInt
 
-	resp, err := h.Controller.UsersController.GetUsersWithPagination(r.Context(), req)
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.UID = mux.Vars(r)["uid"]
+	req.ActorUID = claims.Sub
+	req.ActorRoleUID = claims.RoleUID
+
+	resp, err := h.Controller.UsersController.UpdateProfile(r.Context(), req)
 	if err != nil {
-		res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// DeleteUser handler
+// @Summary DeleteUser
+// @Description DeleteUser for soft-delete a user and revoke their sessions
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "user uid"
+// @Success 200 {object} response.JSONResponse().APIStatusSuccess()
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/users/{uid} [DELETE]
+func (h *HandlerImpl) DeleteUser(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodDelete {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	err := h.Controller.UsersController.DeleteUser(r.Context(), service.DeleteUserReq{
+		UID:          mux.Vars(r)["uid"],
+		ActorUID:     claims.Sub,
+		ActorRoleUID: claims.RoleUID,
+	})
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.APIStatusSuccess().Send(w)
+}
+
+// RestoreUser handler
+// @Summary RestoreUser
+// @Description RestoreUser for bring back a soft-deleted user
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "user uid"
+// @Success 200 {object} response.JSONResponse{data=service.UserDetailResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 404 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/users/{uid}/restore [POST]
+func (h *HandlerImpl) RestoreUser(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	resp, err := h.Controller.UsersController.RestoreUser(r.Context(), service.RestoreUserReq{
+		UID:          mux.Vars(r)["uid"],
+		ActorUID:     claims.Sub,
+		ActorRoleUID: claims.RoleUID,
+	})
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// SuspendUser handler
+// @Summary SuspendUser
+// @Description SuspendUser for deactivate the account of a user beneath the caller with a reason, logging them out of every session and refusing their logins until reactivated
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "user uid"
+// @Param status body service.UpdateUserStatusReq true "Request Suspend User"
+// @Success 200 {object} response.JSONResponse{data=service.UserDetailResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/users/{uid}/suspend [POST]
+func (h *HandlerImpl) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	h.updateUserStatus(w, r, h.Controller.UsersController.SuspendUser)
+}
+
+// ReactivateUser handler
+// @Summary ReactivateUser
+// @Description ReactivateUser for let a suspended user log in again, the reason being optional
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path string true "user uid"
+// @Param status body service.UpdateUserStatusReq false "Request Reactivate User"
+// @Success 200 {object} response.JSONResponse{data=service.UserDetailResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 403 {object} response.JSONResponse
+// @Failure 409 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/users/{uid}/reactivate [POST]
+func (h *HandlerImpl) ReactivateUser(w http.ResponseWriter, r *http.Request) {
+	h.updateUserStatus(w, r, h.Controller.UsersController.ReactivateUser)
+}
+
+// updateUserStatus reads the status request of the user in path, an empty
+// body standing for no reason, and answers the user as update leaves them.
+func (h *HandlerImpl) updateUserStatus(w http.ResponseWriter, r *http.Request,
+	update func(ctx context.Context, req service.UpdateUserStatusReq) (service.UserDetailResp, error)) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.UpdateUserStatusReq
+
+	if err := decodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.UID = mux.Vars(r)["uid"]
+	req.ActorUID = claims.Sub
+	req.ActorRoleUID = claims.RoleUID
+
+	resp, err := update(r.Context(), req)
+	if err != nil {
+		usecaseError(res, err, response.ErrBadRequest).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// GetMe handler
+// @Summary GetMe
+// @Description GetMe for get the full profile of the caller
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} response.JSONResponse{data=service.UserDetailResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me [GET]
+func (h *HandlerImpl) GetMe(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodGet {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+
+	resp, err := h.Controller.UsersController.GetUser(r.Context(), service.GetUserReq{
+		UID:          claims.Sub,
+		ActorUID:     claims.Sub,
+		ActorRoleUID: claims.RoleUID,
+	})
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
 		return
 	}
 
 	res.SetData(resp).Send(w)
 }
+
+// UpdateMe handler
+// @Summary UpdateMe
+// @Description UpdateMe for update the profile of the caller, only the fields sent are changed
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param users body service.UpdateProfileReq true "Request Update Profile"
+// @Success 200 {object} response.JSONResponse{data=service.UserDetailResp}
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me [PATCH]
+func (h *HandlerImpl) UpdateMe(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPatch {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.UpdateProfileReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.UID = claims.Sub
+	req.ActorUID = claims.Sub
+	req.ActorRoleUID = claims.RoleUID
+
+	resp, err := h.Controller.UsersController.UpdateProfile(r.Context(), req)
+	if err != nil {
+		res.SetError(errorOf(err, response.ErrBadRequest)).SetMessage(err.Error()).Send(w)
+		return
+	}
+
+	res.SetData(resp).Send(w)
+}
+
+// ChangePassword handler
+// @Summary ChangePassword
+// @Description ChangePassword for set a new password of the caller given the current one. The new password has to pass the password policy and differ from the last ones, and every other session of the caller is logged out
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param password body service.ChangePasswordReq true "Request Change Password"
+// @Success 200 {object} response.JSONResponse().APIStatusSuccess()
+// @Failure 400 {object} response.JSONResponse
+// @Failure 422 {object} response.JSONResponse
+// @Failure 500 {object} response.JSONResponse
+// @Router /v1/me/password [POST]
+func (h *HandlerImpl) ChangePassword(w http.ResponseWriter, r *http.Request) {
+
+	res := response.NewJSONResponse()
+
+	if r.Method != http.MethodPost {
+		res.SetError(response.ErrMethodNotAllowed).Send(w)
+		return
+	}
+
+	var req service.ChangePasswordReq
+
+	if err := decodeJSON(r, &req); err != nil {
+		requestError(res, err).Send(w)
+		return
+	}
+
+	claims := r.Context().Value(constant.Claim).(service.JWTClaims)
+	req.UserUID = claims.Sub
+	req.SessionUID = claims.SessionUID
+
+	err := h.Controller.UsersController.ChangePassword(r.Context(), req)
+	if err != nil {
+		usecaseError(res, err, response.ErrBadRequest).Send(w)
+		return
+	}
+
+	res.APIStatusSuccess().Send(w)
+}
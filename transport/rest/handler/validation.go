@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+	"net/http"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// decodeJSON decodes the JSON body into req, then runs its Validate method
+// when the request has one.
+func decodeJSON(r *http.Request, req interface{}) error {
+
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return err
+	}
+
+	return validation.Validate(req)
+}
+
+// requestError answers 422 with the invalid fields of a request that failed
+// its validation, and 400 when the request couldn't be read at all.
+func requestError(res *response.JSONResponse, err error) *response.JSONResponse {
+
+	fields := map[string]response.FieldError{}
+	if !fieldErrors(fields, "", err) {
+		return res.SetError(response.ErrBadRequest).SetMessage(err.Error())
+	}
+
+	return res.SetError(response.ErrUnprocessable).SetMessage("request has invalid fields").SetFields(fields)
+}
+
+// usecaseError answers a use case error keyed by field as requestError does,
+// and maps any other error through errorOf.
+func usecaseError(res *response.JSONResponse, err, fallback error) *response.JSONResponse {
+
+	fields := map[string]response.FieldError{}
+	if !fieldErrors(fields, "", err) {
+		return res.SetError(errorOf(err, fallback)).SetMessage(err.Error())
+	}
+
+	return res.SetError(response.ErrUnprocessable).SetMessage("request has invalid fields").SetFields(fields)
+}
+
+// fieldErrors flattens the validation errors into fields, keyed by the path
+// of the field such as checks.0.user_uid.
+func fieldErrors(fields map[string]response.FieldError, path string, err error) bool {
+
+	var errs validation.Errors
+	if errors.As(err, &errs) {
+		for key, v := range errs {
+			if path != "" {
+				key = path + "." + key
+			}
+			if !fieldErrors(fields, key, v) {
+				fields[key] = response.FieldError{Code: "invalid", Message: v.Error()}
+			}
+		}
+		return true
+	}
+
+	var e validation.Error
+	if errors.As(err, &e) {
+		fields[path] = response.FieldError{Code: strings.TrimPrefix(e.Code(), "validation_"), Message: e.Message()}
+		return true
+	}
+
+	return false
+}
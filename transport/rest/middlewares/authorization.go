@@ -0,0 +1,83 @@
+package middlewares
+
+import (
+	"errors"
+	"github/yogabagas/join-app/config"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+	"github/yogabagas/join-app/transport/rest/handler/response"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// AuthorizationMiddleware checks one of the roles the caller holds in its
+// validity window, not only the role of the token, is granted an API resource
+// for the matched route (path template as name, HTTP method as action) and
+// that the condition attached to the access rule holds for the request. The
+// route variables are the attributes of the resource, which the use case
+// completes with owner_uid. The routes that didn't match any template are
+// denied.
+func (mi *MiddlewareImpl) AuthorizationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if !config.GlobalCfg.Authorization.Enforced() || mi.isWhitelist(r.URL.Path, r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		res := response.NewJSONResponse()
+
+		claims, ok := r.Context().Value(constant.Claim).(service.JWTClaims)
+		if !ok {
+			res.SetError(response.ErrUnauthorized).SetMessage(errors.New("missing authorized claims").Error()).Send(w)
+			return
+		}
+
+		route := mux.CurrentRoute(r)
+		if route == nil {
+			res.SetError(response.ErrForbiddenResource).SetMessage(errors.New("route is not registered as a resource").Error()).Send(w)
+			return
+		}
+
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			res.SetError(response.ErrInternalServerError).SetMessage(err.Error()).Send(w)
+			return
+		}
+
+		attrs := make(map[string]interface{})
+		for k, v := range mux.Vars(r) {
+			attrs[k] = v
+		}
+
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+
+		resp, err := mi.appController.AuthzController.Authorize(r.Context(), service.AuthorizeReq{
+			Claims:   claims,
+			Resource: tpl,
+			Action:   r.Method,
+			Attrs:    attrs,
+			Context: map[string]interface{}{
+				"method": r.Method,
+				"path":   r.URL.Path,
+				"ip":     ip,
+			},
+		})
+		if err != nil {
+			res.SetError(response.ErrInternalServerError).SetMessage(err.Error()).Send(w)
+			return
+		}
+
+		if !resp.Allowed {
+			res.SetError(response.ErrForbiddenResource).SetMessage(errors.New("role is not allowed to access the resource").Error()).Send(w)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
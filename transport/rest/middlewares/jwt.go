@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github/yogabagas/join-app/adapter/controller"
 	"github/yogabagas/join-app/config"
+	"github/yogabagas/join-app/domain/repository/sql"
 	"github/yogabagas/join-app/domain/service"
 	"github/yogabagas/join-app/registry"
 	"github/yogabagas/join-app/shared/constant"
@@ -19,6 +20,7 @@ type MiddlewareImpl struct {
 
 type Middleware interface {
 	AuthenticationMiddleware(next http.Handler) http.Handler
+	AuthorizationMiddleware(next http.Handler) http.Handler
 	CORSHandle(next http.Handler) http.Handler
 }
 
@@ -28,14 +30,25 @@ func NewMiddleware(r registry.Registry) Middleware {
 	}
 }
 
-// AuthenticationMiddleware validates the JWT token.
+// AuthenticationMiddleware validates the JWT token and scopes the request to
+// the organization carried by it. Whitelisted requests are scoped to the
+// organization named by the X-Organization header, or the default one.
 func (mi *MiddlewareImpl) AuthenticationMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
+		res := response.NewJSONResponse()
+
+		if mi.isWhitelist(r.URL.Path, r.Method) {
+			org, err := mi.appController.OrganizationsController.ResolveOrganization(ctx, service.ResolveOrganizationReq{
+				Key: r.Header.Get(constant.OrganizationHeader),
+			})
+			if err != nil {
+				res.SetError(response.ErrBadRequest).SetMessage(err.Error()).Send(w)
+				return
+			}
 
-		if !mi.isWhitelist(r.URL.Path, r.Method) {
-			res := response.NewJSONResponse()
-
+			ctx = sql.WithTenant(ctx, org.UID)
+		} else {
 			token := r.Header.Get("Authorization")
 
 			if token == "" {
@@ -61,10 +74,8 @@ func (mi *MiddlewareImpl) isWhitelist(endpoint, method string) bool {
 
 	for _, v := range config.GlobalCfg.Whitelist.APIs {
 
-		if strings.ContainsAny(v.Endpoint, "*") {
-			if v.Endpoint[:strings.Index(v.Endpoint, "*")] == endpoint[:strings.Index(v.Endpoint, "*")] {
-				v.Endpoint = endpoint
-			}
+		if i := strings.Index(v.Endpoint, "*"); i >= 0 && strings.HasPrefix(endpoint, v.Endpoint[:i]) {
+			v.Endpoint = endpoint
 		}
 
 		mapAPI[v.Endpoint] = append(mapAPI[v.Endpoint], v.Methods...)
@@ -96,12 +107,18 @@ func (mi *MiddlewareImpl) parseJwt(ctx context.Context, token string) (context.C
 	claims := service.JWTClaims{
 		Sub:        resp.UserUID,
 		RoleUID:    resp.RoleUID,
+		OrgUID:     resp.OrgUID,
+		SessionUID: resp.SessionUID,
 		LastActive: resp.LastActive,
 		ExpiredAt:  resp.ExpiredAt,
 	}
 
+	ctx = sql.WithTenant(ctx, claims.OrgUID)
+
 	auth, _ := authzSvc.HasAuthenticated(ctx, service.HasAuthenticatedReq{
-		Sub: claims.Sub,
+		Sub:        claims.Sub,
+		RoleUID:    claims.RoleUID,
+		SessionUID: claims.SessionUID,
 	})
 
 	if !auth.Valid {
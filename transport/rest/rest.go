@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"github/yogabagas/join-app/config"
@@ -34,6 +35,7 @@ type Option struct {
 
 type Handler struct {
 	option    *Option
+	registry  registry.Registry
 	listenErr chan error
 }
 
@@ -53,6 +55,18 @@ type Handler struct {
 // @name Authorization
 func NewRest(o *Option) *Handler {
 
+	h := NewRouter(o)
+
+	go h.registry.ListenCacheInvalidation(context.Background())
+
+	return h
+}
+
+// NewRouter registers the routes without starting the cache invalidation
+// listener NewRest runs along the server, for the commands that only walk
+// the routes.
+func NewRouter(o *Option) *Handler {
+
 	reg := registry.NewRegistry(
 		registry.NewSQLConn(o.Sql),
 		registry.NewCache(o.Redis),
@@ -67,6 +81,7 @@ func NewRest(o *Option) *Handler {
 
 	r := mux.NewRouter()
 	r.Use(middleware.AuthenticationMiddleware)
+	r.Use(middleware.AuthorizationMiddleware)
 
 	// r.Use(middleware.CORSHandle)
 
@@ -89,13 +104,21 @@ func NewRest(o *Option) *Handler {
 	groupV1.NewUsersV1(handlerImpl, v1)
 	groupV1.NewRolesV1(handlerImpl, v1)
 	groupV1.NewResourcesV1(handlerImpl, v1)
+	groupV1.NewRoleConstraintsV1(handlerImpl, v1)
+	groupV1.NewElevationsV1(handlerImpl, v1)
+	groupV1.NewNotificationsV1(handlerImpl, v1)
+	groupV1.NewAccessReviewsV1(handlerImpl, v1)
+	groupV1.NewProfileSectionsV1(handlerImpl, v1)
+	groupV1.NewExpertisesV1(handlerImpl, v1)
+	groupV1.NewMentorsV1(handlerImpl, v1)
+	groupV1.NewPhotosV1(handlerImpl, v1)
 
 	o.Mux = r
 
 	return &Handler{
-		option: o,
+		option:   o,
+		registry: reg,
 	}
-
 }
 
 func (h *Handler) Serve() {
@@ -112,6 +135,22 @@ func (h *Handler) Serve() {
 	h.listenErr <- srv.ListenAndServe()
 }
 
+// SweepExpiredAuthz runs the expired grant sweeper until ctx is done.
+func (h *Handler) SweepExpiredAuthz(ctx context.Context) {
+	h.registry.SweepExpiredAuthz(ctx)
+}
+
+// CloseOverdueAccessReviews runs the overdue access review job until ctx is
+// done.
+func (h *Handler) CloseOverdueAccessReviews(ctx context.Context) {
+	h.registry.CloseOverdueAccessReviews(ctx)
+}
+
+// ProcessDataRequests runs the personal data request job until ctx is done.
+func (h *Handler) ProcessDataRequests(ctx context.Context) {
+	h.registry.ProcessDataRequests(ctx)
+}
+
 func (h *Handler) ListenError() <-chan error {
 	return h.listenErr
 }
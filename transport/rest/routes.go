@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"context"
+	"github/yogabagas/join-app/domain/service"
+	"github/yogabagas/join-app/shared/constant"
+
+	"github.com/gorilla/mux"
+)
+
+// Routes lists every route of the router that is bound to HTTP methods, using
+// the path template so that it matches the name of its API resource.
+func (h *Handler) Routes() (routes []service.APIRoute, err error) {
+
+	err = h.option.Mux.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+
+		for _, m := range methods {
+			routes = append(routes, service.APIRoute{
+				Path:   tpl,
+				Method: m,
+			})
+		}
+		return nil
+	})
+
+	return routes, err
+}
+
+// SyncResources upserts an API resource for every route of the router.
+func (h *Handler) SyncResources(ctx context.Context, dryRun bool) (resp service.SyncAPIResourcesResp, err error) {
+
+	routes, err := h.Routes()
+	if err != nil {
+		return resp, err
+	}
+
+	return h.registry.NewAppController().ResourcesController.SyncAPIResources(ctx, service.SyncAPIResourcesReq{
+		Routes:    routes,
+		DryRun:    dryRun,
+		CreatedBy: constant.System,
+	})
+}